@@ -1,20 +1,37 @@
 package main
 
-import ()
+import (
+	"go/ast"
+	"go/parser"
+	"go/types"
+	"strconv"
+	"strings"
+)
 
+// JavaApiConv converts a go function, method or value to java. a template replaces the whole call,
+// {0}, {1}.. are the arguments, {args} is the argument list and {recv} is the receiver of a method
 type JavaApiConv struct {
 	method     string
+	template string
+	args []int
 	imports *JavaImport
-	argSeparator *ArgSeparator
+	extraImports []*JavaImport
+	printArgs *PrintArgs
+	results []string
 }
 
-type ArgSeparator struct {
-	sep string
+// PrintArgs marks the arguments printed by the Fmt runtime, from the index of the first one
+type PrintArgs struct {
+	from int
 }
 
+// JavaTypeConv converts a go type to java, {0}, {1}.. in the type name are the generic parameters.
+// zero is the zero value of the values of the type, they are null without it
 type JavaTypeConv struct {
 	typeName     string
 	imports *JavaImport
+	extraImports []*JavaImport
+	zero string
 }
 
 type JavaImport struct {
@@ -23,14 +40,583 @@ type JavaImport struct {
 }
 
 var JI_DATE = &JavaImport{"Date", "java.util.Date"}
-var JI_ARRAY_UTIL = &JavaImport{"ArrayUtil", "org.go2j.util.ArrayUtil"}
+var JI_GO_STRING = &JavaImport{"GoString", "org.go2j.util.GoString"}
+var JI_GO_MAP = &JavaImport{"GoMap", "org.go2j.util.GoMap"}
+var JI_GO_OBJECTS = &JavaImport{"GoObjects", "org.go2j.util.GoObjects"}
+var JI_GO_NUMBERS = &JavaImport{"GoNumbers", "org.go2j.util.GoNumbers"}
+var JI_GO_ERROR = &JavaImport{"GoError", "org.go2j.util.GoError"}
+var JI_GO_ERRORS = &JavaImport{"GoErrors", "org.go2j.util.GoErrors"}
+var JI_GO_EXCEPTION = &JavaImport{"GoException", "org.go2j.util.GoException"}
+var JI_FMT = &JavaImport{"Fmt", "org.go2j.util.Fmt"}
+var JI_GO_TYPE = &JavaImport{"GoType", "org.go2j.util.GoType"}
+var JI_GO_STRINGS = &JavaImport{"GoStrings", "org.go2j.util.GoStrings"}
+var JI_STRCONV = &JavaImport{"Strconv", "org.go2j.util.Strconv"}
+var JI_GO_MATH = &JavaImport{"GoMath", "org.go2j.util.GoMath"}
+var JI_GO_BITS = &JavaImport{"GoBits", "org.go2j.util.GoBits"}
+var JI_GO_RAND = &JavaImport{"GoRand", "org.go2j.util.GoRand"}
+var JI_RANDOM = &JavaImport{"Random", "java.util.Random"}
+var JI_GO_SORT = &JavaImport{"GoSort", "org.go2j.util.GoSort"}
+var JI_GO_SLICES = &JavaImport{"GoSlices", "org.go2j.util.GoSlices"}
+var JI_GO_MAPS = &JavaImport{"GoMaps", "org.go2j.util.GoMaps"}
+var JI_GO_PTR = &JavaImport{"GoPtr", "org.go2j.util.GoPtr"}
 
 var apiConvs = map[string]*JavaApiConv{
-	"fmt.Println": &JavaApiConv{method: "System.out.println", argSeparator: &ArgSeparator{sep: " + \" \" + "}},
+	"fmt.Println": &JavaApiConv{method: "Fmt.Println", imports: JI_FMT, printArgs: &PrintArgs{from: 0}, results: []string{"int", "error"}},
+	"fmt.Print": &JavaApiConv{method: "Fmt.Print", imports: JI_FMT, printArgs: &PrintArgs{from: 0}, results: []string{"int", "error"}},
+	"fmt.Printf": &JavaApiConv{method: "Fmt.Printf", imports: JI_FMT, printArgs: &PrintArgs{from: 1}, results: []string{"int", "error"}},
+	"fmt.Sprintf": &JavaApiConv{method: "Fmt.Sprintf", imports: JI_FMT, printArgs: &PrintArgs{from: 1}, results: []string{"string"}},
+	"fmt.Fprintf": &JavaApiConv{method: "Fmt.Fprintf", imports: JI_FMT, printArgs: &PrintArgs{from: 2}, results: []string{"int", "error"}},
+	"fmt.Sprint": &JavaApiConv{method: "Fmt.Sprint", imports: JI_FMT, printArgs: &PrintArgs{from: 0}, results: []string{"string"}},
+	"fmt.Sprintln": &JavaApiConv{method: "Fmt.Sprintln", imports: JI_FMT, printArgs: &PrintArgs{from: 0}, results: []string{"string"}},
+	"fmt.Fprint": &JavaApiConv{method: "Fmt.Fprint", imports: JI_FMT, printArgs: &PrintArgs{from: 1}, results: []string{"int", "error"}},
+	"fmt.Fprintln": &JavaApiConv{method: "Fmt.Fprintln", imports: JI_FMT, printArgs: &PrintArgs{from: 1}, results: []string{"int", "error"}},
+	"fmt.Errorf": &JavaApiConv{method: "Fmt.Errorf", imports: JI_FMT, printArgs: &PrintArgs{from: 1}, results: []string{"error"}},
+	"os.Stdout": &JavaApiConv{method: "System.out"},
+	"os.Stderr": &JavaApiConv{method: "System.err"},
+	"errors.New": &JavaApiConv{method: "GoErrors.New", imports: JI_GO_ERRORS, results: []string{"error"}},
+	"errors.Is": &JavaApiConv{method: "GoErrors.Is", imports: JI_GO_ERRORS, results: []string{"bool"}},
+	"errors.Unwrap": &JavaApiConv{method: "GoErrors.Unwrap", imports: JI_GO_ERRORS, results: []string{"error"}},
+	"errors.Join": &JavaApiConv{method: "GoErrors.Join", imports: JI_GO_ERRORS, results: []string{"error"}},
 	"time.Now": &JavaApiConv{method: "new Date", imports: JI_DATE},
-	"append": &JavaApiConv{method: "ArrayUtil.append", imports: JI_ARRAY_UTIL},
+	"strings.Contains": &JavaApiConv{method: "GoStrings.Contains", imports: JI_GO_STRINGS, results: []string{"bool"}},
+	"strings.ContainsAny": &JavaApiConv{method: "GoStrings.ContainsAny", imports: JI_GO_STRINGS, results: []string{"bool"}},
+	"strings.ContainsRune": &JavaApiConv{method: "GoStrings.ContainsRune", imports: JI_GO_STRINGS, results: []string{"bool"}},
+	"strings.Count": &JavaApiConv{method: "GoStrings.Count", imports: JI_GO_STRINGS, results: []string{"int"}},
+	"strings.HasPrefix": &JavaApiConv{method: "GoStrings.HasPrefix", imports: JI_GO_STRINGS, results: []string{"bool"}},
+	"strings.HasSuffix": &JavaApiConv{method: "GoStrings.HasSuffix", imports: JI_GO_STRINGS, results: []string{"bool"}},
+	"strings.Index": &JavaApiConv{method: "GoStrings.Index", imports: JI_GO_STRINGS, results: []string{"int"}},
+	"strings.LastIndex": &JavaApiConv{method: "GoStrings.LastIndex", imports: JI_GO_STRINGS, results: []string{"int"}},
+	"strings.IndexByte": &JavaApiConv{method: "GoStrings.IndexByte", imports: JI_GO_STRINGS, results: []string{"int"}},
+	"strings.LastIndexByte": &JavaApiConv{method: "GoStrings.LastIndexByte", imports: JI_GO_STRINGS, results: []string{"int"}},
+	"strings.IndexRune": &JavaApiConv{method: "GoStrings.IndexRune", imports: JI_GO_STRINGS, results: []string{"int"}},
+	"strings.IndexAny": &JavaApiConv{method: "GoStrings.IndexAny", imports: JI_GO_STRINGS, results: []string{"int"}},
+	"strings.Split": &JavaApiConv{method: "GoStrings.Split", imports: JI_GO_STRINGS, results: []string{"[]string"}},
+	"strings.SplitN": &JavaApiConv{method: "GoStrings.SplitN", imports: JI_GO_STRINGS, results: []string{"[]string"}},
+	"strings.SplitAfter": &JavaApiConv{method: "GoStrings.SplitAfter", imports: JI_GO_STRINGS, results: []string{"[]string"}},
+	"strings.SplitAfterN": &JavaApiConv{method: "GoStrings.SplitAfterN", imports: JI_GO_STRINGS, results: []string{"[]string"}},
+	"strings.Fields": &JavaApiConv{method: "GoStrings.Fields", imports: JI_GO_STRINGS, results: []string{"[]string"}},
+	"strings.Join": &JavaApiConv{method: "GoStrings.Join", imports: JI_GO_STRINGS, results: []string{"string"}},
+	"strings.Repeat": &JavaApiConv{method: "GoStrings.Repeat", imports: JI_GO_STRINGS, results: []string{"string"}},
+	"strings.Replace": &JavaApiConv{method: "GoStrings.Replace", imports: JI_GO_STRINGS, results: []string{"string"}},
+	"strings.ReplaceAll": &JavaApiConv{method: "GoStrings.ReplaceAll", imports: JI_GO_STRINGS, results: []string{"string"}},
+	"strings.ToUpper": &JavaApiConv{method: "GoStrings.ToUpper", imports: JI_GO_STRINGS, results: []string{"string"}},
+	"strings.ToLower": &JavaApiConv{method: "GoStrings.ToLower", imports: JI_GO_STRINGS, results: []string{"string"}},
+	"strings.TrimSpace": &JavaApiConv{method: "GoStrings.TrimSpace", imports: JI_GO_STRINGS, results: []string{"string"}},
+	"strings.Trim": &JavaApiConv{method: "GoStrings.Trim", imports: JI_GO_STRINGS, results: []string{"string"}},
+	"strings.TrimLeft": &JavaApiConv{method: "GoStrings.TrimLeft", imports: JI_GO_STRINGS, results: []string{"string"}},
+	"strings.TrimRight": &JavaApiConv{method: "GoStrings.TrimRight", imports: JI_GO_STRINGS, results: []string{"string"}},
+	"strings.TrimPrefix": &JavaApiConv{method: "GoStrings.TrimPrefix", imports: JI_GO_STRINGS, results: []string{"string"}},
+	"strings.TrimSuffix": &JavaApiConv{method: "GoStrings.TrimSuffix", imports: JI_GO_STRINGS, results: []string{"string"}},
+	"strings.Cut": &JavaApiConv{method: "GoStrings.Cut", imports: JI_GO_STRINGS, results: []string{"string", "string", "bool"}},
+	"strings.CutPrefix": &JavaApiConv{method: "GoStrings.CutPrefix", imports: JI_GO_STRINGS, results: []string{"string", "bool"}},
+	"strings.CutSuffix": &JavaApiConv{method: "GoStrings.CutSuffix", imports: JI_GO_STRINGS, results: []string{"string", "bool"}},
+	"strings.EqualFold": &JavaApiConv{method: "GoStrings.EqualFold", imports: JI_GO_STRINGS, results: []string{"bool"}},
+	"strings.Compare": &JavaApiConv{method: "GoStrings.Compare", imports: JI_GO_STRINGS, results: []string{"int"}},
+	"strings.NewReplacer": &JavaApiConv{method: "GoStrings.NewReplacer", imports: JI_GO_STRINGS, results: []string{"*strings.Replacer"}},
+	"strconv.Itoa": &JavaApiConv{method: "Strconv.Itoa", imports: JI_STRCONV, results: []string{"string"}},
+	"strconv.Atoi": &JavaApiConv{method: "Strconv.Atoi", imports: JI_STRCONV, results: []string{"int", "error"}},
+	"strconv.ParseInt": &JavaApiConv{method: "Strconv.ParseInt", imports: JI_STRCONV, results: []string{"int64", "error"}},
+	"strconv.ParseUint": &JavaApiConv{method: "Strconv.ParseUint", imports: JI_STRCONV, results: []string{"uint64", "error"}},
+	"strconv.ParseFloat": &JavaApiConv{method: "Strconv.ParseFloat", imports: JI_STRCONV, results: []string{"float64", "error"}},
+	"strconv.ParseBool": &JavaApiConv{method: "Strconv.ParseBool", imports: JI_STRCONV, results: []string{"bool", "error"}},
+	"strconv.FormatInt": &JavaApiConv{method: "Strconv.FormatInt", imports: JI_STRCONV, results: []string{"string"}},
+	"strconv.FormatUint": &JavaApiConv{method: "Strconv.FormatUint", imports: JI_STRCONV, results: []string{"string"}},
+	"strconv.FormatFloat": &JavaApiConv{method: "Strconv.FormatFloat", imports: JI_STRCONV, results: []string{"string"}},
+	"strconv.FormatBool": &JavaApiConv{method: "Strconv.FormatBool", imports: JI_STRCONV, results: []string{"string"}},
+	"strconv.Quote": &JavaApiConv{method: "Strconv.Quote", imports: JI_STRCONV, results: []string{"string"}},
+	"strconv.QuoteToASCII": &JavaApiConv{method: "Strconv.QuoteToASCII", imports: JI_STRCONV, results: []string{"string"}},
+	"strconv.QuoteRune": &JavaApiConv{method: "Strconv.QuoteRune", imports: JI_STRCONV, results: []string{"string"}},
+	"strconv.QuoteRuneToASCII": &JavaApiConv{method: "Strconv.QuoteRuneToASCII", imports: JI_STRCONV, results: []string{"string"}},
+	"strconv.Unquote": &JavaApiConv{method: "Strconv.Unquote", imports: JI_STRCONV, results: []string{"string", "error"}},
+	"strconv.CanBackquote": &JavaApiConv{method: "Strconv.CanBackquote", imports: JI_STRCONV, results: []string{"bool"}},
+	"strconv.IsPrint": &JavaApiConv{method: "Strconv.IsPrint", imports: JI_STRCONV, results: []string{"bool"}},
+	"strconv.AppendInt": &JavaApiConv{method: "Strconv.AppendInt", imports: JI_STRCONV, results: []string{"[]byte"}},
+	"strconv.AppendUint": &JavaApiConv{method: "Strconv.AppendUint", imports: JI_STRCONV, results: []string{"[]byte"}},
+	"strconv.AppendFloat": &JavaApiConv{method: "Strconv.AppendFloat", imports: JI_STRCONV, results: []string{"[]byte"}},
+	"strconv.AppendBool": &JavaApiConv{method: "Strconv.AppendBool", imports: JI_STRCONV, results: []string{"[]byte"}},
+	"strconv.AppendQuote": &JavaApiConv{method: "Strconv.AppendQuote", imports: JI_STRCONV, results: []string{"[]byte"}},
+	"strconv.ErrRange": &JavaApiConv{method: "Strconv.ErrRange", imports: JI_STRCONV},
+	"strconv.ErrSyntax": &JavaApiConv{method: "Strconv.ErrSyntax", imports: JI_STRCONV},
+	"strconv.IntSize": &JavaApiConv{method: "Strconv.IntSize", imports: JI_STRCONV},
+	"math.Abs": &JavaApiConv{method: "Math.abs", results: []string{"float64"}},
+	"math.Sqrt": &JavaApiConv{method: "Math.sqrt", results: []string{"float64"}},
+	"math.Cbrt": &JavaApiConv{method: "Math.cbrt", results: []string{"float64"}},
+	"math.Floor": &JavaApiConv{method: "Math.floor", results: []string{"float64"}},
+	"math.Ceil": &JavaApiConv{method: "Math.ceil", results: []string{"float64"}},
+	"math.Exp": &JavaApiConv{method: "Math.exp", results: []string{"float64"}},
+	"math.Expm1": &JavaApiConv{method: "Math.expm1", results: []string{"float64"}},
+	"math.Log": &JavaApiConv{method: "Math.log", results: []string{"float64"}},
+	"math.Log10": &JavaApiConv{method: "Math.log10", results: []string{"float64"}},
+	"math.Log1p": &JavaApiConv{method: "Math.log1p", results: []string{"float64"}},
+	"math.Sin": &JavaApiConv{method: "Math.sin", results: []string{"float64"}},
+	"math.Cos": &JavaApiConv{method: "Math.cos", results: []string{"float64"}},
+	"math.Tan": &JavaApiConv{method: "Math.tan", results: []string{"float64"}},
+	"math.Asin": &JavaApiConv{method: "Math.asin", results: []string{"float64"}},
+	"math.Acos": &JavaApiConv{method: "Math.acos", results: []string{"float64"}},
+	"math.Atan": &JavaApiConv{method: "Math.atan", results: []string{"float64"}},
+	"math.Atan2": &JavaApiConv{method: "Math.atan2", results: []string{"float64"}},
+	"math.Sinh": &JavaApiConv{method: "Math.sinh", results: []string{"float64"}},
+	"math.Cosh": &JavaApiConv{method: "Math.cosh", results: []string{"float64"}},
+	"math.Tanh": &JavaApiConv{method: "Math.tanh", results: []string{"float64"}},
+	"math.Hypot": &JavaApiConv{method: "Math.hypot", results: []string{"float64"}},
+	"math.RoundToEven": &JavaApiConv{method: "Math.rint", results: []string{"float64"}},
+	"math.Remainder": &JavaApiConv{method: "Math.IEEEremainder", results: []string{"float64"}},
+	"math.Copysign": &JavaApiConv{method: "Math.copySign", results: []string{"float64"}},
+	"math.Ldexp": &JavaApiConv{method: "Math.scalb", results: []string{"float64"}},
+	"math.IsNaN": &JavaApiConv{method: "Double.isNaN", results: []string{"bool"}},
+	"math.Float64bits": &JavaApiConv{method: "Double.doubleToRawLongBits", results: []string{"uint64"}},
+	"math.Float64frombits": &JavaApiConv{method: "Double.longBitsToDouble", results: []string{"float64"}},
+	"math.Float32bits": &JavaApiConv{method: "Float.floatToRawIntBits", results: []string{"uint32"}},
+	"math.Float32frombits": &JavaApiConv{method: "Float.intBitsToFloat", results: []string{"float32"}},
+	"math.Inf": &JavaApiConv{method: "GoMath.Inf", imports: JI_GO_MATH, results: []string{"float64"}},
+	"math.NaN": &JavaApiConv{method: "GoMath.NaN", imports: JI_GO_MATH, results: []string{"float64"}},
+	"math.IsInf": &JavaApiConv{method: "GoMath.IsInf", imports: JI_GO_MATH, results: []string{"bool"}},
+	"math.Signbit": &JavaApiConv{method: "GoMath.Signbit", imports: JI_GO_MATH, results: []string{"bool"}},
+	"math.Mod": &JavaApiConv{method: "GoMath.Mod", imports: JI_GO_MATH, results: []string{"float64"}},
+	"math.Dim": &JavaApiConv{method: "GoMath.Dim", imports: JI_GO_MATH, results: []string{"float64"}},
+	"math.Trunc": &JavaApiConv{method: "GoMath.Trunc", imports: JI_GO_MATH, results: []string{"float64"}},
+	"math.Round": &JavaApiConv{method: "GoMath.Round", imports: JI_GO_MATH, results: []string{"float64"}},
+	"math.Max": &JavaApiConv{method: "GoMath.Max", imports: JI_GO_MATH, results: []string{"float64"}},
+	"math.Min": &JavaApiConv{method: "GoMath.Min", imports: JI_GO_MATH, results: []string{"float64"}},
+	"math.Pow": &JavaApiConv{method: "GoMath.Pow", imports: JI_GO_MATH, results: []string{"float64"}},
+	"math.Pow10": &JavaApiConv{method: "GoMath.Pow10", imports: JI_GO_MATH, results: []string{"float64"}},
+	"math.Log2": &JavaApiConv{method: "GoMath.Log2", imports: JI_GO_MATH, results: []string{"float64"}},
+	"math.Frexp": &JavaApiConv{method: "GoMath.Frexp", imports: JI_GO_MATH, results: []string{"float64", "int"}},
+	"math.Modf": &JavaApiConv{method: "GoMath.Modf", imports: JI_GO_MATH, results: []string{"float64", "float64"}},
+	"math.Pi": &JavaApiConv{method: "Math.PI", results: []string{"float64"}},
+	"math.E": &JavaApiConv{method: "Math.E", results: []string{"float64"}},
+	"math.Phi": &JavaApiConv{method: "GoMath.Phi", imports: JI_GO_MATH, results: []string{"float64"}},
+	"math.Sqrt2": &JavaApiConv{method: "GoMath.Sqrt2", imports: JI_GO_MATH, results: []string{"float64"}},
+	"math.SqrtE": &JavaApiConv{method: "GoMath.SqrtE", imports: JI_GO_MATH, results: []string{"float64"}},
+	"math.SqrtPi": &JavaApiConv{method: "GoMath.SqrtPi", imports: JI_GO_MATH, results: []string{"float64"}},
+	"math.SqrtPhi": &JavaApiConv{method: "GoMath.SqrtPhi", imports: JI_GO_MATH, results: []string{"float64"}},
+	"math.Ln2": &JavaApiConv{method: "GoMath.Ln2", imports: JI_GO_MATH, results: []string{"float64"}},
+	"math.Log2E": &JavaApiConv{method: "GoMath.Log2E", imports: JI_GO_MATH, results: []string{"float64"}},
+	"math.Ln10": &JavaApiConv{method: "GoMath.Ln10", imports: JI_GO_MATH, results: []string{"float64"}},
+	"math.Log10E": &JavaApiConv{method: "GoMath.Log10E", imports: JI_GO_MATH, results: []string{"float64"}},
+	"math.MaxFloat64": &JavaApiConv{method: "Double.MAX_VALUE", results: []string{"float64"}},
+	"math.SmallestNonzeroFloat64": &JavaApiConv{method: "Double.MIN_VALUE", results: []string{"float64"}},
+	"math.MaxFloat32": &JavaApiConv{method: "Float.MAX_VALUE", results: []string{"float64"}},
+	"math.SmallestNonzeroFloat32": &JavaApiConv{method: "Float.MIN_VALUE", results: []string{"float64"}},
+	"math.MaxInt": &JavaApiConv{method: "Integer.MAX_VALUE", results: []string{"int"}},
+	"math.MinInt": &JavaApiConv{method: "Integer.MIN_VALUE", results: []string{"int"}},
+	"math.MaxInt8": &JavaApiConv{method: "Byte.MAX_VALUE", results: []string{"int"}},
+	"math.MinInt8": &JavaApiConv{method: "Byte.MIN_VALUE", results: []string{"int"}},
+	"math.MaxInt16": &JavaApiConv{method: "Short.MAX_VALUE", results: []string{"int"}},
+	"math.MinInt16": &JavaApiConv{method: "Short.MIN_VALUE", results: []string{"int"}},
+	"math.MaxInt32": &JavaApiConv{method: "Integer.MAX_VALUE", results: []string{"int"}},
+	"math.MinInt32": &JavaApiConv{method: "Integer.MIN_VALUE", results: []string{"int"}},
+	"math.MaxInt64": &JavaApiConv{method: "Long.MAX_VALUE", results: []string{"int64"}},
+	"math.MinInt64": &JavaApiConv{method: "Long.MIN_VALUE", results: []string{"int64"}},
+	"bits.UintSize": &JavaApiConv{method: "GoBits.UintSize", imports: JI_GO_BITS, results: []string{"int"}},
+	"bits.LeadingZeros": &JavaApiConv{method: "Integer.numberOfLeadingZeros", results: []string{"int"}},
+	"bits.LeadingZeros8": &JavaApiConv{method: "GoBits.LeadingZeros8", imports: JI_GO_BITS, results: []string{"int"}},
+	"bits.LeadingZeros16": &JavaApiConv{method: "GoBits.LeadingZeros16", imports: JI_GO_BITS, results: []string{"int"}},
+	"bits.LeadingZeros32": &JavaApiConv{method: "Integer.numberOfLeadingZeros", results: []string{"int"}},
+	"bits.LeadingZeros64": &JavaApiConv{method: "Long.numberOfLeadingZeros", results: []string{"int"}},
+	"bits.TrailingZeros": &JavaApiConv{method: "Integer.numberOfTrailingZeros", results: []string{"int"}},
+	"bits.TrailingZeros8": &JavaApiConv{method: "GoBits.TrailingZeros8", imports: JI_GO_BITS, results: []string{"int"}},
+	"bits.TrailingZeros16": &JavaApiConv{method: "GoBits.TrailingZeros16", imports: JI_GO_BITS, results: []string{"int"}},
+	"bits.TrailingZeros32": &JavaApiConv{method: "Integer.numberOfTrailingZeros", results: []string{"int"}},
+	"bits.TrailingZeros64": &JavaApiConv{method: "Long.numberOfTrailingZeros", results: []string{"int"}},
+	"bits.OnesCount": &JavaApiConv{method: "Integer.bitCount", results: []string{"int"}},
+	"bits.OnesCount8": &JavaApiConv{method: "GoBits.OnesCount8", imports: JI_GO_BITS, results: []string{"int"}},
+	"bits.OnesCount16": &JavaApiConv{method: "GoBits.OnesCount16", imports: JI_GO_BITS, results: []string{"int"}},
+	"bits.OnesCount32": &JavaApiConv{method: "Integer.bitCount", results: []string{"int"}},
+	"bits.OnesCount64": &JavaApiConv{method: "Long.bitCount", results: []string{"int"}},
+	"bits.RotateLeft": &JavaApiConv{method: "Integer.rotateLeft", results: []string{"uint"}},
+	"bits.RotateLeft8": &JavaApiConv{method: "GoBits.RotateLeft8", imports: JI_GO_BITS, results: []string{"uint8"}},
+	"bits.RotateLeft16": &JavaApiConv{method: "GoBits.RotateLeft16", imports: JI_GO_BITS, results: []string{"uint16"}},
+	"bits.RotateLeft32": &JavaApiConv{method: "Integer.rotateLeft", results: []string{"uint32"}},
+	"bits.RotateLeft64": &JavaApiConv{method: "Long.rotateLeft", results: []string{"uint64"}},
+	"bits.Reverse": &JavaApiConv{method: "Integer.reverse", results: []string{"uint"}},
+	"bits.Reverse8": &JavaApiConv{method: "GoBits.Reverse8", imports: JI_GO_BITS, results: []string{"uint8"}},
+	"bits.Reverse16": &JavaApiConv{method: "GoBits.Reverse16", imports: JI_GO_BITS, results: []string{"uint16"}},
+	"bits.Reverse32": &JavaApiConv{method: "Integer.reverse", results: []string{"uint32"}},
+	"bits.Reverse64": &JavaApiConv{method: "Long.reverse", results: []string{"uint64"}},
+	"bits.ReverseBytes": &JavaApiConv{method: "Integer.reverseBytes", results: []string{"uint"}},
+	"bits.ReverseBytes16": &JavaApiConv{method: "Short.reverseBytes", results: []string{"uint16"}},
+	"bits.ReverseBytes32": &JavaApiConv{method: "Integer.reverseBytes", results: []string{"uint32"}},
+	"bits.ReverseBytes64": &JavaApiConv{method: "Long.reverseBytes", results: []string{"uint64"}},
+	"bits.Len": &JavaApiConv{method: "GoBits.Len", imports: JI_GO_BITS, results: []string{"int"}},
+	"bits.Len8": &JavaApiConv{method: "GoBits.Len8", imports: JI_GO_BITS, results: []string{"int"}},
+	"bits.Len16": &JavaApiConv{method: "GoBits.Len16", imports: JI_GO_BITS, results: []string{"int"}},
+	"bits.Len32": &JavaApiConv{method: "GoBits.Len", imports: JI_GO_BITS, results: []string{"int"}},
+	"bits.Len64": &JavaApiConv{method: "GoBits.Len64", imports: JI_GO_BITS, results: []string{"int"}},
+	"bits.Add": &JavaApiConv{method: "GoBits.Add32", imports: JI_GO_BITS, results: []string{"uint", "uint"}},
+	"bits.Add32": &JavaApiConv{method: "GoBits.Add32", imports: JI_GO_BITS, results: []string{"uint32", "uint32"}},
+	"bits.Add64": &JavaApiConv{method: "GoBits.Add64", imports: JI_GO_BITS, results: []string{"uint64", "uint64"}},
+	"bits.Sub": &JavaApiConv{method: "GoBits.Sub32", imports: JI_GO_BITS, results: []string{"uint", "uint"}},
+	"bits.Sub32": &JavaApiConv{method: "GoBits.Sub32", imports: JI_GO_BITS, results: []string{"uint32", "uint32"}},
+	"bits.Sub64": &JavaApiConv{method: "GoBits.Sub64", imports: JI_GO_BITS, results: []string{"uint64", "uint64"}},
+	"bits.Mul": &JavaApiConv{method: "GoBits.Mul32", imports: JI_GO_BITS, results: []string{"uint", "uint"}},
+	"bits.Mul32": &JavaApiConv{method: "GoBits.Mul32", imports: JI_GO_BITS, results: []string{"uint32", "uint32"}},
+	"bits.Mul64": &JavaApiConv{method: "GoBits.Mul64", imports: JI_GO_BITS, results: []string{"uint64", "uint64"}},
+	"bits.Div": &JavaApiConv{method: "GoBits.Div32", imports: JI_GO_BITS, results: []string{"uint", "uint"}},
+	"bits.Div32": &JavaApiConv{method: "GoBits.Div32", imports: JI_GO_BITS, results: []string{"uint32", "uint32"}},
+	"bits.Div64": &JavaApiConv{method: "GoBits.Div64", imports: JI_GO_BITS, results: []string{"uint64", "uint64"}},
+	"bits.Rem": &JavaApiConv{method: "GoBits.Rem32", imports: JI_GO_BITS, results: []string{"uint"}},
+	"bits.Rem32": &JavaApiConv{method: "GoBits.Rem32", imports: JI_GO_BITS, results: []string{"uint32"}},
+	"bits.Rem64": &JavaApiConv{method: "GoBits.Rem64", imports: JI_GO_BITS, results: []string{"uint64"}},
+	"rand.Seed": &JavaApiConv{method: "GoRand.global.Seed", imports: JI_GO_RAND},
+	"rand.Int63": &JavaApiConv{method: "GoRand.global.Int63", imports: JI_GO_RAND, results: []string{"int64"}},
+	"rand.Uint32": &JavaApiConv{method: "GoRand.global.Uint32", imports: JI_GO_RAND, results: []string{"uint32"}},
+	"rand.Uint64": &JavaApiConv{method: "GoRand.global.Uint64", imports: JI_GO_RAND, results: []string{"uint64"}},
+	"rand.Int31": &JavaApiConv{method: "GoRand.global.Int31", imports: JI_GO_RAND, results: []string{"int32"}},
+	"rand.Int": &JavaApiConv{method: "GoRand.global.Int", imports: JI_GO_RAND, results: []string{"int"}},
+	"rand.Int63n": &JavaApiConv{method: "GoRand.global.Int63n", imports: JI_GO_RAND, results: []string{"int64"}},
+	"rand.Int31n": &JavaApiConv{method: "GoRand.global.Int31n", imports: JI_GO_RAND, results: []string{"int32"}},
+	"rand.Intn": &JavaApiConv{method: "GoRand.global.Intn", imports: JI_GO_RAND, results: []string{"int"}},
+	"rand.Float64": &JavaApiConv{method: "GoRand.global.Float64", imports: JI_GO_RAND, results: []string{"float64"}},
+	"rand.Float32": &JavaApiConv{method: "GoRand.global.Float32", imports: JI_GO_RAND, results: []string{"float32"}},
+	"rand.NormFloat64": &JavaApiConv{method: "GoRand.global.NormFloat64", imports: JI_GO_RAND, results: []string{"float64"}},
+	"rand.Perm": &JavaApiConv{method: "GoRand.global.Perm", imports: JI_GO_RAND, results: []string{"[]int"}},
+	"rand.Shuffle": &JavaApiConv{method: "GoRand.global.Shuffle", imports: JI_GO_RAND},
+	"rand.NewSource": &JavaApiConv{method: "GoRand.NewSource", imports: JI_GO_RAND, results: []string{"rand.Source"}},
+	"rand.New": &JavaApiConv{method: "GoRand.New", imports: JI_GO_RAND, results: []string{"*rand.Rand"}},
+	"sort.Sort": &JavaApiConv{method: "GoSort.Sort", imports: JI_GO_SORT},
+	"sort.Stable": &JavaApiConv{method: "GoSort.Stable", imports: JI_GO_SORT},
+	"sort.IsSorted": &JavaApiConv{method: "GoSort.IsSorted", imports: JI_GO_SORT, results: []string{"bool"}},
+	"sort.Reverse": &JavaApiConv{method: "GoSort.Reverse", imports: JI_GO_SORT, results: []string{"sort.Interface"}},
+	"sort.Slice": &JavaApiConv{method: "GoSort.Slice", imports: JI_GO_SORT},
+	"sort.SliceStable": &JavaApiConv{method: "GoSort.SliceStable", imports: JI_GO_SORT},
+	"sort.SliceIsSorted": &JavaApiConv{method: "GoSort.SliceIsSorted", imports: JI_GO_SORT, results: []string{"bool"}},
+	"sort.Search": &JavaApiConv{method: "GoSort.Search", imports: JI_GO_SORT, results: []string{"int"}},
+	"sort.SearchInts": &JavaApiConv{method: "GoSort.SearchInts", imports: JI_GO_SORT, results: []string{"int"}},
+	"sort.SearchFloat64s": &JavaApiConv{method: "GoSort.SearchFloat64s", imports: JI_GO_SORT, results: []string{"int"}},
+	"sort.SearchStrings": &JavaApiConv{method: "GoSort.SearchStrings", imports: JI_GO_SORT, results: []string{"int"}},
+	"sort.Ints": &JavaApiConv{method: "GoSlices.Sort", imports: JI_GO_SLICES},
+	"sort.Strings": &JavaApiConv{method: "GoSlices.Sort", imports: JI_GO_SLICES},
+	"sort.Float64s": &JavaApiConv{method: "GoSlices.Sort", imports: JI_GO_SLICES},
+	"sort.IntsAreSorted": &JavaApiConv{method: "GoSlices.IsSorted", imports: JI_GO_SLICES, results: []string{"bool"}},
+	"sort.StringsAreSorted": &JavaApiConv{method: "GoSlices.IsSorted", imports: JI_GO_SLICES, results: []string{"bool"}},
+	"sort.Float64sAreSorted": &JavaApiConv{method: "GoSlices.IsSorted", imports: JI_GO_SLICES, results: []string{"bool"}},
+	"slices.Sort": &JavaApiConv{method: "GoSlices.Sort", imports: JI_GO_SLICES},
+	"slices.SortFunc": &JavaApiConv{method: "GoSlices.SortFunc", imports: JI_GO_SLICES},
+	"slices.SortStableFunc": &JavaApiConv{method: "GoSlices.SortStableFunc", imports: JI_GO_SLICES},
+	"slices.Reverse": &JavaApiConv{method: "GoSlices.Reverse", imports: JI_GO_SLICES},
+	"slices.IsSorted": &JavaApiConv{method: "GoSlices.IsSorted", imports: JI_GO_SLICES, results: []string{"bool"}},
+	"slices.IsSortedFunc": &JavaApiConv{method: "GoSlices.IsSortedFunc", imports: JI_GO_SLICES, results: []string{"bool"}},
+	"slices.Contains": &JavaApiConv{method: "GoSlices.Contains", imports: JI_GO_SLICES, results: []string{"bool"}},
+	"slices.ContainsFunc": &JavaApiConv{method: "GoSlices.ContainsFunc", imports: JI_GO_SLICES, results: []string{"bool"}},
+	"slices.Index": &JavaApiConv{method: "GoSlices.Index", imports: JI_GO_SLICES, results: []string{"int"}},
+	"slices.IndexFunc": &JavaApiConv{method: "GoSlices.IndexFunc", imports: JI_GO_SLICES, results: []string{"int"}},
+	"slices.Sorted": &JavaApiConv{method: "GoSlices.Sorted", imports: JI_GO_SLICES, results: []string{"{0}"}},
+	"slices.Collect": &JavaApiConv{method: "GoSlices.Collect", imports: JI_GO_SLICES, results: []string{"{0}"}},
+	"maps.Keys": &JavaApiConv{method: "GoMaps.Keys", imports: JI_GO_MAPS, results: []string{"[]{0:key}"}},
+	"maps.Values": &JavaApiConv{method: "GoMaps.Values", imports: JI_GO_MAPS, results: []string{"[]{0:value}"}},
+	"maps.Clone": &JavaApiConv{method: "GoMaps.Clone", imports: JI_GO_MAPS, results: []string{"{0}"}},
+	"maps.Copy": &JavaApiConv{method: "GoMaps.Copy", imports: JI_GO_MAPS},
+	"maps.DeleteFunc": &JavaApiConv{method: "GoMaps.DeleteFunc", imports: JI_GO_MAPS},
+	"maps.Equal": &JavaApiConv{method: "GoMaps.Equal", imports: JI_GO_MAPS, results: []string{"bool"}},
+	"cmp.Compare": &JavaApiConv{method: "GoSlices.Compare", imports: JI_GO_SLICES, results: []string{"int"}},
+	"cmp.Less": &JavaApiConv{method: "GoSlices.Less", imports: JI_GO_SLICES, results: []string{"bool"}},
+}
+
+// methodConvs converts the methods of the mapped types, the keys are like pkg.Type.Method
+var methodConvs = map[string]*JavaApiConv{
+	"strings.Builder.Write": &JavaApiConv{template: "{recv}.Write({args})", results: []string{"int", "error"}},
+	"strings.Builder.WriteByte": &JavaApiConv{template: "{recv}.WriteByte({args})", results: []string{"error"}},
+	"strings.Builder.WriteRune": &JavaApiConv{template: "{recv}.WriteRune({args})", results: []string{"int", "error"}},
+	"strings.Builder.WriteString": &JavaApiConv{template: "{recv}.WriteString({args})", results: []string{"int", "error"}},
+	"strings.Builder.Grow": &JavaApiConv{template: "{recv}.Grow({args})"},
+	"strings.Builder.Len": &JavaApiConv{template: "{recv}.Len({args})", results: []string{"int"}},
+	"strings.Builder.Cap": &JavaApiConv{template: "{recv}.Cap({args})", results: []string{"int"}},
+	"strings.Builder.Reset": &JavaApiConv{template: "{recv}.Reset({args})"},
+	"strings.Builder.String": &JavaApiConv{template: "{recv}.String({args})", results: []string{"string"}},
+	"strings.Replacer.Replace": &JavaApiConv{template: "{recv}.Replace({args})", results: []string{"string"}},
+	"strings.Replacer.WriteString": &JavaApiConv{template: "{recv}.WriteString({args})", results: []string{"int", "error"}},
+	"rand.Rand.Seed": &JavaApiConv{template: "{recv}.Seed({args})"},
+	"rand.Rand.Int63": &JavaApiConv{template: "{recv}.Int63({args})", results: []string{"int64"}},
+	"rand.Rand.Uint32": &JavaApiConv{template: "{recv}.Uint32({args})", results: []string{"uint32"}},
+	"rand.Rand.Uint64": &JavaApiConv{template: "{recv}.Uint64({args})", results: []string{"uint64"}},
+	"rand.Rand.Int31": &JavaApiConv{template: "{recv}.Int31({args})", results: []string{"int32"}},
+	"rand.Rand.Int": &JavaApiConv{template: "{recv}.Int({args})", results: []string{"int"}},
+	"rand.Rand.Int63n": &JavaApiConv{template: "{recv}.Int63n({args})", results: []string{"int64"}},
+	"rand.Rand.Int31n": &JavaApiConv{template: "{recv}.Int31n({args})", results: []string{"int32"}},
+	"rand.Rand.Intn": &JavaApiConv{template: "{recv}.Intn({args})", results: []string{"int"}},
+	"rand.Rand.Float64": &JavaApiConv{template: "{recv}.Float64({args})", results: []string{"float64"}},
+	"rand.Rand.Float32": &JavaApiConv{template: "{recv}.Float32({args})", results: []string{"float32"}},
+	"rand.Rand.NormFloat64": &JavaApiConv{template: "{recv}.NormFloat64({args})", results: []string{"float64"}},
+	"rand.Rand.Perm": &JavaApiConv{template: "{recv}.Perm({args})", results: []string{"[]int"}},
+	"rand.Rand.Shuffle": &JavaApiConv{template: "{recv}.Shuffle({args})"},
 }
 
 var typeConvs = map[string]*JavaTypeConv{
 	"time.Time": &JavaTypeConv{typeName: "Date", imports: JI_DATE},
+	"strings.Builder": &JavaTypeConv{typeName: "GoStrings.Builder", imports: JI_GO_STRINGS, zero: "new GoStrings.Builder()"},
+	"strings.Replacer": &JavaTypeConv{typeName: "GoStrings.Replacer", imports: JI_GO_STRINGS},
+	"strconv.NumError": &JavaTypeConv{typeName: "Strconv.NumError", imports: JI_STRCONV},
+	"rand.Rand": &JavaTypeConv{typeName: "GoRand", imports: JI_GO_RAND},
+	"rand.Source": &JavaTypeConv{typeName: "Random", imports: JI_RANDOM},
+	"sort.Interface": &JavaTypeConv{typeName: "GoSort.Interface", imports: JI_GO_SORT},
+}
+
+// isApiPackage tells whether a package is converted to java apis, its go sources are not parsed
+func isApiPackage(path string) bool {
+	prefix := path[strings.LastIndex(path, "/")+1:] + "."
+	for name := range apiConvs {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	for name := range methodConvs {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	for name := range typeConvs {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func addImports(imports *JavaImport, extraImports []*JavaImport, out *Output) {
+	if imports != nil {
+		out.outSource.addSysImportName(imports.typeName, imports.qualifiedName)
+	}
+	for _, extraImport := range extraImports {
+		out.outSource.addSysImportName(extraImport.typeName, extraImport.qualifiedName)
+	}
+}
+
+func (conv *JavaApiConv) addImports(out *Output) {
+	addImports(conv.imports, conv.extraImports, out)
+}
+
+func (conv *JavaTypeConv) addImports(out *Output) {
+	addImports(conv.imports, conv.extraImports, out)
+}
+
+// resultTypes returns the go types of the results of a converted call. {0}, {1}.. in the results
+// are the types of the arguments, {0:elem} is the element type of a slice argument, {0:key} and
+// {0:value} are the key and the value types of a map argument
+func (conv *JavaApiConv) resultTypes(callExpr *ast.CallExpr, out *Output) []ast.Expr {
+	results := []ast.Expr{}
+	for _, result := range conv.results {
+		known := true
+		result = expandResult(result, func(idx int, part string) string {
+			argType := argTypePart(callExpr, idx, part, out)
+			if argType == nil {
+				known = false
+				return "T"
+			}
+			return types.ExprString(argType)
+		})
+		resultType, err := parser.ParseExpr(result)
+		if !known || err != nil {
+			return nil
+		}
+		results = append(results, resultType)
+	}
+	return results
+}
+
+func expandResult(result string, fill func(idx int, part string) string) string {
+	expanded := ""
+	for {
+		start := strings.Index(result, "{")
+		end := strings.Index(result, "}")
+		if start < 0 || end < start {
+			break
+		}
+		name, part := result[start+1:end], ""
+		if colon := strings.Index(name, ":"); colon >= 0 {
+			name, part = name[:colon], name[colon+1:]
+		}
+		if idx, err := strconv.Atoi(name); err == nil && idx >= 0 {
+			expanded += result[:start] + fill(idx, part)
+		} else {
+			expanded += result[:end+1]
+		}
+		result = result[end+1:]
+	}
+	return expanded + result
+}
+
+func argTypePart(callExpr *ast.CallExpr, idx int, part string, out *Output) ast.Expr {
+	if callExpr == nil || idx >= len(callExpr.Args) {
+		return nil
+	}
+	argType := typeOf(callExpr.Args[idx], out)
+	switch tp := underlyingType(argType).(type) {
+	case *ast.ArrayType:
+		if part == "elem" {
+			return tp.Elt
+		}
+	case *ast.MapType:
+		if part == "key" {
+			return tp.Key
+		}
+		if part == "value" {
+			return tp.Value
+		}
+	}
+	if part == "" {
+		return argType
+	}
+	return nil
+}
+
+// apiConvOf returns the conversion of a called function, or of a called method of a mapped type with its receiver
+func apiConvOf(callExpr *ast.CallExpr, out *Output) (*JavaApiConv, ast.Expr) {
+	if conv := apiConvs[resolveTypeName(callExpr.Fun, false)]; conv != nil {
+		return conv, nil
+	}
+	selectorExpr, ok := callExpr.Fun.(*ast.SelectorExpr)
+	if !ok || len(methodConvs) == 0 {
+		return nil, nil
+	}
+	if typeName := mappedTypeName(typeOf(selectorExpr.X, out)); typeName != "" {
+		if conv := methodConvs[typeName+"."+selectorExpr.Sel.Name]; conv != nil {
+			return conv, selectorExpr.X
+		}
+	}
+	return nil, nil
+}
+
+// mappedTypeName returns the qualified name of a type of an other package, like pkg.Type
+func mappedTypeName(typeExpr ast.Expr) string {
+	switch tp := typeExpr.(type) {
+	case *ast.StarExpr:
+		return mappedTypeName(tp.X)
+	case *ast.IndexExpr:
+		return mappedTypeName(tp.X)
+	case *ast.IndexListExpr:
+		return mappedTypeName(tp.X)
+	case *ast.SelectorExpr:
+		return resolveTypeName(tp, false)
+	}
+	return ""
+}
+
+// convertApiCall converts a call of a function or a method converted to a java api
+func convertApiCall(callExpr *ast.CallExpr, out *Output) bool {
+	conv, recv := apiConvOf(callExpr, out)
+	if conv == nil {
+		return false
+	}
+	conv.addImports(out)
+	if conv.template != "" {
+		expandTemplate(conv.template, out, func(name string, out *Output) {
+			switch name {
+			case "recv":
+				if recv != nil {
+					convertExpr(recv, out)
+				}
+			case "args":
+				convertApiArgs(callExpr, conv, nil, out)
+			default:
+				if idx, _ := strconv.Atoi(name); idx < len(callExpr.Args) {
+					convertApiArg(callExpr, idx, conv, out)
+				}
+			}
+		})
+		return true
+	}
+	out.Print(conv.method, "(")
+	if recv != nil {
+		// the receiver is the first argument of the java method
+		convertExpr(recv, out)
+		if len(callExpr.Args) > 0 {
+			out.Print(", ")
+		}
+	}
+	convertApiArgs(callExpr, conv, conv.args, out)
+	out.Print(")")
+	return true
+}
+
+// convertApiArgs prints the arguments of an api call in the given order, or all of them
+func convertApiArgs(callExpr *ast.CallExpr, conv *JavaApiConv, order []int, out *Output) {
+	if order == nil {
+		for idx := range callExpr.Args {
+			order = append(order, idx)
+		}
+	}
+	sep := ""
+	for _, idx := range order {
+		if idx >= len(callExpr.Args) {
+			continue
+		}
+		out.Print(sep)
+		convertApiArg(callExpr, idx, conv, out)
+		sep = ", "
+	}
+}
+
+func convertApiArg(callExpr *ast.CallExpr, idx int, conv *JavaApiConv, out *Output) {
+	if convertSortInterfaceArg(callExpr, idx, out) {
+		return
+	}
+	if conv.printArgs != nil {
+		convertPrintArg(callExpr, idx, conv, out)
+	} else {
+		convertCallArg(callExpr, idx, nil, out)
+	}
+}
+
+// convertTypeConv prints a converted type with its generic parameters
+func convertTypeConv(conv *JavaTypeConv, params []ast.Expr, out *Output) {
+	conv.addImports(out)
+	expandTemplate(conv.typeName, out, func(name string, out *Output) {
+		idx, err := strconv.Atoi(name)
+		if err != nil || idx >= len(params) {
+			out.Print("Object")
+			return
+		}
+		nrto := newResolveTypeOpts()
+		nrto.PrimitiveAsObject = true
+		convertType(params[idx], out, nrto)
+	})
+}
+
+// zeroTypeConv returns the conversion of a mapped type with a zero value, pointers are nil
+func zeroTypeConv(typeExpr ast.Expr) *JavaTypeConv {
+	if _, isPtr := typeExpr.(*ast.StarExpr); isPtr {
+		return nil
+	}
+	if conv := typeConvs[mappedTypeName(typeExpr)]; conv != nil && conv.zero != "" {
+		return conv
+	}
+	return nil
+}
+
+// convertGenericType prints an instantiated generic type
+func convertGenericType(typeExpr ast.Expr, params []ast.Expr, out *Output, opts *ResolveTypeOpts) {
+	if conv, has := typeConvs[mappedTypeName(typeExpr)]; has {
+		convertTypeConv(conv, params, out)
+		return
+	}
+	convertType(typeExpr, out, opts)
+}
+
+// expandTemplate prints a template, fill prints its {0}, {1}.., {args} and {recv} placeholders.
+// other braces are printed as they are
+func expandTemplate(template string, out *Output, fill func(name string, out *Output)) {
+	for {
+		end := strings.Index(template, "}")
+		if end < 0 {
+			break
+		}
+		start := strings.LastIndex(template[:end], "{")
+		if name := template[start+1 : end]; start >= 0 && isPlaceholder(name) {
+			out.Print(template[:start])
+			fill(name, out)
+		} else {
+			out.Print(template[:end+1])
+		}
+		template = template[end+1:]
+	}
+	out.Print(template)
+}
+
+func isPlaceholder(name string) bool {
+	if name == "args" || name == "recv" {
+		return true
+	}
+	_, err := strconv.Atoi(name)
+	return err == nil && !strings.HasPrefix(name, "-") && !strings.HasPrefix(name, "+")
 }
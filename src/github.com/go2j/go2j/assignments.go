@@ -0,0 +1,312 @@
+package main
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+)
+
+// go evaluates all right hand sides of a tuple assignment before it assigns any of them,
+// values that would see an earlier assignment of the same statement are kept in temporaries first
+
+// builtins without side effects, assigning them to _ is dropped
+var goPureBuiltins = map[string]bool{
+	"len": true, "cap": true, "min": true, "max": true, "new": true, "make": true,
+	"complex": true, "real": true, "imag": true,
+}
+
+var tmpVarCount = 0
+
+// isNewDef tells whether a := statement declares the name, the parser links redeclared names to their first declaration
+func isNewDef(expr ast.Expr, assignStmt *ast.AssignStmt) bool {
+	ident, ok := expr.(*ast.Ident)
+	if !ok || ident.Name == "_" || assignStmt.Tok != token.DEFINE {
+		return false
+	}
+	return ident.Obj == nil || ident.Obj.Decl == assignStmt
+}
+
+// rootName returns the variable an assignment target belongs to
+func rootName(expr ast.Expr) string {
+	switch tp := expr.(type) {
+	case *ast.Ident:
+		return tp.Name
+	case *ast.IndexExpr:
+		return rootName(tp.X)
+	case *ast.SelectorExpr:
+		return rootName(tp.X)
+	case *ast.StarExpr:
+		return rootName(tp.X)
+	case *ast.ParenExpr:
+		return rootName(tp.X)
+	}
+	return ""
+}
+
+// refersTo tells whether an expression reads one of the names
+func refersTo(expr ast.Expr, names map[string]bool) bool {
+	found := false
+	ast.Inspect(expr, func(node ast.Node) bool {
+		switch tp := node.(type) {
+		case *ast.SelectorExpr:
+			// the selected field is not a variable
+			found = found || refersTo(tp.X, names)
+			return false
+		case *ast.Ident:
+			found = found || names[tp.Name]
+		}
+		return !found
+	})
+	return found
+}
+
+// hasCall tells whether evaluating an expression may have side effects
+func hasCall(expr ast.Expr, out *Output) bool {
+	found := false
+	ast.Inspect(expr, func(node ast.Node) bool {
+		if callExpr, ok := node.(*ast.CallExpr); ok && !isTypeExpr(callExpr.Fun, out) {
+			ident, isIdent := callExpr.Fun.(*ast.Ident)
+			found = !isIdent || !goPureBuiltins[ident.Name] || out.GetVarType(ident.Name) != nil
+		}
+		return !found
+	})
+	return found
+}
+
+// convertAssignedValue prints constants as literals of the type of the variable, parameter or result
+// they are assigned to, wraps the values of named types converted to interfaces and copies structs and arrays
+func convertAssignedValue(typeExpr ast.Expr, expr ast.Expr, isArg bool, out *Output) bool {
+	return convertTypedConst(typeExpr, expr, isArg, out) || convertInterfaceValue(typeExpr, expr, out) ||
+		convertValueCopy(typeExpr, expr, out)
+}
+
+func isConstExpr(expr ast.Expr) bool {
+	return evalConst(expr, 0, lookupConst(oFileSet.currentPackage)).Kind() != constant.Unknown
+}
+
+// convertDiscard evaluates a value assigned to _ when it may have side effects
+func convertDiscard(expr ast.Expr, out *Output) bool {
+	if _, isCall := expr.(*ast.CallExpr); !isCall || !hasCall(expr, out) {
+		return false
+	}
+	convertExpr(expr, out)
+	return true
+}
+
+// convertBlankAssign translates assignments to _, only calls are kept
+func convertBlankAssign(assignStmt *ast.AssignStmt, out *Output) bool {
+	if len(assignStmt.Rhs) != 1 || !isBlank(assignStmt.Lhs[0]) {
+		return false
+	}
+	if convertDiscard(assignStmt.Rhs[0], out) {
+		convertStmtEnd(out)
+	}
+	return true
+}
+
+// newTmpVarName returns a name not used by the variables of the function
+func newTmpVarName(out *Output) string {
+	name := ""
+	for name == "" || out.GetVarType(name) != nil {
+		tmpVarCount++
+		name = "tmp" + strconv.Itoa(tmpVarCount)
+	}
+	return name
+}
+
+// newTmpVar declares a temporary holding the value of an expression
+func newTmpVar(typeExpr ast.Expr, expr ast.Expr, out *Output) *ast.Ident {
+	name := newTmpVarName(out)
+	if typeExpr == nil {
+		typeExpr = findType(expr, out)
+	}
+	nrto := newResolveTypeOpts()
+	nrto.FunctionAsReference = true
+	out.Print("")
+	convertType(typeExpr, out, nrto)
+	out.Print(" ", name, " = ")
+	convertExpr(expr, out)
+	convertStmtEnd(out)
+	out.AddVar(name, typeExpr)
+	return ast.NewIdent(name)
+}
+
+// evalOnce keeps the operands of an index expression a translation evaluates twice in temporaries,
+// constants and variables are evaluated again
+func evalOnce(indexExpr *ast.IndexExpr, out *Output) *ast.IndexExpr {
+	if out.banStmtEnd {
+		// for post statements have no place for declarations
+		return indexExpr
+	}
+	x, index := indexExpr.X, indexExpr.Index
+	if hasCall(x, out) {
+		x = newTmpVar(typeOf(x, out), x, out)
+	}
+	if _, isIdent := index.(*ast.Ident); !isIdent && !isConstExpr(index) {
+		index = newTmpVar(typeOf(index, out), index, out)
+	}
+	return &ast.IndexExpr{X: x, Lbrack: indexExpr.Lbrack, Index: index, Rbrack: indexExpr.Rbrack}
+}
+
+// hoistOperands keeps the index and the operand of a target in temporaries when an earlier assignment rebinds them
+func hoistOperands(target ast.Expr, rebound map[string]bool, out *Output) ast.Expr {
+	hoist := func(expr ast.Expr) ast.Expr {
+		if isConstExpr(expr) || !refersTo(expr, rebound) {
+			return expr
+		}
+		return newTmpVar(typeOf(expr, out), expr, out)
+	}
+	switch tp := target.(type) {
+	case *ast.IndexExpr:
+		return &ast.IndexExpr{X: hoist(tp.X), Lbrack: tp.Lbrack, Index: hoist(tp.Index), Rbrack: tp.Rbrack}
+	case *ast.SelectorExpr:
+		return &ast.SelectorExpr{X: hoist(tp.X), Sel: tp.Sel}
+	case *ast.StarExpr:
+		return &ast.StarExpr{Star: tp.Star, X: hoist(tp.X)}
+	}
+	return target
+}
+
+// convertTupleAssign translates a, b = x, y into single assignments, new names of a := statement are declared
+func convertTupleAssign(assignStmt *ast.AssignStmt, out *Output) bool {
+	lhs, rhs := assignStmt.Lhs, assignStmt.Rhs
+	if len(lhs) < 2 || len(lhs) != len(rhs) {
+		return false
+	}
+	// find the values and targets that depend on earlier assignments of the statement
+	needsTmp := make([]bool, len(rhs))
+	anyTmp := false
+	calls := 0
+	assigned, rebound := map[string]bool{}, map[string]bool{}
+	mutated := false
+	for idx := range lhs {
+		if hasCall(rhs[idx], out) {
+			calls++
+		}
+		if !isConstExpr(rhs[idx]) && (refersTo(rhs[idx], assigned) || mutated && hasCall(rhs[idx], out)) {
+			needsTmp[idx] = true
+		}
+		if _, isIdent := lhs[idx].(*ast.Ident); !isIdent && refersTo(lhs[idx], rebound) {
+			anyTmp = true
+		}
+		anyTmp = anyTmp || needsTmp[idx]
+		if isBlank(lhs[idx]) {
+			continue
+		}
+		assigned[rootName(lhs[idx])] = true
+		if _, isIdent := lhs[idx].(*ast.Ident); isIdent {
+			rebound[rootName(lhs[idx])] = true
+		} else {
+			mutated = true
+		}
+	}
+	if anyTmp && calls > 1 {
+		// calls keep their order
+		for idx := range rhs {
+			needsTmp[idx] = needsTmp[idx] || hasCall(rhs[idx], out)
+		}
+	}
+	if out.banStmtEnd {
+		// for statements take lists of expressions, there is no place for temporaries
+		if anyTmp {
+			out.Warn(assignStmt, "the tuple assignment is converted in sequence: "+exprListString(lhs)+" = "+exprListString(rhs))
+		}
+		return convertAssignList(assignStmt, out)
+	}
+	// evaluate everything before the first assignment
+	targets := make([]ast.Expr, len(lhs))
+	values := make([]ast.Expr, len(rhs))
+	copy(values, rhs)
+	rebound = map[string]bool{}
+	for idx, target := range lhs {
+		if anyTmp && !isBlank(target) {
+			targets[idx] = hoistOperands(target, rebound, out)
+		} else {
+			targets[idx] = target
+		}
+		if ident, isIdent := target.(*ast.Ident); isIdent {
+			rebound[ident.Name] = true
+		}
+	}
+	for idx, value := range rhs {
+		if !needsTmp[idx] {
+			continue
+		}
+		if isBlank(lhs[idx]) {
+			if convertDiscard(value, out) {
+				convertStmtEnd(out)
+			}
+			values[idx] = nil
+			continue
+		}
+		typeExpr := typeOf(value, out)
+		if !isNewDef(lhs[idx], assignStmt) {
+			if targetType := typeOf(lhs[idx], out); targetType != nil {
+				typeExpr = targetType
+			}
+		}
+		values[idx] = newTmpVar(typeExpr, value, out)
+	}
+	for idx, target := range targets {
+		if isBlank(target) {
+			if values[idx] != nil && convertDiscard(values[idx], out) {
+				convertStmtEnd(out)
+			}
+			continue
+		}
+		tok := token.ASSIGN
+		if isNewDef(lhs[idx], assignStmt) {
+			tok = token.DEFINE
+		}
+		convertAssignStmt(&ast.AssignStmt{Lhs: []ast.Expr{target}, TokPos: assignStmt.TokPos, Tok: tok, Rhs: []ast.Expr{values[idx]}}, out)
+	}
+	return true
+}
+
+// convertAssignList translates a tuple assignment of a for statement to a comma separated list,
+// java declares several variables of one type only and cannot mix declarations with assignments
+func convertAssignList(assignStmt *ast.AssignStmt, out *Output) bool {
+	var declType ast.Expr
+	first := true
+	for idx, target := range assignStmt.Lhs {
+		value := assignStmt.Rhs[idx]
+		if isBlank(target) {
+			if _, isCall := value.(*ast.CallExpr); !isCall || !hasCall(value, out) {
+				continue
+			}
+		}
+		if !first {
+			out.Print(", ")
+		}
+		tok := token.ASSIGN
+		switch {
+		case isBlank(target):
+			convertExpr(value, out)
+			first = false
+			continue
+		case first && isNewDef(target, assignStmt):
+			tok = token.DEFINE
+			declType = typeOf(value, out)
+		case isNewDef(target, assignStmt) || declType != nil:
+			valueType := typeOf(value, out)
+			if declType == nil || valueType == nil || typeString(declType) != typeString(valueType) || !isNewDef(target, assignStmt) {
+				out.Warn(assignStmt, "java cannot declare these variables in one statement: "+exprListString(assignStmt.Lhs))
+			}
+			out.AddVar(target.(*ast.Ident).Name, valueType)
+		}
+		convertAssignStmt(&ast.AssignStmt{Lhs: []ast.Expr{target}, TokPos: assignStmt.TokPos, Tok: tok, Rhs: []ast.Expr{value}}, out)
+		first = false
+	}
+	return true
+}
+
+func exprListString(exprs []ast.Expr) string {
+	strs := []string{}
+	for _, expr := range exprs {
+		strs = append(strs, types.ExprString(expr))
+	}
+	return strings.Join(strs, ", ")
+}
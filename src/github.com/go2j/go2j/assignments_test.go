@@ -0,0 +1,32 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// the warnings of the translator go to stderr with the position of the statement
+func TestAssignWarnings(t *testing.T) {
+	tr := translate(t, map[string]string{"app/main.go": `package main
+
+import "fmt"
+
+func main() {
+	a, b := 1, 2
+	for i, j := 0, "x"; i < 1; i++ {
+		fmt.Println(i, j)
+	}
+	for a, b = b, a; a < 1; a++ {
+	}
+	fmt.Println(a, b)
+}
+`})
+	for _, warning := range []string{
+		"main.go:7:6: warning: java cannot declare these variables in one statement: i, j",
+		"main.go:10:6: warning: the tuple assignment is converted in sequence: a, b = b, a",
+	} {
+		if !strings.Contains(tr.stderr, warning) {
+			t.Errorf("missing %q in\n%s", warning, tr.stderr)
+		}
+	}
+}
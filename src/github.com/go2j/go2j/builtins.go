@@ -0,0 +1,230 @@
+package main
+
+import (
+	"go/ast"
+)
+
+// builtin functions are translated by the type of their first operand
+
+// convertBuiltinCall translates calls of the go builtins, it returns false if the call is not a builtin
+func convertBuiltinCall(callExpr *ast.CallExpr, out *Output) bool {
+	ident, ok := callExpr.Fun.(*ast.Ident)
+	if !ok || out.GetVarType(ident.Name) != nil || out.GetFuncType(ident.Name) != nil {
+		return false
+	}
+	name := ident.Name
+	switch name {
+	case "print", "println":
+		convertPrintCall(callExpr, name, out)
+		return true
+	}
+	if len(callExpr.Args) == 0 {
+		return false
+	}
+	switch name {
+	case "len", "cap":
+		return convertLenCall(callExpr, name, out)
+	case "append", "copy":
+		return convertSliceCall(callExpr, name, out)
+	case "delete":
+		return convertMapCall(callExpr, name, out)
+	case "close":
+		return convertChanCall(callExpr, name, out)
+	case "clear":
+		return convertMapCall(callExpr, name, out) || convertSliceClear(callExpr, out)
+	case "make":
+		return convertMakeCall(callExpr, out)
+	case "new":
+		if isCellElem(callExpr.Args[0]) {
+			convertNewCell(callExpr.Args[0], nil, out)
+			return true
+		}
+		convertZeroValue(callExpr.Args[0], out)
+		return true
+	case "min", "max":
+		convertMinMaxCall(callExpr, name, out)
+		return true
+	}
+	return false
+}
+
+func convertLenCall(callExpr *ast.CallExpr, name string, out *Output) bool {
+	arg := callExpr.Args[0]
+	argType := underlyingType(typeOf(arg, out))
+	if starType, ok := argType.(*ast.StarExpr); ok {
+		// len of a pointer to array
+		argType = underlyingType(starType.X)
+	}
+	switch tp := argType.(type) {
+	case *ast.Ident:
+		if tp.Name == "string" && name == "len" {
+			printGoStringCall("len", out, arg)
+			return true
+		}
+	case *ast.ArrayType:
+		if tp.Len == nil {
+			return convertSliceCall(callExpr, name, out)
+		}
+		convertExpr(arg, out)
+		out.Print(".length")
+		return true
+	case *ast.MapType:
+		return convertMapCall(callExpr, name, out)
+	case *ast.ChanType:
+		return convertChanCall(callExpr, name, out)
+	}
+	return false
+}
+
+// hasObjectZero tells whether the zero value of a type is an object instead of null
+func hasObjectZero(typeExpr ast.Expr, out *Output) bool {
+	if zeroTypeConv(typeExpr) != nil {
+		return true
+	}
+	switch tp := underlyingType(typeExpr).(type) {
+	case *ast.Ident:
+		return tp.Name == "string" || oEnums.set[typeKey(tp.Name, out)] != nil
+	case *ast.StructType:
+		return true
+	case *ast.ArrayType:
+		return tp.Len != nil
+	}
+	return false
+}
+
+func convertZeroSupplier(elemType ast.Expr, out *Output) {
+	out.Print("() -> ")
+	convertZeroValue(elemType, out)
+}
+
+// convertSliceClear zeroes the elements of a slice
+func convertSliceClear(callExpr *ast.CallExpr, out *Output) bool {
+	arg := callExpr.Args[0]
+	className := sliceClassOf(arg, out)
+	if className == "" {
+		return false
+	}
+	useSliceClass(className, out)
+	out.Print(className, ".clear(")
+	convertExpr(arg, out)
+	if elemType := sliceElemType(typeOf(arg, out)); className == "Slice" && hasObjectZero(elemType, out) {
+		out.Print(", ")
+		convertZeroSupplier(elemType, out)
+	}
+	out.Print(")")
+	return true
+}
+
+// convertMakeCall translates make of slices and maps, channels are not supported
+func convertMakeCall(callExpr *ast.CallExpr, out *Output) bool {
+	args := callExpr.Args
+	switch tp := underlyingType(args[0]).(type) {
+	case *ast.ArrayType:
+		if tp.Len != nil || len(args) < 2 {
+			return false
+		}
+		className := sliceClass(tp.Elt)
+		useSliceClass(className, out)
+		out.Print(className, ".")
+		if className == "Slice" {
+			convertSliceTypeArg(tp.Elt, out)
+		}
+		out.Print("make(")
+		for idx, arg := range args[1:] {
+			if idx > 0 {
+				out.Print(", ")
+			}
+			convertExpr(arg, out)
+		}
+		if className == "Slice" && hasObjectZero(tp.Elt, out) {
+			out.Print(", ")
+			convertZeroSupplier(tp.Elt, out)
+		}
+		out.Print(")")
+		return true
+	case *ast.MapType:
+		out.outSource.addSysImportName("HashMap", "java.util.HashMap")
+		out.Print("new HashMap")
+		convertMapTypeArgs(tp, out)
+		out.Print("(")
+		if len(args) > 1 {
+			convertExpr(args[1], out)
+		}
+		out.Print(")")
+		return true
+	case *ast.ChanType:
+		convertChanType(tp, out)
+		return true
+	}
+	return false
+}
+
+// convertMinMaxCall translates min and max, strings are ordered by their bytes
+func convertMinMaxCall(callExpr *ast.CallExpr, name string, out *Output) {
+	args := callExpr.Args
+	if len(args) == 1 {
+		convertExpr(args[0], out)
+		return
+	}
+	var argType ast.Expr
+	for _, arg := range args {
+		if _, isLit := arg.(*ast.BasicLit); !isLit {
+			argType = typeOf(arg, out)
+			break
+		}
+	}
+	if argType == nil {
+		argType = typeOf(args[0], out)
+	}
+	if isStringType(argType) {
+		printGoStringCall(name, out, args...)
+		return
+	}
+	// Math.min returns int for byte and short operands
+	switch javaType := javaPrimitiveOf(argType); javaType {
+	case "byte", "short":
+		out.Print("(", javaType, ") ")
+	}
+	for i := 1; i < len(args); i++ {
+		out.Print("Math.", name, "(")
+	}
+	convertExpr(args[0], out)
+	for _, arg := range args[1:] {
+		out.Print(", ")
+		convertExpr(arg, out)
+		out.Print(")")
+	}
+}
+
+// convertPrintCall translates print and println, they write to the standard error like in go
+func convertPrintCall(callExpr *ast.CallExpr, name string, out *Output) {
+	if len(callExpr.Args) == 0 {
+		if name == "println" {
+			out.Print("System.err.println()")
+		} else {
+			out.Print("System.err.print(\"\")")
+		}
+		return
+	}
+	separator := " + "
+	if name == "println" {
+		separator = ` + " " + `
+	}
+	out.Print("System.err.", name, "(")
+	if name == "print" {
+		out.Print(`"" + `)
+	}
+	for idx, arg := range callExpr.Args {
+		if idx > 0 {
+			out.Print(separator)
+		}
+		if _, isBinary := arg.(*ast.BinaryExpr); isBinary {
+			out.Print("(")
+			convertExpr(arg, out)
+			out.Print(")")
+		} else {
+			convertExpr(arg, out)
+		}
+	}
+	out.Print(")")
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+// channels are not translated, their types, operations and builtins fail the translation
+
+func chanTypeOf(expr ast.Expr, out *Output) *ast.ChanType {
+	chanType, _ := underlyingType(typeOf(expr, out)).(*ast.ChanType)
+	return chanType
+}
+
+func convertChanType(chanType *ast.ChanType, out *Output) {
+	out.Error(chanType, "channels are not supported")
+	out.Print("Object")
+}
+
+// convertChanCall reports len, cap and close of channels
+func convertChanCall(callExpr *ast.CallExpr, name string, out *Output) bool {
+	if chanTypeOf(callExpr.Args[0], out) == nil {
+		return false
+	}
+	out.Error(callExpr, name+" of channels is not supported")
+	return true
+}
+
+func convertSendStmt(sendStmt *ast.SendStmt, out *Output) {
+	out.Error(sendStmt, "channels are not supported")
+}
+
+func convertReceive(unaryExpr *ast.UnaryExpr, out *Output) bool {
+	if unaryExpr.Op != token.ARROW {
+		return false
+	}
+	out.Error(unaryExpr, "channels are not supported")
+	return true
+}
+
+func convertChanRangeStmt(rangeStmt *ast.RangeStmt, out *Output) bool {
+	if chanTypeOf(rangeStmt.X, out) == nil {
+		return false
+	}
+	out.Error(rangeStmt, "channels are not supported")
+	return true
+}
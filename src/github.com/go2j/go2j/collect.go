@@ -0,0 +1,267 @@
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+)
+
+// the types, methods, functions and variables of all packages are collected before conversion,
+// so packages see the declarations of the packages converted after them. a method is written
+// into the class of its receiver type, a method seen before its type waits for the class
+
+// oOwnPackages holds the import paths of the go packages of the sources, a selector on an import of
+// one of them names a class of the project
+var oOwnPackages = map[string]bool{}
+
+// oPackageImports maps the paths of the packages of the project to the names and the paths of the
+// packages of the project they import
+var oPackageImports = map[string]map[string]string{}
+
+// oPackageMembers maps the paths of the packages of the project to the names of their variables, constants and functions
+var oPackageMembers = map[string]map[string]bool{}
+
+// oPackageObjects holds the objects of the package level declarations, identifiers resolved to other objects are local
+var oPackageObjects = map[*ast.Object]bool{}
+
+// PendingMethod is a method converted when the class of its receiver type is written
+type PendingMethod struct {
+	funcDecl *ast.FuncDecl
+	out      *Output
+}
+
+// receiverTypeName returns the name of the receiver type of value and pointer receivers
+func receiverTypeName(fieldList *ast.FieldList, needTitle bool) string {
+	if fieldList == nil || len(fieldList.List) == 0 {
+		return ""
+	}
+	recvType := fieldList.List[0].Type
+	for {
+		switch tp := recvType.(type) {
+		case *ast.ParenExpr:
+			recvType = tp.X
+			continue
+		case *ast.StarExpr:
+			recvType = tp.X
+			continue
+		}
+		break
+	}
+	return resolveTypeName(recvType, needTitle)
+}
+
+func isPointerReceiver(fieldList *ast.FieldList) bool {
+	if fieldList == nil || len(fieldList.List) == 0 {
+		return false
+	}
+	recvType := fieldList.List[0].Type
+	for {
+		paren, ok := recvType.(*ast.ParenExpr)
+		if !ok {
+			break
+		}
+		recvType = paren.X
+	}
+	_, isPtr := recvType.(*ast.StarExpr)
+	return isPtr
+}
+
+// ownPackagePath returns the path of the package of the project an import name used in the package
+// of pkgPath names, an empty pkgPath is the package being converted
+func ownPackagePath(name, pkgPath string) string {
+	if pkgPath == "" {
+		pkgPath = oFileSet.currentPackage
+	}
+	if importPath := oPackageImports[pkgPath][name]; oOwnPackages[importPath] {
+		return importPath
+	}
+	return ""
+}
+
+// packageKey returns the name the package class of a go package is registered with
+func packageKey(pkgPath, name string) string {
+	return pkgPath + "/" + strings.Title(name)
+}
+
+func currentPackageKey() string {
+	return packageKey(oFileSet.currentPackage, oFileSet.currentPackageName)
+}
+
+// currentGoPackage returns the package being converted, the classes of its types have packages of their own
+func currentGoPackage() *Package {
+	return oFileSet.packageSet[currentPackageKey()]
+}
+
+func sourcePackageKey(sourceFile *SourceFile, trimPrefix string) string {
+	path := strings.TrimPrefix(sourceFile.absPath, trimPrefix)
+	packagePath := convertPackageFileName(path, sourceFile.file.Name.Name)
+	return packageKey(getPackagePath(packagePath), sourceFile.file.Name.Name)
+}
+
+// sourceImports maps the names of the packages of the project a source imports to their keys
+func sourceImports(sourceFile *SourceFile) map[string]string {
+	imports := map[string]string{}
+	for _, importSpec := range sourceFile.file.Imports {
+		importPath := strings.Trim(importSpec.Path.Value, "\"")
+		key := packageKey(importPath, fileNameOf(importPath))
+		if oFileSet.packageSet[key] == nil {
+			continue
+		}
+		name := fileNameOf(importPath)
+		if importSpec.Name != nil {
+			name = importSpec.Name.Name
+		}
+		imports[name] = key
+	}
+	return imports
+}
+
+// isOwnPackageName tells whether an identifier names a package of the project
+func isOwnPackageName(expr ast.Expr, out *Output) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && ownPackagePath(ident.Name, currentPkgName(out)) != "" && out.GetVarType(ident.Name) == nil
+}
+
+// isPackageMember tells whether an identifier used in the class of a type names a member of the package class
+func isPackageMember(ident *ast.Ident, out *Output) bool {
+	if out.outSource.isPackage || out.outSource.system {
+		return false
+	}
+	if ident.Obj != nil {
+		return oPackageObjects[ident.Obj]
+	}
+	return out.GetVarType(ident.Name) == nil && oPackageMembers[oFileSet.currentPackage][ident.Name]
+}
+
+func collectDecls(sourceFiles []*SourceFile, trimPrefix string) {
+	for _, sourceFile := range sourceFiles {
+		pkgKey := sourcePackageKey(sourceFile, trimPrefix)
+		pkg := oFileSet.packageSet[pkgKey]
+		if pkg == nil {
+			pkg = newPackage(strings.Title(sourceFile.file.Name.Name))
+			oFileSet.packageSet[pkgKey] = pkg
+		}
+		pkgPath := pathOf(pkgKey)
+		oOwnPackages[pkgPath] = true
+		if oPackageMembers[pkgPath] == nil {
+			oPackageMembers[pkgPath] = map[string]bool{}
+		}
+		for name, obj := range sourceFile.file.Scope.Objects {
+			if obj.Kind == ast.Var || obj.Kind == ast.Con || obj.Kind == ast.Fun {
+				oPackageMembers[pkgPath][name] = true
+				oPackageObjects[obj] = true
+			}
+		}
+		for _, decl := range sourceFile.file.Decls {
+			switch tp := decl.(type) {
+			case *ast.GenDecl:
+				for _, spec := range tp.Specs {
+					if typeSpec, ok := spec.(*ast.TypeSpec); ok && !typeSpec.Assign.IsValid() {
+						oTypes.setTypeSpec(qualifyTypeKey(pkgPath, typeSpec.Name.Name), pkgPath, false, typeSpec)
+					}
+				}
+			case *ast.FuncDecl:
+				// the methods of both receiver kinds are written into the class of the type, addMethod keeps the kind
+				if typeName := receiverTypeName(tp.Recv, false); typeName != "" {
+					oTypes.addMethod(qualifyTypeKey(pkgPath, typeName), tp)
+					continue
+				}
+				if resultType := funcResultType(tp.Type); resultType != nil {
+					pkg.AddFunc(tp.Name.Name, resultType)
+				}
+				oPackageFuncs[packageFuncKey(pkgKey, tp.Name.Name)] = tp.Type
+			}
+		}
+	}
+	// variables may be initialized by the functions of any package
+	for _, sourceFile := range sourceFiles {
+		pkgKey := sourcePackageKey(sourceFile, trimPrefix)
+		pkg := oFileSet.packageSet[pkgKey]
+		imports := sourceImports(sourceFile)
+		if oPackageImports[pathOf(pkgKey)] == nil {
+			oPackageImports[pathOf(pkgKey)] = map[string]string{}
+		}
+		for name, importKey := range imports {
+			oPackageImports[pathOf(pkgKey)][name] = pathOf(importKey)
+		}
+		for _, decl := range sourceFile.file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.VAR {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				valueSpec := spec.(*ast.ValueSpec)
+				for idx, name := range valueSpec.Names {
+					if valueSpec.Type != nil {
+						pkg.AddVarType(name.Name, valueSpec.Type)
+					} else if len(valueSpec.Values) == len(valueSpec.Names) {
+						if valueType := declValueType(valueSpec.Values[idx], pkg, imports); valueType != nil {
+							pkg.AddVarType(name.Name, valueType)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// declValueType returns the type of the value of a package variable as far as it is known before conversion,
+// the conversion of the package finds the others
+func declValueType(expr ast.Expr, pkg *Package, imports map[string]string) ast.Expr {
+	switch tp := expr.(type) {
+	case *ast.ParenExpr:
+		return declValueType(tp.X, pkg, imports)
+	case *ast.CompositeLit:
+		return tp.Type
+	case *ast.UnaryExpr:
+		if tp.Op != token.AND {
+			return nil
+		}
+		if xType := declValueType(tp.X, pkg, imports); xType != nil {
+			return &ast.StarExpr{X: xType}
+		}
+	case *ast.CallExpr:
+		switch fun := tp.Fun.(type) {
+		case *ast.Ident:
+			if isTypeName(fun.Name) {
+				return fun
+			}
+			return pkg.FuncReturnTypes[fun.Name]
+		case *ast.SelectorExpr:
+			if ident, ok := fun.X.(*ast.Ident); ok && imports[ident.Name] != "" {
+				return oFileSet.packageSet[imports[ident.Name]].FuncReturnTypes[fun.Sel.Name]
+			}
+		}
+	}
+	return nil
+}
+
+// hasClass tells whether a type is converted to a class its methods are written into
+func hasClass(key string) bool {
+	outType := oTypes.get(key)
+	return outType != nil && outType.structType != nil || oEnums.set[key] != nil || hasValueClass(key)
+}
+
+// deferMethod keeps a method until the class of its receiver type is written
+func (outTypes *OutTypes) deferMethod(key string, funcDecl *ast.FuncDecl, out *Output) bool {
+	if !hasClass(key) || outTypes.getFunctionsPos(key) != nil {
+		return false
+	}
+	outTypes.ensure(key)
+	outType := outTypes.set[key]
+	outType.pendingMethods = append(outType.pendingMethods, &PendingMethod{funcDecl, out})
+	return true
+}
+
+// convertPendingMethods writes the methods seen before the class of their type
+func convertPendingMethods(key string) {
+	outType := oTypes.get(key)
+	if outType == nil {
+		return
+	}
+	pendingMethods := outType.pendingMethods
+	outType.pendingMethods = nil
+	for _, pending := range pendingMethods {
+		convertFuncDecl(pending.funcDecl, pending.out)
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import "testing"
+
+// the packages of the project are told apart by their import paths, not by their names
+func TestSameNamedPackages(t *testing.T) {
+	sources := map[string]string{
+		"app/main.go": `package main
+
+import (
+	"fmt"
+
+	"app/b/util"
+	"app/shapes"
+)
+
+func main() {
+	var p util.Pair = 3
+	q := p
+	fmt.Println(p.Label(), q, shapes.Total(shapes.Origin))
+}
+`,
+		"app/a/util/util.go": `package util
+
+type Pair struct {
+	X, Y int
+}
+
+func (p Pair) Sum() int { return p.X + p.Y }
+`,
+		"app/b/util/util.go": `package util
+
+type Pair int
+
+func (p Pair) Label() string { return "pair" }
+`,
+		"app/shapes/shapes.go": `package shapes
+
+import "app/a/util"
+
+var Origin = util.Pair{}
+
+func Total(p util.Pair) int {
+	q := p
+	return q.Sum()
+}
+`,
+	}
+	tr := translate(t, sources)
+	expectContains(t, tr.java(t, "app/Main.java"),
+		"int p = 3;",
+		"int q = p;",
+		"new app.b.util.Pair(p).Label()",
+	)
+	expectContains(t, tr.java(t, "app/shapes/Shapes.java"),
+		"public static app.a.util.Pair Origin = new app.a.util.Pair();",
+		"public static int Total(app.a.util.Pair p) {",
+		"app.a.util.Pair q = p.copy$();",
+		"return q.Sum();",
+	)
+	expectContains(t, tr.java(t, "app/a/util/Pair.java"), "public int Sum() {")
+	expectContains(t, tr.java(t, "app/b/util/Pair.java"), "public String Label() {")
+}
@@ -0,0 +1,255 @@
+package main
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"strconv"
+	"strings"
+)
+
+// composite literals are translated by their type, the types of elided inner literals come from the outer literal
+
+// anonymous struct types become static classes of the package class, identical types share a class
+type AnonStructs struct {
+	names map[string]string
+	ips   map[string]*InsertPoint
+}
+
+var oAnonStructs = &AnonStructs{map[string]string{}, map[string]*InsertPoint{}}
+
+// setPackagePos sets the position of the anonymous struct classes of the package being converted
+func (anonStructs *AnonStructs) setPackagePos(insertPoint *InsertPoint) {
+	anonStructs.ips[oFileSet.currentPackage] = insertPoint
+}
+
+func anonStructKey(structType *ast.StructType) string {
+	return oFileSet.currentPackage + " " + types.ExprString(structType)
+}
+
+// lookup returns the class name of an anonymous struct type seen before
+func (anonStructs *AnonStructs) lookup(structType *ast.StructType) string {
+	return anonStructs.names[anonStructKey(structType)]
+}
+
+// anonStructName returns the class name of an anonymous struct type, the class is generated when first seen
+func anonStructName(structType *ast.StructType, out *Output) string {
+	if name := oAnonStructs.lookup(structType); name != "" {
+		return name
+	}
+	key := anonStructKey(structType)
+	ident := ast.NewIdent("anonStruct" + strconv.Itoa(len(oAnonStructs.names)+1))
+	oAnonStructs.names[key] = ident.Name
+	oTypes.setTypeSpec(typeKey(ident.Name, out), currentPkgName(out), out.outSource.system, &ast.TypeSpec{Name: ident, Type: structType})
+	if insertPoint := oAnonStructs.ips[oFileSet.currentPackage]; insertPoint != nil {
+		classOut := insertPoint.getOut()
+		convertStruct(structType, ident, classOut)
+		classOut.Println("")
+	}
+	return ident.Name
+}
+
+// structTypeOf returns the struct type of named and anonymous struct types
+func structTypeOf(typeExpr ast.Expr) *ast.StructType {
+	switch tp := underlyingType(typeExpr).(type) {
+	case *ast.StructType:
+		return tp
+	case *ast.SelectorExpr:
+		if outType := lookupType(typeKeyIn(tp, "")); outType != nil {
+			return outType.structType
+		}
+	}
+	return nil
+}
+
+func convertCompositeLit(compositeLit *ast.CompositeLit, typeExpr ast.Expr, out *Output) {
+	if starType, ok := typeExpr.(*ast.StarExpr); ok {
+		// elided &T{}
+		typeExpr = starType.X
+	}
+	if typeExpr == nil {
+		// the type is unknown
+		out.Print("null")
+		return
+	}
+	switch tp := underlyingType(typeExpr).(type) {
+	case *ast.MapType:
+		convertMapLit(compositeLit, tp, out)
+		return
+	case *ast.ArrayType:
+		if tp.Len == nil {
+			convertSliceLit(compositeLit, tp, out)
+		} else {
+			convertArrayLit(compositeLit, tp, out)
+		}
+		return
+	}
+	if structType := structTypeOf(typeExpr); structType != nil {
+		convertStructLit(compositeLit, typeExpr, structType, out)
+		return
+	}
+	out.Print("new ")
+	resolveOpts := newResolveTypeOpts()
+	resolveOpts.ImplementationClass = true
+	convertType(typeExpr, out, resolveOpts)
+	out.Print("()")
+}
+
+// convertLitValue converts an element of a composite literal to the element type
+func convertLitValue(typeExpr ast.Expr, expr ast.Expr, out *Output) {
+	recordConversion(expr, typeExpr, out)
+	if convertValueCopy(typeExpr, expr, out) {
+		return
+	}
+	if compositeLit, ok := expr.(*ast.CompositeLit); ok && compositeLit.Type == nil {
+		convertCompositeLit(compositeLit, typeExpr, out)
+		return
+	}
+	javaType := javaPrimitiveOf(typeExpr)
+	if javaType == "" || javaType == "boolean" {
+		convertExpr(expr, out)
+		return
+	}
+	if value := evalConst(expr, 0, lookupConst(oFileSet.currentPackage)); value.Kind() == constant.Int || value.Kind() == constant.Float {
+		literal := javaConstLiteral(value, javaType)
+		if (javaType == "byte" || javaType == "short") && !strings.HasPrefix(literal, "(") {
+			// constants are not narrowed in method arguments
+			literal = "(" + javaType + ") " + literal
+		}
+		out.Print(literal)
+		return
+	}
+	if isNarrowType(javaType) && javaPrimitiveOf(typeOf(expr, out)) != javaType {
+		out.Print("(", javaType, ") ")
+		convertOperand(expr, out)
+		return
+	}
+	convertExpr(expr, out)
+}
+
+// litValues orders the elements of an array or slice literal by their keys, missing elements are nil
+func litValues(compositeLit *ast.CompositeLit, length int) []ast.Expr {
+	values := []ast.Expr{}
+	idx := 0
+	for _, elt := range compositeLit.Elts {
+		if keyValue, ok := elt.(*ast.KeyValueExpr); ok {
+			key := evalConst(keyValue.Key, 0, lookupConst(oFileSet.currentPackage))
+			if keyIdx, exact := constant.Int64Val(constant.ToInt(key)); exact {
+				idx = int(keyIdx)
+			}
+			elt = keyValue.Value
+		}
+		for len(values) <= idx {
+			values = append(values, nil)
+		}
+		values[idx] = elt
+		idx++
+	}
+	for len(values) < length {
+		values = append(values, nil)
+	}
+	return values
+}
+
+func convertLitValues(values []ast.Expr, elemType ast.Expr, convertValue func(ast.Expr), out *Output) {
+	for idx, value := range values {
+		if idx > 0 {
+			out.Print(", ")
+		}
+		if value == nil {
+			convertZeroValue(elemType, out)
+		} else {
+			convertValue(value)
+		}
+	}
+}
+
+// convertSliceLit creates the slice of a slice literal with the of method of its slice class
+func convertSliceLit(compositeLit *ast.CompositeLit, arrayType *ast.ArrayType, out *Output) {
+	className := sliceClass(arrayType.Elt)
+	useSliceClass(className, out)
+	out.Print(className, ".")
+	if className == "Slice" {
+		convertSliceTypeArg(arrayType.Elt, out)
+	}
+	out.Print("of(")
+	convertLitValues(litValues(compositeLit, 0), arrayType.Elt, func(value ast.Expr) {
+		convertLitValue(arrayType.Elt, value, out)
+	}, out)
+	out.Print(")")
+}
+
+// convertArrayLit creates a java array with an initializer, missing elements are zero values
+func convertArrayLit(compositeLit *ast.CompositeLit, arrayType *ast.ArrayType, out *Output) {
+	length := 0
+	if _, isEllipsis := arrayType.Len.(*ast.Ellipsis); !isEllipsis {
+		lenValue := evalConst(arrayType.Len, 0, lookupConst(oFileSet.currentPackage))
+		if n, exact := constant.Int64Val(constant.ToInt(lenValue)); exact {
+			length = int(n)
+		}
+	}
+	out.Print("new ")
+	convertErasedType(arrayType.Elt, out)
+	out.Print("[]{")
+	convertLitValues(litValues(compositeLit, length), arrayType.Elt, func(value ast.Expr) {
+		convertLitValue(arrayType.Elt, value, out)
+	}, out)
+	out.Print("}")
+}
+
+// convertErasedType prints a type without type arguments, java does not create generic arrays
+func convertErasedType(typeExpr ast.Expr, out *Output) {
+	iout := out.NewIndependentOutput()
+	nrto := newResolveTypeOpts()
+	nrto.DirectEval = true
+	convertType(typeExpr, iout, nrto)
+	typeName := iout.out.buf.String()
+	if start := strings.Index(typeName, "<"); start >= 0 {
+		end := strings.LastIndex(typeName, ">")
+		typeName = typeName[:start] + typeName[end+1:]
+	}
+	out.Print(typeName)
+}
+
+// convertStructLit passes the fields to the constructor in declaration order, omitted fields are zero values
+func convertStructLit(compositeLit *ast.CompositeLit, typeExpr ast.Expr, structType *ast.StructType, out *Output) {
+	resolveOpts := newResolveTypeOpts()
+	resolveOpts.ImplementationClass = true
+	out.Print("new ")
+	convertType(typeExpr, out, resolveOpts)
+	out.Print("(")
+	fields := equalityFields(structType)
+	if len(compositeLit.Elts) > 0 {
+		// unkeyed literals list the blank fields too
+		positions := []string{}
+		for _, field := range structType.Fields.List {
+			if len(field.Names) == 0 {
+				positions = append(positions, embeddedFieldName(field.Type))
+			}
+			for _, name := range field.Names {
+				positions = append(positions, name.Name)
+			}
+		}
+		values := map[string]ast.Expr{}
+		for idx, elt := range compositeLit.Elts {
+			if keyValue, ok := elt.(*ast.KeyValueExpr); ok {
+				if keyIdent, ok := keyValue.Key.(*ast.Ident); ok {
+					values[keyIdent.Name] = keyValue.Value
+				}
+			} else if idx < len(positions) {
+				values[positions[idx]] = elt
+			}
+		}
+		for idx, field := range fields {
+			if idx > 0 {
+				out.Print(", ")
+			}
+			if value := values[field.name]; value != nil {
+				convertLitValue(field.typeExpr, value, out)
+			} else {
+				convertZeroValue(field.typeExpr, out)
+			}
+		}
+	}
+	out.Print(")")
+}
@@ -0,0 +1,447 @@
+package main
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// constant expressions are folded with exact arithmetic, the java source gets the computed values
+
+// constValues holds the evaluated constants by package path and name
+var constValues = map[string]constant.Value{}
+
+func init() {
+	// the unsigned maximums of math are folded, java prints them for the type they are used with
+	for name, value := range map[string]uint64{
+		"MaxUint8": math.MaxUint8, "MaxUint16": math.MaxUint16, "MaxUint32": math.MaxUint32,
+		"MaxUint64": math.MaxUint64, "MaxUint": math.MaxUint,
+	} {
+		constValues[constKey("math", name)] = constant.MakeUint64(value)
+	}
+}
+
+type ConstData struct {
+	iota     int64
+	typeExpr ast.Expr
+	values   []ast.Expr
+}
+
+var goIntTypes = map[string]bool{
+	"int": true, "int8": true, "int16": true, "int32": true, "int64": true, "rune": true,
+	"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true, "byte": true, "uintptr": true,
+}
+
+var goUnsignedBits = map[string]uint{
+	"uint": 64, "uint8": 8, "uint16": 16, "uint32": 32, "uint64": 64, "byte": 8, "uintptr": 64,
+}
+
+var goFloatTypes = map[string]bool{
+	"float32": true, "float64": true,
+}
+
+var javaIntRanges = map[string][2]int64{
+	"byte":  {-128, 127},
+	"short": {-32768, 32767},
+	"char":  {0, 65535},
+}
+
+func constKey(pkgPath, name string) string {
+	return pkgPath + "." + name
+}
+
+func lookupConst(pkgPath string) func(string) constant.Value {
+	return func(name string) constant.Value {
+		if value, has := constValues[constKey(pkgPath, name)]; has {
+			return value
+		}
+		return constant.MakeUnknown()
+	}
+}
+
+func isIntValue(value constant.Value) bool {
+	return value.Kind() == constant.Int
+}
+
+// evalConst evaluates a constant expression, the result is unknown if it is not constant
+func evalConst(expr ast.Expr, iota int64, lookup func(string) constant.Value) constant.Value {
+	unknown := constant.MakeUnknown()
+	switch tp := expr.(type) {
+	case *ast.BasicLit:
+		if tp.Kind == token.IMAG {
+			return unknown
+		}
+		return constant.MakeFromLiteral(tp.Value, tp.Kind, 0)
+	case *ast.Ident:
+		switch tp.Name {
+		case "iota":
+			return constant.MakeInt64(iota)
+		case "true":
+			return constant.MakeBool(true)
+		case "false":
+			return constant.MakeBool(false)
+		}
+		return lookup(tp.Name)
+	case *ast.ParenExpr:
+		return evalConst(tp.X, iota, lookup)
+	case *ast.SelectorExpr:
+		if pkgIdent, ok := tp.X.(*ast.Ident); ok {
+			if value, has := constValues[constKey(pkgIdent.Name, tp.Sel.Name)]; has {
+				return value
+			}
+		}
+		return unknown
+	case *ast.UnaryExpr:
+		x := evalConst(tp.X, iota, lookup)
+		if x.Kind() == constant.Unknown {
+			return unknown
+		}
+		if tp.Op == token.XOR && !isIntValue(x) {
+			return unknown
+		}
+		if tp.Op != token.ADD && tp.Op != token.SUB && tp.Op != token.XOR && tp.Op != token.NOT {
+			// &x of a constant is not constant
+			return unknown
+		}
+		prec := uint(0)
+		if call, ok := tp.X.(*ast.CallExpr); ok {
+			if funIdent, ok := call.Fun.(*ast.Ident); ok {
+				prec = goUnsignedBits[underlyingTypeName(funIdent.Name)]
+			}
+		}
+		return constant.UnaryOp(tp.Op, x, prec)
+	case *ast.BinaryExpr:
+		x := evalConst(tp.X, iota, lookup)
+		y := evalConst(tp.Y, iota, lookup)
+		if x.Kind() == constant.Unknown || y.Kind() == constant.Unknown {
+			return unknown
+		}
+		switch tp.Op {
+		case token.SHL, token.SHR:
+			shift, ok := constant.Uint64Val(constant.ToInt(y))
+			if !ok || !isIntValue(constant.ToInt(x)) {
+				return unknown
+			}
+			return constant.Shift(constant.ToInt(x), tp.Op, uint(shift))
+		case token.EQL, token.NEQ, token.LSS, token.LEQ, token.GTR, token.GEQ:
+			return constant.MakeBool(constant.Compare(x, tp.Op, y))
+		case token.QUO:
+			if constant.Sign(y) == 0 && y.Kind() != constant.Bool {
+				return unknown
+			}
+			if isIntValue(x) && isIntValue(y) {
+				// integer division of untyped integer constants
+				return constant.BinaryOp(x, token.QUO_ASSIGN, y)
+			}
+		case token.REM, token.AND, token.OR, token.XOR, token.AND_NOT:
+			if !isIntValue(x) || !isIntValue(y) {
+				return unknown
+			}
+			if tp.Op == token.REM && constant.Sign(y) == 0 {
+				return unknown
+			}
+		}
+		return constant.BinaryOp(x, tp.Op, y)
+	case *ast.CallExpr:
+		if len(tp.Args) != 1 {
+			return unknown
+		}
+		funIdent, ok := tp.Fun.(*ast.Ident)
+		if !ok {
+			return unknown
+		}
+		x := evalConst(tp.Args[0], iota, lookup)
+		switch {
+		case funIdent.Name == "len" && x.Kind() == constant.String:
+			return constant.MakeInt64(int64(len(constant.StringVal(x))))
+		case x.Kind() == constant.Unknown:
+			return unknown
+		}
+		typeName := underlyingTypeName(funIdent.Name)
+		switch {
+		case goIntTypes[typeName]:
+			return truncateConst(constant.ToInt(x), typeName)
+		case goFloatTypes[typeName]:
+			return constant.ToFloat(x)
+		case typeName == "string" && x.Kind() == constant.String:
+			return x
+		}
+	}
+	return unknown
+}
+
+// underlyingTypeName follows the named types declared with a predeclared type
+func underlyingTypeName(name string) string {
+	for i := 0; i < 8; i++ {
+		aliasName, has := oFileSet.typeAliases[name]
+		if !has {
+			break
+		}
+		name = aliasName
+	}
+	return name
+}
+
+// truncateConst keeps unsigned complements in range, ^uint8(0) is 255
+func truncateConst(value constant.Value, typeName string) constant.Value {
+	if bits, unsigned := goUnsignedBits[typeName]; unsigned && isIntValue(value) && constant.Sign(value) < 0 {
+		mask := constant.Shift(constant.MakeInt64(1), token.SHL, bits)
+		return constant.BinaryOp(value, token.ADD, mask)
+	}
+	return value
+}
+
+// untypedJavaType returns the java type of the default type of an untyped constant
+func untypedJavaType(value constant.Value) string {
+	switch value.Kind() {
+	case constant.Bool:
+		return "boolean"
+	case constant.String:
+		return "String"
+	case constant.Int:
+		if _, exact := constant.Int64Val(value); exact && fitsInt(value) {
+			return "int"
+		}
+		return "long"
+	case constant.Float:
+		return "double"
+	}
+	return "Object"
+}
+
+func untypedGoType(value constant.Value) string {
+	switch value.Kind() {
+	case constant.Bool:
+		return "bool"
+	case constant.String:
+		return "string"
+	case constant.Float:
+		return "float64"
+	}
+	if fitsInt(value) {
+		return "int"
+	}
+	return "int64"
+}
+
+func fitsInt(value constant.Value) bool {
+	v, exact := constant.Int64Val(value)
+	return exact && v >= -1<<31 && v < 1<<31
+}
+
+// javaConstLiteral prints a constant value as a java literal of the given java type
+func javaConstLiteral(value constant.Value, javaType string) string {
+	switch value.Kind() {
+	case constant.Bool:
+		return value.ExactString()
+	case constant.String:
+		return javaQuote(constant.StringVal(value))
+	case constant.Int:
+		switch javaType {
+		case "double", "float", "Double", "Float":
+			return javaConstLiteral(constant.ToFloat(value), javaType)
+		case "long", "Long":
+			if _, exact := constant.Int64Val(value); !exact {
+				// unsigned 64 bit values wrap around
+				v, _ := constant.Uint64Val(value)
+				return strconv.FormatInt(int64(v), 10) + "L"
+			}
+			return value.ExactString() + "L"
+		case "byte", "short", "char":
+			if v, exact := constant.Int64Val(value); !exact || v < javaIntRanges[javaType][0] || v > javaIntRanges[javaType][1] {
+				return "(" + javaType + ") " + value.ExactString()
+			}
+			return value.ExactString()
+		}
+		if !fitsInt(value) {
+			if javaType == "int" || javaType == "Integer" {
+				// unsigned 32 bit values wrap around
+				v, _ := constant.Uint64Val(value)
+				return strconv.FormatInt(int64(int32(uint32(v))), 10)
+			}
+			return value.ExactString() + "L"
+		}
+		return value.ExactString()
+	case constant.Float:
+		switch javaType {
+		case "int", "long", "Integer", "Long":
+			if intValue := constant.ToInt(value); intValue.Kind() == constant.Int {
+				return javaConstLiteral(intValue, javaType)
+			}
+		}
+		f, _ := constant.Float64Val(value)
+		literal := strconv.FormatFloat(f, 'g', -1, 64)
+		if !strings.ContainsAny(literal, ".eE") {
+			literal += ".0"
+		}
+		if javaType == "float" || javaType == "Float" {
+			literal += "f"
+		}
+		return literal
+	}
+	return value.ExactString()
+}
+
+// convertTypedConst prints a constant assigned to a value of typeExpr as a literal of its java type, it returns false
+// if the value is not constant or has the java type. java narrows int constants in assignments but not in arguments
+func convertTypedConst(typeExpr ast.Expr, expr ast.Expr, isArg bool, out *Output) bool {
+	javaType := javaPrimitiveOf(typeExpr)
+	if javaType == "" || javaType == "boolean" {
+		return false
+	}
+	value := evalConst(expr, 0, lookupConst(oFileSet.currentPackage))
+	if value.Kind() != constant.Int && value.Kind() != constant.Float {
+		return false
+	}
+	if typeIdent, ok := underlyingType(typeExpr).(*ast.Ident); ok && value.Kind() == constant.Int {
+		value = truncateConst(value, underlyingTypeName(typeIdent.Name))
+	}
+	literal := javaConstLiteral(value, javaType)
+	if enumData := enumOf(typeExpr, out); enumData != nil {
+		literal = enumData.name + ".valueOf(" + javaConstLiteral(value, enumData.javaType()) + ")"
+	} else if isArg && isNarrowType(javaType) && !strings.HasPrefix(literal, "(") {
+		literal = "(" + javaType + ") " + literal
+	}
+	out.Print(literal)
+	return true
+}
+
+// isPackageConst tells whether a selector is a constant of another package folded by evalConst
+func isPackageConst(expr ast.Expr) bool {
+	_, isSelector := expr.(*ast.SelectorExpr)
+	return isSelector && isConstExpr(expr)
+}
+
+// convertPackageConst prints a constant of another package as a literal of its java type
+func convertPackageConst(selectorExpr *ast.SelectorExpr, out *Output) bool {
+	if !isPackageConst(selectorExpr) {
+		return false
+	}
+	value := evalConst(selectorExpr, 0, lookupConst(oFileSet.currentPackage))
+	out.Print(javaConstLiteral(value, javaPrimitiveOf(typeOf(selectorExpr, out))))
+	return true
+}
+
+// javaQuote quotes a string as a java string literal
+func javaQuote(value string) string {
+	var sb strings.Builder
+	sb.WriteString("\"")
+	for len(value) > 0 {
+		r, size := utf8.DecodeRuneInString(value)
+		if r == utf8.RuneError && size == 1 {
+			// invalid bytes are kept as lone surrogates, see GoString
+			sb.WriteString(javaUnicodeEscape(0xdc00 + rune(value[0])))
+		} else {
+			sb.WriteString(javaEscapeRune(r, '"'))
+		}
+		value = value[size:]
+	}
+	sb.WriteString("\"")
+	return sb.String()
+}
+
+func javaEscapeRune(r rune, quote rune) string {
+	switch r {
+	case '\\':
+		return "\\\\"
+	case '\n':
+		return "\\n"
+	case '\t':
+		return "\\t"
+	case '\r':
+		return "\\r"
+	case '\b':
+		return "\\b"
+	case '\f':
+		return "\\f"
+	case quote:
+		return "\\" + string(quote)
+	}
+	if r >= 0x20 && r < 0x7f {
+		return string(r)
+	}
+	if r > 0xffff {
+		// surrogate pair
+		r -= 0x10000
+		return javaUnicodeEscape(0xd800+(r>>10)) + javaUnicodeEscape(0xdc00+(r&0x3ff))
+	}
+	return javaUnicodeEscape(r)
+}
+
+func javaUnicodeEscape(r rune) string {
+	hex := strconv.FormatInt(int64(r), 16)
+	return "\\u" + strings.Repeat("0", 4-len(hex)) + hex
+}
+
+func isLocalDecl(out *Output) bool {
+	return out.structuralInfo.LocalDecl
+}
+
+func convertConstSpec(valueSpec *ast.ValueSpec, out *Output, constData *ConstData) {
+	if len(valueSpec.Values) > 0 {
+		constData.typeExpr = valueSpec.Type
+		constData.values = valueSpec.Values
+	}
+	pkgPath := oFileSet.currentPackage
+	for idx, name := range valueSpec.Names {
+		if idx >= len(constData.values) {
+			break
+		}
+		valueExpr := constData.values[idx]
+		value := evalConst(valueExpr, constData.iota, lookupConst(pkgPath))
+		if constData.typeExpr != nil {
+			if typeIdent, ok := constData.typeExpr.(*ast.Ident); ok && value.Kind() != constant.Unknown {
+				typeName := underlyingTypeName(typeIdent.Name)
+				value = truncateConst(value, typeName)
+				if goFloatTypes[typeName] {
+					// the constants depending on it are folded as floats
+					value = constant.ToFloat(value)
+				}
+			}
+		}
+		if name.Name == "_" {
+			continue
+		}
+		if value.Kind() != constant.Unknown {
+			constValues[constKey(pkgPath, name.Name)] = value
+		}
+		if !isLocalDecl(out) {
+			convertExport(name, out)
+			out.Print("static ")
+		}
+		convertConst(true, out)
+		nrto := newResolveTypeOpts()
+		if constData.typeExpr != nil {
+			convertType(constData.typeExpr, out, nrto)
+			out.outSource.getPackage().AddVarType(name.Name, constData.typeExpr)
+			out.AddVar(name.Name, constData.typeExpr)
+		} else if value.Kind() != constant.Unknown {
+			out.Print(untypedJavaType(value))
+			out.AddVar(name.Name, ast.NewIdent(untypedGoType(value)))
+		} else {
+			resolveType(valueExpr, out, nrto)
+		}
+		out.Print(" ")
+		convertIdent(name, out)
+		out.Print(" = ")
+		if value.Kind() == constant.Unknown {
+			convertExpr(valueExpr, out)
+		} else if constData.typeExpr != nil {
+			// the java type of named types is known after every file is converted
+			typeExpr := constData.typeExpr
+			pos := out.getPosition()
+			pos.postEvalFn = func(postOut *Output) {
+				typeOut := postOut.NewIndependentOutput()
+				convertType(typeExpr, typeOut, newResolveTypeOpts())
+				postOut.Print(javaConstLiteral(value, typeOut.out.buf.String()))
+			}
+		} else {
+			out.Print(javaConstLiteral(value, untypedJavaType(value)))
+		}
+		convertStmtEnd(out)
+	}
+}
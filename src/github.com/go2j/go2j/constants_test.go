@@ -0,0 +1,29 @@
+package main
+
+import "testing"
+
+// typed float constants are folded as floats, even when they are written as integers
+func TestFloatConstFolding(t *testing.T) {
+	tr := translate(t, map[string]string{"app/main.go": `package main
+
+import "fmt"
+
+type Celsius float64
+
+const F float64 = 7
+const G = F / 2
+const Boiling Celsius = 100
+const Half = Boiling / 8
+
+func main() {
+	const L float32 = 3
+	fmt.Println(F, G, Boiling, Half, L/2)
+}
+`})
+	expectContains(t, tr.java(t, "app/Main.java"),
+		"public static final double F = 7.0;",
+		"public static final double G = 3.5;",
+		"public static final double Half = 12.5;",
+		"Fmt.Println(F, G, Boiling, Half, 1.5f);",
+	)
+}
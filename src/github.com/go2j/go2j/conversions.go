@@ -0,0 +1,233 @@
+package main
+
+import (
+	"go/ast"
+	"go/constant"
+)
+
+// type conversions are translated by the kinds of their source and target types.
+// integers keep their bits in the java type of the same size, unsigned values are masked when widened
+
+// typeConversion maps the string conversions to GoString methods
+var typeConversion = map[string]string{
+	"string->bytes": "bytes",
+	"string->runes": "runes",
+	"bytes->string": "fromBytes",
+	"runes->string": "fromRunes",
+	"int->string":   "fromRune",
+	"uint->string":  "fromRune",
+}
+
+var javaIntMasks = map[string]string{
+	"byte":  "0xFF",
+	"short": "0xFFFF",
+	"int":   "0xFFFFFFFFL",
+}
+
+var javaTypeSizes = map[string]int{
+	"byte": 1, "short": 2, "int": 4, "long": 8, "float": 4, "double": 8,
+}
+
+// conversionKind classifies a type for the conversion table
+func conversionKind(typeExpr ast.Expr) string {
+	switch tp := underlyingType(typeExpr).(type) {
+	case *ast.Ident:
+		name := underlyingTypeName(tp.Name)
+		switch {
+		case name == "string":
+			return "string"
+		case goUnsignedBits[name] > 0:
+			return "uint"
+		case goIntTypes[name]:
+			return "int"
+		case goFloatTypes[name]:
+			return "float"
+		}
+	case *ast.ArrayType:
+		if tp.Len != nil {
+			return ""
+		}
+		switch {
+		case isBasicType(tp.Elt, "byte", "uint8"):
+			return "bytes"
+		case isBasicType(tp.Elt, "rune", "int32"):
+			return "runes"
+		}
+	}
+	return ""
+}
+
+func useGoNumbers(out *Output) {
+	out.outSource.addSysImportName(JI_GO_NUMBERS.typeName, JI_GO_NUMBERS.qualifiedName)
+}
+
+func isNumericKind(kind string) bool {
+	return kind == "int" || kind == "uint" || kind == "float"
+}
+
+func enumOf(typeExpr ast.Expr, out *Output) *EnumData {
+	if ident, ok := typeExpr.(*ast.Ident); ok {
+		return oEnums.set[typeKey(ident.Name, out)]
+	}
+	return nil
+}
+
+// convertOperand prints an expression used as an operand of a cast
+func convertOperand(expr ast.Expr, out *Output) {
+	if _, isBinary := expr.(*ast.BinaryExpr); isBinary {
+		out.Print("(")
+		convertExpr(expr, out)
+		out.Print(")")
+		return
+	}
+	convertExpr(expr, out)
+}
+
+// convertConversion translates T(x), it returns false if the call is not a conversion
+func convertConversion(callExpr *ast.CallExpr, out *Output) bool {
+	if len(callExpr.Args) != 1 || !isTypeExpr(callExpr.Fun, out) {
+		return false
+	}
+	dstType := callExpr.Fun
+	if parenExpr, ok := dstType.(*ast.ParenExpr); ok {
+		dstType = parenExpr.X
+	}
+	arg := callExpr.Args[0]
+	srcType := typeOf(arg, out)
+	dstKind, srcKind := conversionKind(dstType), conversionKind(srcType)
+
+	if dstEnum := enumOf(dstType, out); dstEnum != nil {
+		if srcEnum := enumOf(srcType, out); srcEnum == dstEnum {
+			convertExpr(arg, out)
+			return true
+		}
+		out.Print(dstEnum.name, ".valueOf(")
+		convertNumericConversion(ast.NewIdent(dstEnum.valueType), srcType, arg, out)
+		out.Print(")")
+		return true
+	}
+	if srcEnum := enumOf(srcType, out); srcEnum != nil && dstKind != "" {
+		if dstKind == "string" {
+			// the String method of the enum is not used by conversions
+			convertFromRune(ast.NewIdent(srcEnum.valueType), &ast.SelectorExpr{X: arg, Sel: ast.NewIdent("value")}, out)
+			return true
+		}
+		convertNumericConversion(dstType, ast.NewIdent(srcEnum.valueType), &ast.SelectorExpr{X: arg, Sel: ast.NewIdent("value")}, out)
+		return true
+	}
+
+	if method := typeConversion[srcKind+"->"+dstKind]; method == "fromRune" {
+		convertFromRune(srcType, arg, out)
+		return true
+	} else if method != "" {
+		printGoStringCall(method, out, arg)
+		return true
+	}
+	switch {
+	case isNumericKind(dstKind):
+		convertNumericConversion(dstType, srcType, arg, out)
+	case convertStructConversion(dstType, srcType, arg, out):
+	default:
+		// conversions between types of the same java type
+		convertOperand(arg, out)
+	}
+	return true
+}
+
+// convertFromRune prints string(r) of an integer, the unsigned values are masked to their rune
+func convertFromRune(srcType ast.Expr, arg ast.Expr, out *Output) {
+	useGoString(out)
+	out.Print("GoString.fromRune(")
+	if conversionKind(srcType) == "uint" {
+		convertNumericConversion(ast.NewIdent("int64"), srcType, arg, out)
+	} else {
+		convertExpr(arg, out)
+	}
+	out.Print(")")
+}
+
+// convertNumericConversion prints a numeric value converted to the numeric type dstType
+func convertNumericConversion(dstType, srcType ast.Expr, arg ast.Expr, out *Output) {
+	dstJava, srcJava := javaPrimitiveOf(dstType), javaPrimitiveOf(srcType)
+	dstKind, srcKind := conversionKind(dstType), conversionKind(srcType)
+	if value := evalConst(arg, 0, lookupConst(oFileSet.currentPackage)); value.Kind() == constant.Int || value.Kind() == constant.Float {
+		if dstIdent, ok := underlyingType(dstType).(*ast.Ident); ok && dstKind != "float" {
+			value = truncateConst(constant.ToInt(value), underlyingTypeName(dstIdent.Name))
+		}
+		out.Print(javaConstLiteral(value, dstJava))
+		return
+	}
+	if srcJava == "" {
+		// the source type is unknown
+		out.Print("(", dstJava, ") ")
+		convertOperand(arg, out)
+		return
+	}
+	switch {
+	case srcKind == "uint" && srcJava == "long" && dstKind == "float":
+		useGoNumbers(out)
+		if dstJava == "float" {
+			out.Print("(float) ")
+		}
+		out.Print("GoNumbers.unsignedToDouble(")
+		convertExpr(arg, out)
+		out.Print(")")
+	case srcKind == "float" && dstKind == "uint" && dstJava == "long":
+		useGoNumbers(out)
+		out.Print("GoNumbers.doubleToUnsigned(")
+		convertExpr(arg, out)
+		out.Print(")")
+	case srcKind == "float" && dstKind == "uint" && dstJava == "int":
+		// values above the int range keep their low bits
+		out.Print("(int) (long) ")
+		convertOperand(arg, out)
+	case srcKind == "uint" && (dstKind == "float" || javaTypeSizes[srcJava] < javaTypeSizes[dstJava]):
+		mask := javaIntMasks[srcJava]
+		switch dstJava {
+		case "long":
+			if srcJava != "int" {
+				mask += "L"
+			}
+		case "int":
+		default:
+			out.Print("(", dstJava, ") ")
+		}
+		out.Print("(")
+		convertOperand(arg, out)
+		out.Print(" & ", mask, ")")
+	case srcJava == dstJava:
+		convertOperand(arg, out)
+	default:
+		out.Print("(", dstJava, ") ")
+		convertOperand(arg, out)
+	}
+}
+
+// convertStructConversion copies the fields of a struct into a struct type of the same fields
+func convertStructConversion(dstType, srcType ast.Expr, arg ast.Expr, out *Output) bool {
+	dstStruct, ok := underlyingType(dstType).(*ast.StructType)
+	if !ok {
+		return false
+	}
+	if _, ok := underlyingType(srcType).(*ast.StructType); !ok {
+		return false
+	}
+	if typeKeyIn(dstType, "") == typeKeyIn(srcType, "") {
+		convertExpr(arg, out)
+		return true
+	}
+	resolveOpts := newResolveTypeOpts()
+	resolveOpts.ImplementationClass = true
+	out.Print("new ")
+	convertType(dstType, out, resolveOpts)
+	out.Print("(")
+	for idx, field := range equalityFields(dstStruct) {
+		if idx > 0 {
+			out.Print(", ")
+		}
+		convertOperand(arg, out)
+		out.Print(".", field.name)
+	}
+	out.Print(")")
+	return true
+}
@@ -0,0 +1,26 @@
+package main
+
+import "testing"
+
+// string of an unsigned integer is the rune of its value, java widens the negative bits
+func TestStringOfUnsigned(t *testing.T) {
+	tr := translate(t, map[string]string{"app/main.go": `package main
+
+import "fmt"
+
+func main() {
+	var b byte = 200
+	var s uint16 = 0xE9
+	var u uint32 = 0x1F600
+	var r rune = 'x'
+	fmt.Println(string(b), string(s), string(u), string(r), string(byte(200)))
+}
+`})
+	expectContains(t, tr.java(t, "app/Main.java"),
+		"GoString.fromRune((b & 0xFFL))",
+		"GoString.fromRune((s & 0xFFFFL))",
+		"GoString.fromRune((u & 0xFFFFFFFFL))",
+		"GoString.fromRune(r)",
+		"GoString.fromRune(200L)",
+	)
+}
@@ -0,0 +1,417 @@
+package main
+
+import (
+	"go/ast"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// embedded fields are translated as composition: the struct gets a field named
+// after the embedded type, promoted fields get accessors and promoted methods
+// get delegating methods
+
+type promotedMember struct {
+	name      string
+	path      []string
+	fieldType ast.Expr
+	funcType  *ast.FuncType
+	pkgName   string
+}
+
+type embeddedType struct {
+	name  string
+	key   string
+	isPtr bool
+}
+
+// currentPkgName returns the qualifier of the type keys of the package being converted, the name
+// of a system package or the path of a package of the project
+func currentPkgName(out *Output) string {
+	if out.outSource.system {
+		return oFileSet.currentPackageName
+	}
+	return out.outSource.path
+}
+
+func qualifyTypeKey(pkgName, name string) string {
+	if pkgName != "" {
+		return pkgName + "." + strings.Title(name)
+	}
+	return strings.Title(name)
+}
+
+// typeKeyName returns the type name of a key
+func typeKeyName(key string) string {
+	return key[strings.LastIndex(key, ".")+1:]
+}
+
+func embeddedFieldName(expr ast.Expr) string {
+	switch tp := expr.(type) {
+	case *ast.Ident:
+		return tp.Name
+	case *ast.StarExpr:
+		return embeddedFieldName(tp.X)
+	case *ast.SelectorExpr:
+		return tp.Sel.Name
+	}
+	return ""
+}
+
+// typeKeyIn returns the oTypes key of a type expression used in package pkgName
+func typeKeyIn(expr ast.Expr, pkgName string) string {
+	switch tp := expr.(type) {
+	case *ast.Ident:
+		return qualifyTypeKey(pkgName, tp.Name)
+	case *ast.StarExpr:
+		return typeKeyIn(tp.X, pkgName)
+	case *ast.SelectorExpr:
+		if ident, ok := tp.X.(*ast.Ident); ok {
+			if pkgPath := ownPackagePath(ident.Name, pkgName); pkgPath != "" {
+				return qualifyTypeKey(pkgPath, tp.Sel.Name)
+			}
+		}
+		return resolveTypeName(tp, true)
+	case *ast.StructType:
+		if name := oAnonStructs.lookup(tp); name != "" {
+			return qualifyTypeKey(pkgName, name)
+		}
+	}
+	return ""
+}
+
+// lookupType finds a type by key. The types of the declarations of an other package are keyed
+// with the package using them, they are found by name if no other type of the project has it.
+func lookupType(key string) *OutType {
+	return oTypes.get(resolveKey(key))
+}
+
+// resolveKey returns the key of the type lookupType finds, a name of more types is looked up in
+// the package being converted
+func resolveKey(key string) string {
+	if oTypes.get(key) != nil {
+		return key
+	}
+	keys := oTypes.names[typeKeyName(key)]
+	if len(keys) == 1 {
+		return keys[0]
+	}
+	for _, otherKey := range keys {
+		if otherKey == qualifyTypeKey(oFileSet.currentPackage, typeKeyName(key)) {
+			return otherKey
+		}
+	}
+	return key
+}
+
+func (outType *OutType) embeddedTypes() []*embeddedType {
+	embeddedList := []*embeddedType{}
+	if outType.structType == nil {
+		return embeddedList
+	}
+	for _, field := range outType.structType.Fields.List {
+		if len(field.Names) > 0 {
+			continue
+		}
+		_, isPtr := field.Type.(*ast.StarExpr)
+		embeddedList = append(embeddedList, &embeddedType{embeddedFieldName(field.Type), typeKeyIn(field.Type, outType.pkgName), isPtr})
+	}
+	return embeddedList
+}
+
+func (outType *OutType) methodNames() []string {
+	names := []string{}
+	for name := range outType.methods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// interfaceMethods collects the methods of an interface including the embedded ones
+func interfaceMethods(interfaceType *ast.InterfaceType, pkgName string, visited map[string]bool) []*promotedMember {
+	members := []*promotedMember{}
+	for _, meth := range interfaceType.Methods.List {
+		switch tp := meth.Type.(type) {
+		case *ast.FuncType:
+			for _, name := range meth.Names {
+				members = append(members, &promotedMember{name: name.Name, funcType: tp, pkgName: pkgName})
+			}
+		case *ast.Ident, *ast.SelectorExpr:
+			key := typeKeyIn(tp, pkgName)
+			embedded := lookupType(key)
+			if visited[key] || embedded == nil || embedded.interfaceType == nil {
+				continue
+			}
+			visited[key] = true
+			members = append(members, interfaceMethods(embedded.interfaceType, embedded.pkgName, visited)...)
+		}
+	}
+	return members
+}
+
+// members lists the fields and methods declared directly on a type
+func (outType *OutType) members() []*promotedMember {
+	if outType.interfaceType != nil {
+		return interfaceMethods(outType.interfaceType, outType.pkgName, map[string]bool{})
+	}
+	members := []*promotedMember{}
+	if outType.structType != nil {
+		for _, field := range outType.structType.Fields.List {
+			for _, name := range fieldNames(field) {
+				members = append(members, &promotedMember{name: name, fieldType: field.Type, pkgName: outType.pkgName})
+			}
+		}
+	}
+	for _, name := range outType.methodNames() {
+		members = append(members, &promotedMember{name: name, funcType: outType.methods[name].Type, pkgName: outType.pkgName})
+	}
+	return members
+}
+
+// promotedMembers walks the embedded types depth by depth. A member is promoted
+// if it is not shadowed by a member of lower depth and it is unique on its depth.
+func (outTypes *OutTypes) promotedMembers(key string) []*promotedMember {
+	outType := lookupType(key)
+	if outType == nil {
+		return nil
+	}
+	blocked := map[string]bool{}
+	for _, member := range outType.members() {
+		blocked[member.name] = true
+	}
+	type level struct {
+		outType *OutType
+		path    []string
+	}
+	visited := map[*OutType]bool{outType: true}
+	current := []level{{outType, nil}}
+	promoted := []*promotedMember{}
+	for len(current) > 0 {
+		next := []level{}
+		for _, entry := range current {
+			for _, embedded := range entry.outType.embeddedTypes() {
+				embeddedOutType := lookupType(embedded.key)
+				if embeddedOutType == nil || visited[embeddedOutType] {
+					continue
+				}
+				path := append(append([]string{}, entry.path...), embedded.name)
+				next = append(next, level{embeddedOutType, path})
+			}
+		}
+		names := []string{}
+		found := map[string][]*promotedMember{}
+		for _, entry := range next {
+			visited[entry.outType] = true
+			for _, member := range entry.outType.members() {
+				if !ast.IsExported(member.name) && member.pkgName != outType.pkgName {
+					// unexported names of other packages are not promoted
+					continue
+				}
+				if len(found[member.name]) == 0 {
+					names = append(names, member.name)
+				}
+				member.path = entry.path
+				found[member.name] = append(found[member.name], member)
+			}
+		}
+		for _, name := range names {
+			if blocked[name] {
+				continue
+			}
+			blocked[name] = true
+			if len(found[name]) == 1 {
+				promoted = append(promoted, found[name][0])
+			}
+		}
+		current = next
+	}
+	return promoted
+}
+
+// fieldTypeOf returns the type of a field or promoted field of a struct
+func fieldTypeOf(key, name string) (ast.Expr, string) {
+	outType := lookupType(key)
+	if outType == nil || outType.structType == nil {
+		return nil, ""
+	}
+	for _, member := range outType.members() {
+		if member.name == name && member.fieldType != nil {
+			return member.fieldType, member.pkgName
+		}
+	}
+	for _, member := range oTypes.promotedMembers(key) {
+		if member.name == name && member.fieldType != nil {
+			return member.fieldType, member.pkgName
+		}
+	}
+	return nil, ""
+}
+
+func promotedFieldPath(key, name string) []string {
+	outType := lookupType(key)
+	if outType == nil || outType.structType == nil {
+		return nil
+	}
+	for _, member := range oTypes.promotedMembers(key) {
+		if member.name == name && member.fieldType != nil {
+			return member.path
+		}
+	}
+	return nil
+}
+
+func convertEmbeddedField(field *ast.Field, out *Output, asParameter bool) {
+	name := embeddedFieldName(field.Type)
+	if name == "" {
+		return
+	}
+	if !asParameter {
+		convertGoTypeAnnotation(field.Type, out)
+		convertExport(ast.NewIdent(name), out)
+	}
+	convertType(field.Type, out, newResolveTypeOpts())
+	out.outSource.getPackage().AddVarType(name, field.Type)
+	out.Print(" ")
+	out.Print(name)
+	if _, isPtr := field.Type.(*ast.StarExpr); !asParameter && !isPtr {
+		// embedded struct values are never nil
+		key := typeKeyIn(field.Type, currentPkgName(out))
+		pos := out.getPosition()
+		pos.postEvalFn = func(postOut *Output) {
+			if embedded := lookupType(key); embedded != nil && embedded.structType != nil {
+				postOut.Print(" = new ")
+				convertType(field.Type, postOut, newResolveTypeOpts())
+				postOut.Print("()")
+			}
+		}
+	}
+	convertStmtEnd(out)
+}
+
+// convertPromotedPath inserts the embedded field names between a selector and its promoted field
+func convertPromotedPath(selectorExpr *ast.SelectorExpr, out *Output) {
+	selectors := []string{}
+	expr := selectorExpr.X
+	for {
+		if sub, ok := expr.(*ast.SelectorExpr); ok {
+			selectors = append([]string{sub.Sel.Name}, selectors...)
+			expr = sub.X
+			continue
+		}
+		break
+	}
+	root, ok := expr.(*ast.Ident)
+	if !ok {
+		return
+	}
+	rootType := out.GetVarType(root.Name)
+	if rootType == nil {
+		return
+	}
+	pkgName := currentPkgName(out)
+	pos := out.getPosition()
+	pos.postEvalFn = func(postOut *Output) {
+		key := typeKeyIn(rootType, pkgName)
+		for _, sel := range selectors {
+			fieldType, fieldPkgName := fieldTypeOf(key, sel)
+			if fieldType == nil {
+				return
+			}
+			key = typeKeyIn(fieldType, fieldPkgName)
+		}
+		for _, name := range promotedFieldPath(key, selectorExpr.Sel.Name) {
+			postOut.Print(".")
+			postOut.Print(name)
+		}
+	}
+}
+
+// withParamNames names the unnamed parameters of a function type, delegating methods pass them on
+func withParamNames(funcType *ast.FuncType) *ast.FuncType {
+	params := &ast.FieldList{}
+	idx := 0
+	for _, field := range funcType.Params.List {
+		names := field.Names
+		if len(names) == 0 {
+			names = []*ast.Ident{ast.NewIdent("arg" + strconv.Itoa(idx))}
+		}
+		idx += len(names)
+		params.List = append(params.List, &ast.Field{Names: names, Type: field.Type})
+	}
+	return &ast.FuncType{Params: params, Results: funcType.Results}
+}
+
+func convertPromotedField(member *promotedMember, out *Output) {
+	nrto := newResolveTypeOpts()
+	nrto.FunctionAsReference = true
+	fieldPath := "this." + strings.Join(member.path, ".") + "." + member.name
+
+	out.Print("public ")
+	convertType(member.fieldType, out, nrto)
+	out.Print(" get")
+	out.Print(strings.Title(member.name))
+	out.Println("() {")
+	out.AddTab().Println("return", fieldPath+";")
+	out.Println("}")
+	out.Println("")
+
+	out.Print("public void set")
+	out.Print(strings.Title(member.name))
+	out.Print("(")
+	convertType(member.fieldType, out, nrto)
+	out.Print(" ")
+	out.Print(member.name)
+	out.Println(") {")
+	out.AddTab().Println(fieldPath, "=", member.name+";")
+	out.Println("}")
+	out.Println("")
+}
+
+func convertPromotedMethod(key string, member *promotedMember, out *Output) {
+	funcType := withParamNames(member.funcType)
+	name := ast.NewIdent(member.name)
+	convertMethodModifiers(key, name, out)
+	convertFuncType(funcType, member.name, name.IsExported(), out, newResolveTypeOpts())
+	out.Println(" {")
+	args := []string{}
+	for _, field := range funcType.Params.List {
+		for _, paramName := range field.Names {
+			if _, isVariadic := field.Type.(*ast.Ellipsis); isVariadic {
+				args = append(args, varargsName(paramName.Name))
+			} else {
+				args = append(args, paramName.Name)
+			}
+		}
+	}
+	call := "this." + strings.Join(member.path, ".") + "." + member.name + "(" + strings.Join(args, ", ") + ");"
+	if funcType.Results == nil {
+		out.AddTab().Println(call)
+	} else {
+		out.AddTab().Println("return", call)
+	}
+	out.Println("}")
+	out.Println("")
+}
+
+// convertPromotedMembers runs in the post evaluation phase, when every type and method is known
+func convertPromotedMembers(key string, out *Output) {
+	outType := lookupType(key)
+	if outType == nil {
+		return
+	}
+	// embedded interfaces are satisfied by forwarding
+	for _, embedded := range outType.embeddedTypes() {
+		embeddedKey := resolveKey(embedded.key)
+		if embeddedOutType := oTypes.get(embeddedKey); embeddedOutType != nil && embeddedOutType.interfaceType != nil && !embeddedOutType.system {
+			oTypes.declareImplements(key, embeddedKey)
+		}
+	}
+	for _, member := range oTypes.promotedMembers(key) {
+		if member.fieldType != nil {
+			convertPromotedField(member, out)
+		} else {
+			convertPromotedMethod(key, member, out)
+		}
+	}
+}
@@ -0,0 +1,601 @@
+package main
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"strings"
+)
+
+// typed iota groups of integer types having a String method are converted to java enums
+
+type EnumData struct {
+	name      string
+	valueType string
+	constants []*EnumConst
+}
+
+type EnumConst struct {
+	name  string
+	value constant.Value
+}
+
+type OutEnums struct {
+	set    map[string]*EnumData
+	decls  map[*ast.GenDecl]*EnumData
+	consts map[string]*EnumData
+}
+
+var oEnums = &OutEnums{map[string]*EnumData{}, map[*ast.GenDecl]*EnumData{}, map[string]*EnumData{}}
+
+// named tells whether an enum of the project has a name
+func (outEnums *OutEnums) named(name string) bool {
+	for _, enumData := range outEnums.set {
+		if enumData.name == strings.Title(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (outEnums *OutEnums) constEnum(name string, out *Output) *EnumData {
+	if out.outSource.system || out.GetVarType(name) != nil {
+		return nil
+	}
+	return outEnums.consts[name]
+}
+
+// javaType returns the java type of the numbers of the enum
+func (enumData *EnumData) javaType() string {
+	if javaType := go2jType[enumData.valueType]; javaType != "" {
+		return javaType
+	}
+	return "long"
+}
+
+func usesIota(exprs []ast.Expr) bool {
+	found := false
+	for _, expr := range exprs {
+		ast.Inspect(expr, func(node ast.Node) bool {
+			if ident, ok := node.(*ast.Ident); ok && ident.Name == "iota" {
+				found = true
+			}
+			return !found
+		})
+	}
+	return found
+}
+
+func isStringMethod(funcDecl *ast.FuncDecl) bool {
+	if funcDecl.Recv == nil || funcDecl.Name.Name != "String" || len(funcDecl.Type.Params.List) > 0 {
+		return false
+	}
+	results := funcDecl.Type.Results
+	if results == nil || len(results.List) != 1 {
+		return false
+	}
+	resultIdent, ok := results.List[0].Type.(*ast.Ident)
+	return ok && resultIdent.Name == "string"
+}
+
+// enumGroupType returns the name of the integer type of a typed iota group
+func enumGroupType(genDecl *ast.GenDecl, pkgPath string, intTypes map[string]string) string {
+	if genDecl.Tok != token.CONST || len(genDecl.Specs) == 0 {
+		return ""
+	}
+	firstSpec := genDecl.Specs[0].(*ast.ValueSpec)
+	typeIdent, ok := firstSpec.Type.(*ast.Ident)
+	if !ok || intTypes[qualifyTypeKey(pkgPath, typeIdent.Name)] == "" || !usesIota(firstSpec.Values) {
+		return ""
+	}
+	for _, spec := range genDecl.Specs[1:] {
+		valueSpec := spec.(*ast.ValueSpec)
+		if len(valueSpec.Values) == 0 {
+			continue
+		}
+		specTypeIdent, ok := valueSpec.Type.(*ast.Ident)
+		if !ok || specTypeIdent.Name != typeIdent.Name {
+			return ""
+		}
+	}
+	return typeIdent.Name
+}
+
+func collectEnums(sourceFiles []*SourceFile, trimPrefix string) {
+	// the int types and the stringers are keyed like the types
+	intTypes := map[string]string{}
+	stringers := map[string]bool{}
+	for _, sourceFile := range sourceFiles {
+		pkgPath := pathOf(sourcePackageKey(sourceFile, trimPrefix))
+		for _, decl := range sourceFile.file.Decls {
+			switch tp := decl.(type) {
+			case *ast.GenDecl:
+				for _, spec := range tp.Specs {
+					if typeSpec, ok := spec.(*ast.TypeSpec); ok {
+						if typeIdent, ok := typeSpec.Type.(*ast.Ident); ok && goIntTypes[typeIdent.Name] {
+							intTypes[qualifyTypeKey(pkgPath, typeSpec.Name.Name)] = typeIdent.Name
+						}
+					}
+				}
+			case *ast.FuncDecl:
+				if isStringMethod(tp) {
+					stringers[qualifyTypeKey(pkgPath, resolveTypeName(tp.Recv.List[0].Type, false))] = true
+				}
+			}
+		}
+	}
+	for _, sourceFile := range sourceFiles {
+		pkgPath := pathOf(sourcePackageKey(sourceFile, trimPrefix))
+		for _, decl := range sourceFile.file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok {
+				continue
+			}
+			typeName := enumGroupType(genDecl, pkgPath, intTypes)
+			key := qualifyTypeKey(pkgPath, typeName)
+			if typeName == "" || !stringers[key] || oEnums.set[key] != nil {
+				continue
+			}
+			enumData := &EnumData{strings.Title(typeName), intTypes[key], []*EnumConst{}}
+			values := []ast.Expr{}
+			groupConsts := map[string]constant.Value{}
+			lookup := func(name string) constant.Value {
+				if value, has := groupConsts[name]; has {
+					return value
+				}
+				return constant.MakeUnknown()
+			}
+			for idx, spec := range genDecl.Specs {
+				valueSpec := spec.(*ast.ValueSpec)
+				if len(valueSpec.Values) > 0 {
+					values = valueSpec.Values
+				}
+				for nameIdx, name := range valueSpec.Names {
+					if nameIdx >= len(values) {
+						break
+					}
+					value := truncateConst(evalConst(values[nameIdx], int64(idx), lookup), enumData.valueType)
+					if name.Name == "_" || value.Kind() != constant.Int {
+						continue
+					}
+					groupConsts[name.Name] = value
+					enumData.constants = append(enumData.constants, &EnumConst{name.Name, value})
+				}
+			}
+			if !enumClosed(enumData, typeName, pkgPath, sourceFiles, trimPrefix) {
+				// the named type gets a class wrapping any value
+				continue
+			}
+			oEnums.set[key] = enumData
+			oEnums.decls[genDecl] = enumData
+			for _, enumConst := range enumData.constants {
+				oEnums.consts[enumConst.name] = enumData
+			}
+		}
+	}
+}
+
+// enumClosed tells whether the values of a type are only its constants, java enums have no other values.
+// the zero value of a type without a 0 constant, conversions of other values and arithmetic leave the constants.
+// the types of the expressions are not known yet, the names declared with the type are taken for its values
+func enumClosed(enumData *EnumData, typeName, pkgPath string, sourceFiles []*SourceFile, trimPrefix string) bool {
+	members := map[string]constant.Value{}
+	hasZero := false
+	for _, enumConst := range enumData.constants {
+		members[enumConst.name] = enumConst.value
+		hasZero = hasZero || constant.Sign(enumConst.value) == 0
+	}
+	if !hasZero {
+		return false
+	}
+	lookup := func(name string) constant.Value {
+		if value, has := members[name]; has {
+			return value
+		}
+		return constant.MakeUnknown()
+	}
+	samePkg := false
+	var isEnumType func(typeExpr ast.Expr) bool
+	isEnumType = func(typeExpr ast.Expr) bool {
+		switch tp := typeExpr.(type) {
+		case *ast.Ident:
+			return samePkg && tp.Name == typeName
+		case *ast.SelectorExpr:
+			return !samePkg && tp.Sel.Name == typeName
+		case *ast.ParenExpr:
+			return isEnumType(tp.X)
+		}
+		return false
+	}
+	// isOther tells whether a constant is not the value of a constant of the enum
+	isOther := func(expr ast.Expr) bool {
+		value := evalConst(expr, 0, lookup)
+		if value.Kind() != constant.Int {
+			return false
+		}
+		for _, enumConst := range enumData.constants {
+			if constant.Compare(value, token.EQL, enumConst.value) {
+				return false
+			}
+		}
+		return true
+	}
+	names, funcs := map[string]bool{}, map[string]*ast.FuncType{}
+	var isEnumExpr func(expr ast.Expr) bool
+	isEnumExpr = func(expr ast.Expr) bool {
+		switch tp := expr.(type) {
+		case *ast.Ident:
+			_, isMember := members[tp.Name]
+			return names[tp.Name] || samePkg && isMember
+		case *ast.SelectorExpr:
+			_, isMember := members[tp.Sel.Name]
+			return names[tp.Sel.Name] || !samePkg && isMember
+		case *ast.ParenExpr:
+			return isEnumExpr(tp.X)
+		case *ast.CallExpr:
+			if len(tp.Args) == 1 && isEnumType(tp.Fun) {
+				return true
+			}
+			funcType := funcs[funcName(tp.Fun)]
+			return funcType != nil && isEnumType(resultTypeAt(funcType, 0))
+		case *ast.BinaryExpr:
+			return !isComparison(tp.Op) && tp.Op != token.LAND && tp.Op != token.LOR && isEnumExpr(tp.X)
+		}
+		return false
+	}
+	addFields := func(fieldList *ast.FieldList) {
+		if fieldList == nil {
+			return
+		}
+		for _, field := range fieldList.List {
+			if isEnumType(field.Type) {
+				for _, name := range field.Names {
+					names[name.Name] = true
+				}
+			}
+		}
+	}
+	eachFile := func(visit func(node ast.Node) bool) {
+		for _, sourceFile := range sourceFiles {
+			samePkg = pathOf(sourcePackageKey(sourceFile, trimPrefix)) == pkgPath
+			ast.Inspect(sourceFile.file, visit)
+		}
+	}
+	// the names declared with the type, twice for the names defined with the others
+	for pass := 0; pass < 3; pass++ {
+		eachFile(func(node ast.Node) bool {
+			switch tp := node.(type) {
+			case *ast.FuncDecl:
+				funcs[tp.Name.Name] = tp.Type
+				addFields(tp.Recv)
+			case *ast.FuncType:
+				addFields(tp.Params)
+				addFields(tp.Results)
+			case *ast.StructType:
+				addFields(tp.Fields)
+			case *ast.ValueSpec:
+				for idx, name := range tp.Names {
+					if isEnumType(tp.Type) || tp.Type == nil && idx < len(tp.Values) && isEnumExpr(tp.Values[idx]) {
+						names[name.Name] = true
+					}
+				}
+			case *ast.AssignStmt:
+				if tp.Tok == token.DEFINE && len(tp.Lhs) == len(tp.Rhs) {
+					for idx, lhs := range tp.Lhs {
+						if ident, ok := lhs.(*ast.Ident); ok && isEnumExpr(tp.Rhs[idx]) {
+							names[ident.Name] = true
+						}
+					}
+				}
+			}
+			return true
+		})
+	}
+	closed := true
+	counters := map[ast.Stmt]bool{}
+	var funcType *ast.FuncType
+	eachFile(func(node ast.Node) bool {
+		switch tp := node.(type) {
+		case *ast.FuncDecl:
+			funcType = tp.Type
+		case *ast.ForStmt:
+			// counted loop variables hold the numbers of the values, see enumCounter
+			if init, ok := tp.Init.(*ast.AssignStmt); ok && init.Tok == token.DEFINE && tp.Post != nil {
+				counters[tp.Post] = true
+			}
+		case *ast.CallExpr:
+			if len(tp.Args) == 1 && isEnumType(tp.Fun) && !isEnumExpr(tp.Args[0]) {
+				isConst := evalConst(tp.Args[0], 0, lookup).Kind() != constant.Unknown
+				closed = closed && isConst && !isOther(tp.Args[0])
+			}
+			if calledType := funcs[funcName(tp.Fun)]; calledType != nil {
+				for idx, arg := range tp.Args {
+					closed = closed && !(isEnumType(fieldTypeAt(calledType.Params, idx)) && isOther(arg))
+				}
+			}
+		case *ast.BinaryExpr:
+			if !isComparison(tp.Op) && tp.Op != token.LAND && tp.Op != token.LOR &&
+				(isEnumExpr(tp.X) || tp.Op != token.SHL && tp.Op != token.SHR && isEnumExpr(tp.Y)) {
+				closed = false
+			}
+		case *ast.UnaryExpr:
+			closed = closed && !((tp.Op == token.SUB || tp.Op == token.XOR) && isEnumExpr(tp.X))
+		case *ast.IncDecStmt:
+			closed = closed && !(isEnumExpr(tp.X) && !counters[tp])
+		case *ast.AssignStmt:
+			for idx, lhs := range tp.Lhs {
+				if !isEnumExpr(lhs) {
+					continue
+				}
+				if tp.Tok != token.ASSIGN && tp.Tok != token.DEFINE || idx < len(tp.Rhs) && isOther(tp.Rhs[idx]) {
+					closed = false
+				}
+			}
+		case *ast.ValueSpec:
+			for _, value := range tp.Values {
+				closed = closed && !(isEnumType(tp.Type) && isOther(value))
+			}
+		case *ast.ReturnStmt:
+			for idx, result := range tp.Results {
+				closed = closed && !(funcType != nil && isEnumType(resultTypeAt(funcType, idx)) && isOther(result))
+			}
+		case *ast.KeyValueExpr:
+			// fields of the type
+			if ident, ok := tp.Key.(*ast.Ident); ok && names[ident.Name] {
+				closed = closed && !isOther(tp.Value)
+			}
+		case *ast.CompositeLit:
+			switch litType := tp.Type.(type) {
+			case *ast.ArrayType:
+				for _, elt := range tp.Elts {
+					if keyValue, ok := elt.(*ast.KeyValueExpr); ok {
+						elt = keyValue.Value
+					}
+					closed = closed && !(isEnumType(litType.Elt) && isOther(elt))
+				}
+			case *ast.MapType:
+				for _, elt := range tp.Elts {
+					if keyValue, ok := elt.(*ast.KeyValueExpr); ok {
+						closed = closed && !(isEnumType(litType.Key) && isOther(keyValue.Key) || isEnumType(litType.Value) && isOther(keyValue.Value))
+					}
+				}
+			}
+		}
+		return closed
+	})
+	return closed
+}
+
+// funcName returns the name of a called function or method
+func funcName(fun ast.Expr) string {
+	switch tp := fun.(type) {
+	case *ast.Ident:
+		return tp.Name
+	case *ast.SelectorExpr:
+		return tp.Sel.Name
+	}
+	return ""
+}
+
+func convertEnum(typeSpec *ast.TypeSpec, enumData *EnumData, out *Output) {
+	if typeSpec.Name.IsExported() {
+		out = toNewFile(typeSpec.Name.Name, out)
+	}
+	valueType := enumData.javaType()
+	out.Print("public ")
+	if !typeSpec.Name.IsExported() {
+		out.Print("static ")
+	}
+	out.Println("enum", enumData.name, "{")
+	outTab := out.AddTab()
+	for idx, enumConst := range enumData.constants {
+		outTab.Print(enumConst.name, "(", javaConstLiteral(enumConst.value, valueType), ")")
+		if idx < len(enumData.constants)-1 {
+			outTab.Println(",")
+		}
+	}
+	outTab.Println(";")
+	outTab.Println("")
+	outTab.Println("public final", valueType, "value;")
+	outTab.Println("")
+	outTab.Println("private", enumData.name+"("+valueType, "value) {")
+	outTab.AddTab().Println("this.value = value;")
+	outTab.Println("}")
+	outTab.Println("")
+	outTab.Println("public static", enumData.name, "valueOf("+valueType, "value) {")
+	outLoop := outTab.AddTab()
+	outLoop.Println("for (" + enumData.name + " constant : values()) {")
+	outLoop.AddTab().Println("if (constant.value == value) {")
+	outLoop.AddTab().AddTab().Println("return constant;")
+	outLoop.AddTab().Println("}")
+	outLoop.Println("}")
+	outLoop.Println("throw new IllegalArgumentException(\"" + enumData.name + ": \" + value);")
+	outTab.Println("}")
+	outTab.Println("")
+	outTab.Println("@Override")
+	outTab.Println("public String toString() {")
+	outTab.AddTab().Println("return String();")
+	outTab.Println("}")
+	outTab.Println("")
+	oTypes.setFunctionsPos(typeKey(typeSpec.Name.Name, out), outTab.getPosition())
+	convertPendingMethods(typeKey(typeSpec.Name.Name, out))
+	out.Println("}")
+}
+
+func convertEnumConstIdent(ident *ast.Ident, out *Output) bool {
+	enumData := oEnums.constEnum(ident.Name, out)
+	if enumData == nil {
+		return false
+	}
+	out.Print(enumData.name)
+	out.Print(".")
+	out.Print(ident.Name)
+	return true
+}
+
+// convertEnumCounterIdent prints an enum loop variable used as an enum value
+func convertEnumCounterIdent(ident *ast.Ident, out *Output) bool {
+	enumData := out.blockInfo.EnumCounters[ident.Name]
+	if enumData == nil {
+		return false
+	}
+	out.Print(enumData.name, ".valueOf(", ident.Name, ")")
+	return true
+}
+
+// enumValueExpr selects the number of an enum value, go enums have no fields so c.value is never go code
+func enumValueExpr(expr ast.Expr) ast.Expr {
+	return &ast.SelectorExpr{X: expr, Sel: ast.NewIdent("value")}
+}
+
+func isEnumValueExpr(selectorExpr *ast.SelectorExpr, out *Output) bool {
+	return selectorExpr.Sel.Name == "value" && enumOf(typeOf(selectorExpr.X, out), out) != nil
+}
+
+// enumArithmetic returns the enum an arithmetic expression calculates with
+func enumArithmetic(binaryExpr *ast.BinaryExpr, out *Output) *EnumData {
+	if isComparison(binaryExpr.Op) || binaryExpr.Op == token.LAND || binaryExpr.Op == token.LOR {
+		return nil
+	}
+	return enumOf(typeOf(binaryExpr, out), out)
+}
+
+// convertEnumValue prints the number of an enum value, arithmetic is calculated with the numbers
+func convertEnumValue(expr ast.Expr, out *Output) {
+	switch tp := expr.(type) {
+	case *ast.ParenExpr:
+		out.Print("(")
+		convertEnumValue(tp.X, out)
+		out.Print(")")
+		return
+	case *ast.BinaryExpr:
+		if enumArithmetic(tp, out) != nil {
+			convertEnumOperands(tp, out)
+			return
+		}
+	case *ast.Ident:
+		if out.blockInfo.EnumCounters[tp.Name] != nil {
+			out.Print(tp.Name)
+			return
+		}
+	case *ast.BasicLit:
+		convertExpr(tp, out)
+		return
+	}
+	if enumOf(typeOf(expr, out), out) == nil {
+		// untyped constants
+		convertExpr(expr, out)
+		return
+	}
+	convertOperand(expr, out)
+	out.Print(".value")
+}
+
+func convertEnumOperands(binaryExpr *ast.BinaryExpr, out *Output) {
+	precedence := javaPrecedence[binaryExpr.Op]
+	convertEnumOperand(binaryExpr.X, precedence, false, out)
+	out.Print(" ", binaryExpr.Op, " ")
+	convertEnumOperand(binaryExpr.Y, precedence, true, out)
+}
+
+// convertEnumOperand parenthesizes an operand java would group differently, like convertBinaryOperand
+func convertEnumOperand(expr ast.Expr, precedence int, isRight bool, out *Output) {
+	if binaryExpr, ok := expr.(*ast.BinaryExpr); ok {
+		if operandPrecedence := javaPrecedence[binaryExpr.Op]; operandPrecedence < precedence || isRight && operandPrecedence == precedence {
+			out.Print("(")
+			convertEnumValue(binaryExpr, out)
+			out.Print(")")
+			return
+		}
+	}
+	convertEnumValue(expr, out)
+}
+
+// convertEnumBinaryExpr compares and calculates with the numbers of enum values,
+// the results of arithmetic are enum values again
+func convertEnumBinaryExpr(binaryExpr *ast.BinaryExpr, out *Output) bool {
+	if isOrdering(binaryExpr.Op) {
+		if enumOf(operandType(binaryExpr, out), out) == nil {
+			return false
+		}
+		convertEnumOperands(binaryExpr, out)
+		return true
+	}
+	enumData := enumArithmetic(binaryExpr, out)
+	if enumData == nil {
+		return false
+	}
+	out.Print(enumData.name, ".valueOf(")
+	if javaType := enumData.javaType(); isNarrowType(javaType) {
+		out.Print("(", javaType, ") (")
+		defer out.Print(")")
+	}
+	convertEnumOperands(binaryExpr, out)
+	out.Print(")")
+	return true
+}
+
+// convertEnumUpdate translates op= assignments and ++ and -- of enum variables
+func convertEnumUpdate(lhs ast.Expr, tok token.Token, rhs ast.Expr, out *Output) bool {
+	if enumOf(typeOf(lhs, out), out) == nil {
+		return false
+	}
+	if ident, ok := lhs.(*ast.Ident); ok && out.blockInfo.EnumCounters[ident.Name] != nil {
+		out.Print(ident.Name)
+		if rhs == nil {
+			convertOp(tok, out)
+		} else {
+			out.Print(" ", tok, " ")
+			convertEnumValue(rhs, out)
+		}
+		convertStmtEnd(out)
+		return true
+	}
+	convertExpr(lhs, out)
+	out.Print(" = ")
+	convertExpr(updateExpr(lhs, tok, rhs), out)
+	convertStmtEnd(out)
+	return true
+}
+
+// enumIndexed indexes arrays, slices and strings by the number of an enum value
+func enumIndexed(indexExpr *ast.IndexExpr, out *Output) *ast.IndexExpr {
+	if enumOf(typeOf(indexExpr.Index, out), out) == nil {
+		return indexExpr
+	}
+	if _, isMap := underlyingType(typeOf(indexExpr.X, out)).(*ast.MapType); isMap {
+		return indexExpr
+	}
+	return &ast.IndexExpr{X: indexExpr.X, Lbrack: indexExpr.Lbrack, Index: enumValueExpr(indexExpr.Index), Rbrack: indexExpr.Rbrack}
+}
+
+// enumCounter returns the enum of a loop variable counted with ++ or --, the variable holds the number
+// of the enum value as the last step may leave the constants of the enum
+func enumCounter(forStmt *ast.ForStmt, out *Output) (*ast.Ident, *EnumData) {
+	init, ok := forStmt.Init.(*ast.AssignStmt)
+	if !ok || init.Tok != token.DEFINE || len(init.Lhs) != 1 || len(init.Rhs) != 1 {
+		return nil, nil
+	}
+	ident, ok := init.Lhs[0].(*ast.Ident)
+	post, isIncDec := forStmt.Post.(*ast.IncDecStmt)
+	if !ok || !isIncDec {
+		return nil, nil
+	}
+	if postIdent, ok := post.X.(*ast.Ident); !ok || postIdent.Name != ident.Name {
+		return nil, nil
+	}
+	enumData := enumOf(typeOf(init.Rhs[0], out), out)
+	if enumData == nil {
+		return nil, nil
+	}
+	return ident, enumData
+}
+
+// convertEnumCounterInit declares an enum loop variable with the number of its value
+func convertEnumCounterInit(forStmt *ast.ForStmt, ident *ast.Ident, enumData *EnumData, out *Output) {
+	init := forStmt.Init.(*ast.AssignStmt)
+	out.Print(enumData.javaType(), " ", ident.Name, " = ")
+	convertEnumValue(init.Rhs[0], out)
+	out.AddVar(ident.Name, typeOf(init.Rhs[0], out))
+	out.blockInfo.EnumCounters[ident.Name] = enumData
+}
@@ -0,0 +1,63 @@
+package main
+
+import "testing"
+
+// only the types whose values are their constants are java enums, the others keep any number
+func TestEnumsClosed(t *testing.T) {
+	tr := translate(t, map[string]string{"app/main.go": `package main
+
+import "fmt"
+
+type Color int
+
+const (
+	Red Color = iota
+	Green
+	Blue
+)
+
+func (c Color) String() string {
+	return [...]string{"red", "green", "blue"}[c]
+}
+
+type Weekday int
+
+const (
+	Sunday Weekday = iota + 1
+	Monday
+)
+
+func (d Weekday) String() string { return "day" }
+
+type Size int
+
+const (
+	Small Size = iota
+	Large
+)
+
+func (s Size) String() string { return "size" }
+
+type Level int
+
+const (
+	Low Level = iota
+	High
+)
+
+func (l Level) String() string { return "level" }
+
+func (l Level) Up() Level { return l + 1 }
+
+func main() {
+	var w Weekday
+	n := 10
+	fmt.Println(Green, w, Size(n), High.Up())
+}
+`}, "-enums")
+	expectContains(t, tr.java(t, "app/Color.java"), "public enum Color {")
+	for _, name := range []string{"Weekday", "Size", "Level"} {
+		expectContains(t, tr.java(t, "app/"+name+".java"), "public class "+name+" {")
+	}
+	expectContains(t, tr.java(t, "app/Main.java"), "new Size(n)")
+}
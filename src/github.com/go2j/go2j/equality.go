@@ -0,0 +1,271 @@
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+)
+
+// comparable structs get equals and hashCode, so they work as map keys like in go.
+// == and != are rewritten where java would compare references
+
+// isComparable tells whether values of a type can be compared with ==
+func isComparable(typeExpr ast.Expr, visited map[string]bool) bool {
+	switch tp := underlyingType(typeExpr).(type) {
+	case *ast.ArrayType:
+		return tp.Len != nil && isComparable(tp.Elt, visited)
+	case *ast.MapType, *ast.FuncType:
+		return false
+	case *ast.StructType:
+		return structComparable(tp, visited)
+	case *ast.SelectorExpr:
+		key := typeKeyIn(tp, "")
+		if outType := lookupType(key); outType != nil && outType.structType != nil && !visited[key] {
+			visited[key] = true
+			return structComparable(outType.structType, visited)
+		}
+	}
+	return true
+}
+
+func structComparable(structType *ast.StructType, visited map[string]bool) bool {
+	for _, field := range structType.Fields.List {
+		if !isComparable(field.Type, visited) {
+			return false
+		}
+	}
+	return true
+}
+
+type equalityField struct {
+	name     string
+	typeExpr ast.Expr
+}
+
+func equalityFields(structType *ast.StructType) []*equalityField {
+	fields := []*equalityField{}
+	for _, field := range structType.Fields.List {
+		if len(field.Names) == 0 {
+			fields = append(fields, &equalityField{embeddedFieldName(field.Type), field.Type})
+			continue
+		}
+		for _, name := range field.Names {
+			if name.Name != "_" {
+				fields = append(fields, &equalityField{name.Name, field.Type})
+			}
+		}
+	}
+	return fields
+}
+
+// arrayDepth returns the number of dimensions of an array type
+func arrayDepth(typeExpr ast.Expr) int {
+	depth := 0
+	for {
+		arrayType, ok := underlyingType(typeExpr).(*ast.ArrayType)
+		if !ok || arrayType.Len == nil {
+			return depth
+		}
+		depth++
+		typeExpr = arrayType.Elt
+	}
+}
+
+// isReferenceCompared tells whether go compares the values by identity
+func isReferenceCompared(typeExpr ast.Expr) bool {
+	switch underlyingType(typeExpr).(type) {
+	case *ast.StarExpr, *ast.ChanType:
+		return true
+	}
+	return javaPrimitiveOf(typeExpr) != ""
+}
+
+func fieldEquals(field *equalityField, out *Output) string {
+	this, other := "this."+field.name, "other."+field.name
+	switch depth := arrayDepth(field.typeExpr); {
+	case depth > 1:
+		useArrays(out)
+		return "Arrays.deepEquals(" + this + ", " + other + ")"
+	case depth == 1:
+		useArrays(out)
+		return "Arrays.equals(" + this + ", " + other + ")"
+	}
+	if isReferenceCompared(field.typeExpr) {
+		return this + " == " + other
+	}
+	useObjects(out)
+	return "Objects.equals(" + this + ", " + other + ")"
+}
+
+func fieldHash(field *equalityField, out *Output) string {
+	this := "this." + field.name
+	switch depth := arrayDepth(field.typeExpr); {
+	case depth > 1:
+		useArrays(out)
+		return "Arrays.deepHashCode(" + this + ")"
+	case depth == 1:
+		useArrays(out)
+		return "Arrays.hashCode(" + this + ")"
+	}
+	if _, isPtr := underlyingType(field.typeExpr).(*ast.StarExpr); isPtr {
+		return "System.identityHashCode(" + this + ")"
+	}
+	return this
+}
+
+func useArrays(out *Output) {
+	out.outSource.addSysImportName("Arrays", "java.util.Arrays")
+}
+
+func useObjects(out *Output) {
+	out.outSource.addSysImportName("Objects", "java.util.Objects")
+}
+
+// convertStructEquality generates equals and hashCode of a comparable struct
+func convertStructEquality(structType *ast.StructType, className string, out *Output) {
+	if !structComparable(structType, map[string]bool{}) {
+		return
+	}
+	fields := equalityFields(structType)
+	out.Println("@Override")
+	out.Println("public boolean equals(Object o) {")
+	outTab := out.AddTab()
+	outTab.Println("if (this == o) {")
+	outTab.AddTab().Println("return true;")
+	outTab.Println("}")
+	outTab.Println("if (!(o instanceof " + className + ")) {")
+	outTab.AddTab().Println("return false;")
+	outTab.Println("}")
+	if len(fields) == 0 {
+		outTab.Println("return true;")
+	} else {
+		outTab.Println(className, "other = ("+className+") o;")
+		comparisons := []string{}
+		for _, field := range fields {
+			comparisons = append(comparisons, fieldEquals(field, out))
+		}
+		outTab.Println("return " + strings.Join(comparisons, " && ") + ";")
+	}
+	out.Println("}")
+	out.Println("")
+
+	out.Println("@Override")
+	out.Println("public int hashCode() {")
+	hashes := []string{}
+	for _, field := range fields {
+		hashes = append(hashes, fieldHash(field, out))
+	}
+	useObjects(out)
+	outTab.Println("return Objects.hash(" + strings.Join(hashes, ", ") + ");")
+	out.Println("}")
+	out.Println("")
+}
+
+// convertStructString generates toString printing the struct like %v
+func convertStructString(out *Output) {
+	out.outSource.addSysImportName(JI_FMT.typeName, JI_FMT.qualifiedName)
+	out.Println("@Override")
+	out.Println("public String toString() {")
+	out.AddTab().Println("return Fmt.Sprint(this);")
+	out.Println("}")
+	out.Println("")
+}
+
+func isNilIdent(expr ast.Expr) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && ident.Name == "nil"
+}
+
+func isInterfaceType(typeExpr ast.Expr) bool {
+	switch tp := underlyingType(typeExpr).(type) {
+	case *ast.InterfaceType:
+		return true
+	case *ast.Ident:
+		return tp.Name == "any" || tp.Name == "error"
+	case *ast.SelectorExpr:
+		outType := lookupType(typeKeyIn(tp, ""))
+		return outType != nil && outType.interfaceType != nil
+	}
+	return false
+}
+
+// equalityFunc returns the java method comparing values of the operand types, or "" if == works
+func equalityFunc(xType, yType ast.Expr, out *Output) string {
+	if isInterfaceType(xType) || isInterfaceType(yType) {
+		out.outSource.addSysImportName(JI_GO_OBJECTS.typeName, JI_GO_OBJECTS.qualifiedName)
+		return "GoObjects.equals"
+	}
+	typeExpr := xType
+	if typeExpr == nil {
+		typeExpr = yType
+	}
+	switch depth := arrayDepth(typeExpr); {
+	case depth > 1:
+		useArrays(out)
+		return "Arrays.deepEquals"
+	case depth == 1:
+		useArrays(out)
+		return "Arrays.equals"
+	}
+	isStruct := false
+	switch tp := underlyingType(typeExpr).(type) {
+	case *ast.StructType:
+		isStruct = true
+	case *ast.SelectorExpr:
+		outType := lookupType(typeKeyIn(tp, ""))
+		isStruct = outType != nil && outType.structType != nil
+	}
+	if isStruct || isStringType(typeExpr) {
+		useObjects(out)
+		return "Objects.equals"
+	}
+	return ""
+}
+
+// convertEqualityExpr translates == and != of structs, arrays, strings and interfaces
+func convertEqualityExpr(binaryExpr *ast.BinaryExpr, out *Output) bool {
+	if binaryExpr.Op != token.EQL && binaryExpr.Op != token.NEQ {
+		return false
+	}
+	if isNilIdent(binaryExpr.X) || isNilIdent(binaryExpr.Y) {
+		return false
+	}
+	xType, yType := typeOf(binaryExpr.X, out), typeOf(binaryExpr.Y, out)
+	fn := equalityFunc(xType, yType, out)
+	if fn == "" {
+		if !isBoxedRead(binaryExpr.X, out) && !isBoxedRead(binaryExpr.Y, out) || conversionKind(operandType(binaryExpr, out)) == "uint" {
+			return false
+		}
+		// values read from maps and cells are boxed
+		convertUnboxed(binaryExpr.X, out)
+		out.Print(" ", binaryExpr.Op, " ")
+		convertUnboxed(binaryExpr.Y, out)
+		return true
+	}
+	if binaryExpr.Op == token.NEQ {
+		out.Print("!")
+	}
+	out.Print(fn, "(")
+	convertExpr(binaryExpr.X, out)
+	out.Print(", ")
+	convertExpr(binaryExpr.Y, out)
+	out.Print(")")
+	return true
+}
+
+func isMapRead(expr ast.Expr, out *Output) bool {
+	indexExpr, ok := expr.(*ast.IndexExpr)
+	return ok && mapTypeOf(indexExpr.X, out) != nil
+}
+
+// isBoxedRead tells whether a value is read from a map or a cell, the java value is boxed
+func isBoxedRead(expr ast.Expr, out *Output) bool {
+	return isMapRead(expr, out) || isCellRead(expr, out)
+}
+
+func convertUnboxed(expr ast.Expr, out *Output) {
+	if javaType := javaPrimitiveOf(typeOf(expr, out)); javaType != "" && isBoxedRead(expr, out) {
+		out.Print("(", javaType, ") ")
+	}
+	convertExpr(expr, out)
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+)
+
+// the error interface is the GoError runtime interface, types with an Error() string method
+// implement it. errors is translated to GoErrors calls and fmt.Errorf to Fmt.Errorf, with -exceptions the
+// exported functions returning an error get a companion method throwing GoException
+
+// the nested interfaces of GoError and the go signatures of their methods
+var errorInterfaceNames = []string{"GoError", "GoError.Wrapper", "GoError.MultiWrapper", "GoError.Matcher"}
+var errorInterfaces = map[string]map[string]string{
+	"GoError":              {"Error": "() (string)"},
+	"GoError.Wrapper":      {"Unwrap": "() (error)"},
+	"GoError.MultiWrapper": {"Unwrap": "() ([]error)"},
+	"GoError.Matcher":      {"Is": "(error) (bool)"},
+}
+
+// orThrowSuffix names the companion method of a function returning an error
+const orThrowSuffix = "OrThrow"
+
+func useGoError(outSource *OutSource) {
+	outSource.addSysImportName(JI_GO_ERROR.typeName, JI_GO_ERROR.qualifiedName)
+}
+
+func isErrorType(typeExpr ast.Expr) bool {
+	ident, ok := typeExpr.(*ast.Ident)
+	return ok && ident.Name == "error"
+}
+
+// declareErrorInterfaces declares the runtime error interfaces the methods of a type satisfy
+func declareErrorInterfaces(key string) {
+	methods := oTypes.methodSet(key)
+	for _, interfaceName := range errorInterfaceNames {
+		satisfied := true
+		for name, signature := range errorInterfaces[interfaceName] {
+			satisfied = satisfied && methods[name] == signature
+		}
+		if !satisfied {
+			continue
+		}
+		oTypes.declareImplements(key, interfaceName)
+		useGoError(oTypes.getImplementsPos(key).origOut.outSource)
+	}
+}
+
+// isErrorInterfaceMethod tells whether a method implements a method of a runtime error interface
+func isErrorInterfaceMethod(interfaceName, methodName string) bool {
+	_, has := errorInterfaces[interfaceName][methodName]
+	return has
+}
+
+func isErrorMethod(methodName string) bool {
+	for _, interfaceName := range errorInterfaceNames {
+		if isErrorInterfaceMethod(interfaceName, methodName) {
+			return true
+		}
+	}
+	return false
+}
+
+// convertErrorsAs assigns the found error to the target of errors.As(err, &target), the call is true if it is found
+func convertErrorsAs(callExpr *ast.CallExpr, out *Output) bool {
+	if resolveTypeName(callExpr.Fun, false) != "errors.As" || len(callExpr.Args) != 2 {
+		return false
+	}
+	unaryExpr, ok := callExpr.Args[1].(*ast.UnaryExpr)
+	if !ok || unaryExpr.Op != token.AND {
+		return false
+	}
+	targetType := typeOf(unaryExpr.X, out)
+	if starExpr, ok := targetType.(*ast.StarExpr); ok {
+		targetType = starExpr.X
+	}
+	if targetType == nil {
+		return false
+	}
+	out.outSource.addSysImportName(JI_GO_ERRORS.typeName, JI_GO_ERRORS.qualifiedName)
+	// the target keeps its value if no error matches
+	out.Print("(GoErrors.found(")
+	convertExpr(callExpr.Args[0], out)
+	out.Print(", ")
+	convertErasedType(targetType, out)
+	out.Print(".class) && (")
+	convertExpr(unaryExpr.X, out)
+	out.Print(" = GoErrors.take(")
+	convertErasedType(targetType, out)
+	out.Print(".class)) != null)")
+	return true
+}
+
+// convertOrThrow adds the companion of an exported function returning an error, java callers catch GoException
+// instead of checking the error
+func convertOrThrow(funcDecl *ast.FuncDecl, funcName string, out *Output) {
+	results := resultTypes(funcDecl.Type)
+	if !*exceptionsFlag || !funcDecl.Name.IsExported() || funcDecl.Body == nil ||
+		len(results) == 0 || !isErrorType(results[len(results)-1]) {
+		return
+	}
+	if funcDecl.Recv != nil && isErrorMethod(funcDecl.Name.Name) {
+		// Unwrap returns an error, it does not fail
+		return
+	}
+	args := []string{}
+	for _, field := range funcDecl.Type.Params.List {
+		for _, name := range field.Names {
+			if _, isVariadic := field.Type.(*ast.Ellipsis); isVariadic {
+				args = append(args, varargsName(name.Name))
+			} else {
+				args = append(args, name.Name)
+			}
+		}
+	}
+	call := funcName + "(" + strings.Join(args, ", ") + ")"
+	tupleName := "results"
+	for _, arg := range args {
+		if arg == tupleName {
+			tupleName += "$"
+		}
+	}
+	out.Print("public ")
+	if funcDecl.Recv == nil {
+		out.Print("static ")
+	}
+	values := results[:len(results)-1]
+	companionType := &ast.FuncType{Params: funcDecl.Type.Params}
+	if len(values) > 0 {
+		companionType.Results = &ast.FieldList{}
+		for _, value := range values {
+			companionType.Results.List = append(companionType.Results.List, &ast.Field{Type: value})
+		}
+	}
+	convertFuncType(companionType, funcName+orThrowSuffix, false, out, newResolveTypeOpts())
+	out.outSource.addSysImportName(JI_GO_EXCEPTION.typeName, JI_GO_EXCEPTION.qualifiedName)
+	out.Println(" throws GoException {")
+	bodyOut := out.AddTab()
+	if len(values) == 0 {
+		bodyOut.Println("GoException.check(" + call + ");")
+		out.Println("}")
+		out.Println("")
+		return
+	}
+	bodyOut.Print("")
+	convertTupleType(results, bodyOut)
+	bodyOut.Println("", tupleName, "=", call+";")
+	bodyOut.Println("GoException.check(" + tupleName + "." + tupleField(len(values)) + ");")
+	if len(values) == 1 {
+		bodyOut.Println("return " + tupleName + "." + tupleField(0) + ";")
+	} else {
+		fields := []string{}
+		for idx := range values {
+			fields = append(fields, tupleName+"."+tupleField(idx))
+		}
+		useTupleClass(len(values), bodyOut)
+		bodyOut.Println("return new " + tupleClass(len(values)) + "<>(" + strings.Join(fields, ", ") + ");")
+	}
+	out.Println("}")
+	out.Println("")
+}
@@ -0,0 +1,98 @@
+package main
+
+import "testing"
+
+// errors.As assigns the target on a match only
+func TestErrorsAsKeepsTarget(t *testing.T) {
+	tr := translate(t, map[string]string{"app/main.go": `package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+type MyErr struct{ code int }
+
+func (e *MyErr) Error() string { return "my" }
+
+func main() {
+	target := &MyErr{1}
+	err := errors.New("other")
+	if !errors.As(err, &target) {
+		fmt.Println(target.code)
+	}
+}
+`})
+	expectContains(t, tr.java(t, "app/Main.java"),
+		"if (!(GoErrors.found(err, MyErr.class) && (target = GoErrors.take(MyErr.class)) != null))")
+	expectContains(t, tr.java(t, "org/go2j/util/GoErrors.java"), "public static boolean found(GoError err, Class<?> target) {")
+}
+
+// the tuple classes of more than the usual results are generated when they are used
+func TestLargeTuple(t *testing.T) {
+	tr := translate(t, map[string]string{"app/main.go": `package main
+
+import "fmt"
+
+func seven() (int, int, int, int, int, int, string) {
+	return 1, 2, 3, 4, 5, 6, "seven"
+}
+
+func main() {
+	a, b, c, d, e, f, g := seven()
+	fmt.Println(a, b, c, d, e, f, g)
+}
+`})
+	expectContains(t, tr.java(t, "app/Main.java"), "Tuple7<Integer, Integer, Integer, Integer, Integer, Integer, String> tmp1 = seven();")
+	expectContains(t, tr.java(t, "org/go2j/util/Tuple7.java"),
+		"public final class Tuple7<T0, T1, T2, T3, T4, T5, T6> {", "public final T6 r6;")
+}
+
+// errors returned as pointers are compared by identity, errors.Is of equal fields does not match
+func TestPointerErrorsIdentity(t *testing.T) {
+	tr := translate(t, map[string]string{"app/main.go": `package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+type NotFound struct{ name string }
+
+func (e *NotFound) Error() string { return e.name + " not found" }
+
+type Code struct{ n int }
+
+func (c Code) Error() string { return "code" }
+
+func find(name string) error {
+	return &NotFound{name}
+}
+
+func main() {
+	err := find("a")
+	fmt.Println(errors.Is(err, find("a")), err == find("a"), errors.Is(Code{1}, Code{1}))
+}
+`})
+	expectContains(t, tr.java(t, "app/NotFound.java"),
+		"public class NotFound implements GoError, GoObjects.Value, GoObjects.Pointer {")
+	expectContains(t, tr.java(t, "app/Code.java"), "public class Code implements GoError, GoObjects.Value {")
+	expectContains(t, tr.java(t, "org/go2j/util/GoObjects.java"),
+		"if (a == null || b == null || a instanceof Pointer) {")
+}
+
+// the values of a struct converted to interfaces as pointers too are compared by identity
+func TestInterfaceValuesOfPointersWarning(t *testing.T) {
+	tr := translate(t, map[string]string{"app/main.go": `package main
+
+import "fmt"
+
+type Key struct{ n int }
+
+func main() {
+	var a, b interface{} = Key{1}, &Key{2}
+	fmt.Println(a == b)
+}
+`})
+	expectContains(t, tr.stderr, "main.go:8:25: warning: the values of Key in interfaces are compared by identity like its pointers")
+}
@@ -0,0 +1,410 @@
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+)
+
+// go types of expressions, inferred from the declarations seen so far
+
+var goBasicTypes = map[string]bool{
+	"bool": true, "string": true, "error": true, "any": true,
+	"float32": true, "float64": true, "complex64": true, "complex128": true,
+}
+
+var goBuiltinResults = map[string]string{
+	"len": "int", "cap": "int", "copy": "int", "real": "float64", "imag": "float64",
+}
+
+func isTypeName(name string) bool {
+	if goBasicTypes[name] || goIntTypes[name] {
+		return true
+	}
+	if _, has := oFileSet.typeAliases[name]; has {
+		return true
+	}
+	if oEnums.named(name) {
+		return true
+	}
+	for _, key := range oTypes.names[strings.Title(name)] {
+		if outType := oTypes.get(key); outType.structType != nil || outType.interfaceType != nil || outType.valueType != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func isImportName(name string, out *Output) bool {
+	return out.GetVarType(name) == nil && out.outSource.importedPackages[strings.Title(name)] != ""
+}
+
+// isTypeExpr tells whether an expression denotes a type
+func isTypeExpr(expr ast.Expr, out *Output) bool {
+	switch tp := expr.(type) {
+	case *ast.ArrayType, *ast.MapType, *ast.FuncType, *ast.InterfaceType, *ast.StructType, *ast.ChanType, *ast.Ellipsis:
+		return true
+	case *ast.Ident:
+		return out.GetVarType(tp.Name) == nil && isTypeName(tp.Name)
+	case *ast.StarExpr:
+		return isTypeExpr(tp.X, out)
+	case *ast.ParenExpr:
+		return isTypeExpr(tp.X, out)
+	case *ast.IndexExpr:
+		return isTypeExpr(tp.X, out)
+	case *ast.IndexListExpr:
+		return isTypeExpr(tp.X, out)
+	case *ast.SelectorExpr:
+		if ident, ok := tp.X.(*ast.Ident); ok && isImportName(ident.Name, out) {
+			return typeConvs[resolveTypeName(tp, false)] != nil || lookupType(typeKeyIn(tp, currentPkgName(out))) != nil
+		}
+	}
+	return false
+}
+
+// typeLiteral returns the struct, interface or underlying type literal of a type
+func typeLiteral(outType *OutType) ast.Expr {
+	switch {
+	case outType == nil:
+		return nil
+	case outType.structType != nil:
+		return outType.structType
+	case outType.interfaceType != nil:
+		return outType.interfaceType
+	case outType.valueType != nil && !outType.system:
+		// named basic types are followed by their aliases
+		if _, isIdent := outType.valueType.(*ast.Ident); !isIdent {
+			return outType.valueType
+		}
+	}
+	return nil
+}
+
+// underlyingType follows named types to their type literals
+func underlyingType(typeExpr ast.Expr) ast.Expr {
+	for i := 0; i < 8; i++ {
+		switch tp := typeExpr.(type) {
+		case *ast.Ident:
+			if aliasName, has := oFileSet.typeAliases[tp.Name]; has {
+				typeExpr = ast.NewIdent(aliasName)
+				continue
+			}
+			if literal := typeLiteral(lookupType(strings.Title(tp.Name))); literal != nil {
+				typeExpr = literal
+				continue
+			}
+		case *ast.SelectorExpr:
+			if ident, ok := tp.X.(*ast.Ident); ok && ownPackagePath(ident.Name, "") != "" {
+				if literal := typeLiteral(lookupType(typeKeyIn(tp, ""))); literal != nil {
+					typeExpr = literal
+					continue
+				}
+				// the other types of the packages of the project are registered by name
+				typeExpr = tp.Sel
+				continue
+			}
+		case *ast.ParenExpr:
+			typeExpr = tp.X
+			continue
+		case *ast.Ellipsis:
+			return &ast.ArrayType{Elt: tp.Elt}
+		}
+		return typeExpr
+	}
+	return typeExpr
+}
+
+func isBasicType(typeExpr ast.Expr, names ...string) bool {
+	ident, ok := underlyingType(typeExpr).(*ast.Ident)
+	if !ok {
+		return false
+	}
+	for _, name := range names {
+		if ident.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func isStringType(typeExpr ast.Expr) bool {
+	return isBasicType(typeExpr, "string")
+}
+
+func isIntegerType(typeExpr ast.Expr) bool {
+	ident, ok := underlyingType(typeExpr).(*ast.Ident)
+	return ok && goIntTypes[ident.Name]
+}
+
+// sliceElemType returns the element type of slices and arrays
+func sliceElemType(typeExpr ast.Expr) ast.Expr {
+	if arrayType, ok := underlyingType(typeExpr).(*ast.ArrayType); ok {
+		return arrayType.Elt
+	}
+	return nil
+}
+
+func typeOf(expr ast.Expr, out *Output) ast.Expr {
+	return typeOfDepth(expr, out, 0)
+}
+
+// storedVarType interprets a stored variable type, variables declared by := may store the assigned expression
+func storedVarType(stored ast.Expr, out *Output, depth int) ast.Expr {
+	if stored == nil {
+		return nil
+	}
+	if isTypeExpr(stored, out) {
+		if ellipsis, ok := stored.(*ast.Ellipsis); ok {
+			// variadic parameters are slices
+			return &ast.ArrayType{Elt: ellipsis.Elt}
+		}
+		return stored
+	}
+	if ident, ok := stored.(*ast.Ident); ok && isTypeName(ident.Name) {
+		return ident
+	}
+	return typeOfDepth(stored, out, depth+1)
+}
+
+func funcResultType(funcType *ast.FuncType) ast.Expr {
+	if funcType == nil || funcType.Results == nil || len(funcType.Results.List) == 0 {
+		return nil
+	}
+	return funcType.Results.List[0].Type
+}
+
+// methodFuncType returns the declaration of a declared, interface or promoted method
+func methodFuncType(recvType ast.Expr, name string) *ast.FuncType {
+	key := typeKeyIn(recvType, "")
+	outType := lookupType(key)
+	if outType == nil {
+		return nil
+	}
+	if funcDecl := outType.methods[name]; funcDecl != nil {
+		return funcDecl.Type
+	}
+	for _, member := range outType.members() {
+		if member.name == name && member.funcType != nil {
+			return member.funcType
+		}
+	}
+	for _, member := range oTypes.promotedMembers(key) {
+		if member.name == name && member.funcType != nil {
+			return member.funcType
+		}
+	}
+	return nil
+}
+
+func methodResultType(recvType ast.Expr, name string) ast.Expr {
+	return funcResultType(methodFuncType(recvType, name))
+}
+
+func importedPackage(name string, out *Output) *Package {
+	if !isImportName(name, out) {
+		return nil
+	}
+	return oFileSet.packageSet[out.outSource.importedPackages[strings.Title(name)]]
+}
+
+func typeOfDepth(expr ast.Expr, out *Output, depth int) ast.Expr {
+	if expr == nil || depth > 16 {
+		return nil
+	}
+	switch tp := expr.(type) {
+	case *ast.BasicLit:
+		switch tp.Kind {
+		case token.INT:
+			return ast.NewIdent("int")
+		case token.FLOAT:
+			return ast.NewIdent("float64")
+		case token.CHAR:
+			return ast.NewIdent("rune")
+		case token.STRING:
+			return ast.NewIdent("string")
+		case token.IMAG:
+			return ast.NewIdent("complex128")
+		}
+	case *ast.Ident:
+		switch tp.Name {
+		case "true", "false":
+			return ast.NewIdent("bool")
+		case "nil", "_":
+			return nil
+		}
+		if enumData := oEnums.constEnum(tp.Name, out); enumData != nil {
+			return ast.NewIdent(enumData.name)
+		}
+		if varType := out.GetVarType(tp.Name); varType != nil {
+			return storedVarType(varType, out, depth)
+		}
+		if varType := out.outSource.getPackage().GetVarType(tp.Name); varType != nil {
+			return storedVarType(varType, out, depth)
+		}
+		if pkg := currentGoPackage(); pkg != nil && pkg.GetVarType(tp.Name) != nil {
+			return storedVarType(pkg.GetVarType(tp.Name), out, depth)
+		}
+	case *ast.ParenExpr:
+		return typeOfDepth(tp.X, out, depth+1)
+	case *ast.StarExpr:
+		if starType, ok := typeOfDepth(tp.X, out, depth+1).(*ast.StarExpr); ok {
+			return starType.X
+		}
+	case *ast.UnaryExpr:
+		switch tp.Op {
+		case token.AND:
+			if xType := typeOfDepth(tp.X, out, depth+1); xType != nil {
+				return &ast.StarExpr{X: xType}
+			}
+			return nil
+		case token.NOT:
+			return ast.NewIdent("bool")
+		case token.ARROW:
+			if chanType, ok := underlyingType(typeOfDepth(tp.X, out, depth+1)).(*ast.ChanType); ok {
+				return chanType.Value
+			}
+			return nil
+		}
+		return typeOfDepth(tp.X, out, depth+1)
+	case *ast.BinaryExpr:
+		switch tp.Op {
+		case token.EQL, token.NEQ, token.LSS, token.LEQ, token.GTR, token.GEQ, token.LAND, token.LOR:
+			return ast.NewIdent("bool")
+		case token.SHL, token.SHR:
+			return typeOfDepth(tp.X, out, depth+1)
+		}
+		// untyped constant operands take the type of the other operand
+		if _, isLit := tp.X.(*ast.BasicLit); !isLit && (!isConstExpr(tp.X) || isConstExpr(tp.Y)) {
+			if xType := typeOfDepth(tp.X, out, depth+1); xType != nil {
+				return xType
+			}
+		}
+		if yType := typeOfDepth(tp.Y, out, depth+1); yType != nil {
+			return yType
+		}
+		return typeOfDepth(tp.X, out, depth+1)
+	case *ast.CallExpr:
+		return callResultType(tp, out, depth)
+	case *ast.SelectorExpr:
+		if isPackageConst(tp) {
+			return ast.NewIdent(untypedGoType(evalConst(tp, 0, lookupConst(oFileSet.currentPackage))))
+		}
+		if ident, ok := tp.X.(*ast.Ident); ok {
+			if conv := apiConvs[resolveTypeName(tp, false)]; conv != nil && isImportName(ident.Name, out) {
+				// converted values like math.Pi
+				if results := conv.resultTypes(nil, out); len(results) == 1 {
+					return results[0]
+				}
+				return nil
+			}
+			if pkg := importedPackage(ident.Name, out); pkg != nil {
+				return pkg.GetVarType(tp.Sel.Name)
+			}
+		}
+		xType := typeOfDepth(tp.X, out, depth+1)
+		if xType == nil {
+			return nil
+		}
+		if enumData := enumOf(xType, out); enumData != nil && tp.Sel.Name == "value" {
+			return ast.NewIdent(enumData.valueType)
+		}
+		key := typeKeyIn(xType, currentPkgName(out))
+		if fieldType, _ := fieldTypeOf(key, tp.Sel.Name); fieldType != nil {
+			return fieldType
+		}
+		if outType := lookupType(key); outType != nil && outType.methods[tp.Sel.Name] != nil {
+			return outType.methods[tp.Sel.Name].Type
+		}
+	case *ast.IndexExpr:
+		xType := underlyingType(typeOfDepth(tp.X, out, depth+1))
+		if starType, ok := xType.(*ast.StarExpr); ok {
+			// indexing a pointer to array
+			xType = underlyingType(starType.X)
+		}
+		switch xTp := xType.(type) {
+		case *ast.Ident:
+			if xTp.Name == "string" {
+				return ast.NewIdent("byte")
+			}
+		case *ast.ArrayType:
+			return xTp.Elt
+		case *ast.MapType:
+			return xTp.Value
+		}
+	case *ast.SliceExpr:
+		xType := typeOfDepth(tp.X, out, depth+1)
+		if starType, ok := xType.(*ast.StarExpr); ok {
+			xType = starType.X
+		}
+		if arrayType, ok := underlyingType(xType).(*ast.ArrayType); ok && arrayType.Len != nil {
+			// slicing an array gives a slice
+			return &ast.ArrayType{Elt: arrayType.Elt}
+		}
+		return xType
+	case *ast.CompositeLit:
+		return tp.Type
+	case *ast.TypeAssertExpr:
+		return tp.Type
+	case *ast.FuncLit:
+		return tp.Type
+	}
+	return nil
+}
+
+func callResultType(callExpr *ast.CallExpr, out *Output, depth int) ast.Expr {
+	if isTypeExpr(callExpr.Fun, out) {
+		// conversion
+		return callExpr.Fun
+	}
+	if ident, ok := callExpr.Fun.(*ast.Ident); ok && out.GetVarType(ident.Name) == nil {
+		if result, has := goBuiltinResults[ident.Name]; has {
+			return ast.NewIdent(result)
+		}
+		switch ident.Name {
+		case "append", "min", "max":
+			if len(callExpr.Args) > 0 {
+				return typeOfDepth(callExpr.Args[0], out, depth+1)
+			}
+			return nil
+		case "make":
+			if len(callExpr.Args) > 0 {
+				return callExpr.Args[0]
+			}
+			return nil
+		case "new":
+			if len(callExpr.Args) > 0 {
+				return &ast.StarExpr{X: callExpr.Args[0]}
+			}
+			return nil
+		}
+		if funcType := out.GetFuncType(ident.Name); funcType != nil {
+			return funcType
+		}
+		if pkg := currentGoPackage(); pkg != nil && pkg.FuncReturnTypes[ident.Name] != nil {
+			// declared later or in an other file of the package
+			return pkg.FuncReturnTypes[ident.Name]
+		}
+	}
+	if selectorExpr, ok := callExpr.Fun.(*ast.SelectorExpr); ok {
+		if conv, _ := apiConvOf(callExpr, out); conv != nil && len(conv.results) > 0 {
+			if results := conv.resultTypes(callExpr, out); len(results) > 0 {
+				return results[0]
+			}
+			return nil
+		}
+		if ident, ok := selectorExpr.X.(*ast.Ident); ok {
+			if pkg := importedPackage(ident.Name, out); pkg != nil {
+				return pkg.FuncReturnTypes[selectorExpr.Sel.Name]
+			}
+		}
+		if xType := typeOfDepth(selectorExpr.X, out, depth+1); xType != nil {
+			if resultType := methodResultType(xType, selectorExpr.Sel.Name); resultType != nil {
+				return resultType
+			}
+		}
+	}
+	if funcType, ok := underlyingType(typeOfDepth(callExpr.Fun, out, depth+1)).(*ast.FuncType); ok {
+		return funcResultType(funcType)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"go/ast"
+	"strconv"
+)
+
+// the fmt functions are translated to the Fmt runtime class. java has no unsigned integers and no pointers
+// to values, the printed arguments of these types are marked with Fmt.uint and Fmt.pointer, and struct
+// fields of these types keep their go type in a GoType annotation
+
+// convertPrintArg prints an argument of a function of the Fmt runtime
+func convertPrintArg(callExpr *ast.CallExpr, idx int, conv *JavaApiConv, out *Output) {
+	arg := callExpr.Args[idx]
+	if idx < conv.printArgs.from || callExpr.Ellipsis.IsValid() && idx == len(callExpr.Args)-1 {
+		convertCallArg(callExpr, idx, nil, out)
+		return
+	}
+	if isNilIdent(arg) {
+		// a single nil is an argument, not the varargs array
+		out.Print("(Object) null")
+		return
+	}
+	if convertInterfaceValue(ast.NewIdent("any"), arg, out) {
+		// fmt calls the String and Error methods of named types
+		return
+	}
+	argType := typeOf(arg, out)
+	if unsignedName := unsignedTypeName(argType); unsignedName != "" {
+		out.Print("Fmt.uint(")
+		convertExpr(arg, out)
+		out.Print(", ", strconv.Quote(unsignedName), ")")
+		return
+	}
+	if isCompositePointer(argType) {
+		out.Print("Fmt.pointer(")
+		convertExpr(arg, out)
+		out.Print(")")
+		return
+	}
+	if arrayType, ok := underlyingType(argType).(*ast.ArrayType); ok && arrayType.Len != nil {
+		// a java array would be passed as the varargs array
+		out.Print("(Object) ")
+	}
+	convertExpr(arg, out)
+}
+
+// unsignedTypeName returns the name of an unsigned integer type, or ""
+func unsignedTypeName(typeExpr ast.Expr) string {
+	ident, ok := underlyingType(typeExpr).(*ast.Ident)
+	if !ok {
+		return ""
+	}
+	if _, unsigned := goUnsignedBits[ident.Name]; !unsigned {
+		return ""
+	}
+	if ident.Name == "byte" {
+		return "uint8"
+	}
+	return ident.Name
+}
+
+// isCompositePointer tells whether a type is a pointer to a struct, an array, a slice or a map
+func isCompositePointer(typeExpr ast.Expr) bool {
+	starExpr, ok := typeExpr.(*ast.StarExpr)
+	if !ok {
+		return false
+	}
+	switch underlyingType(starExpr.X).(type) {
+	case *ast.StructType, *ast.ArrayType, *ast.MapType:
+		return true
+	}
+	return false
+}
+
+// convertGoTypeAnnotation annotates the fields of pointer and unsigned types, Fmt prints them from their go type
+func convertGoTypeAnnotation(typeExpr ast.Expr, out *Output) {
+	goType := unsignedTypeName(typeExpr)
+	if _, isPtr := typeExpr.(*ast.StarExpr); isPtr {
+		goType = typeString(typeExpr)
+	}
+	if goType == "" {
+		return
+	}
+	out.outSource.addSysImportName(JI_GO_TYPE.typeName, JI_GO_TYPE.qualifiedName)
+	out.Print("@GoType(", strconv.Quote(goType), ") ")
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// run compiles the java sources of a translation and runs the main class of the app package,
+// the test is skipped without a jdk
+func (tr *translation) run(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("javac"); err != nil {
+		t.Skip("no javac in the path")
+	}
+	dir := t.TempDir()
+	srcDir, classesDir := filepath.Join(dir, "src"), filepath.Join(dir, "classes")
+	args := []string{"-d", classesDir}
+	for path, source := range tr.files {
+		fileName := filepath.Join(srcDir, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(fileName), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(fileName, []byte(source), 0644); err != nil {
+			t.Fatal(err)
+		}
+		args = append(args, fileName)
+	}
+	if output, err := exec.Command("javac", args...).CombinedOutput(); err != nil {
+		t.Fatalf("javac: %v\n%s", err, output)
+	}
+	output, err := exec.Command("java", "-cp", classesDir, "app.Main").CombinedOutput()
+	if err != nil {
+		t.Fatalf("java: %v\n%s", err, output)
+	}
+	return string(output)
+}
+
+// the shortest representation of floats does not depend on the Double.toString of the jdk
+func TestShortestFloats(t *testing.T) {
+	tr := translate(t, map[string]string{"app/main.go": `package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+func main() {
+	fmt.Println(math.Pow(2, -44), math.SmallestNonzeroFloat64, 0.1, 1e21, 100.0)
+	fmt.Printf("%v %g %v\n", 2.0e-3, 1.0/3, float32(0.1))
+	fmt.Println(strconv.FormatFloat(math.Pow(2, -44), 'g', -1, 64), strconv.FormatFloat(5e-324, 'e', -1, 64))
+}
+`})
+	expectContains(t, tr.java(t, "org/go2j/util/Fmt.java"), "d = shortest(abs, bitSize);")
+	if output, expected := tr.run(t), "5.684341886080802e-14 5e-324 0.1 1e+21 100\n"+
+		"0.002 0.3333333333333333 0.1\n"+
+		"5.684341886080802e-14 5e-324\n"; output != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, output)
+	}
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+)
+
+// function literals are java lambdas, passed to functional interfaces like the less functions of
+// sort.Slice. java captures the variables of the enclosing function only when they are
+// effectively final, and the parameters cannot hide its variables, they are renamed
+
+func convertFuncLit(funcLit *ast.FuncLit, out *Output) {
+	declared := declaredNames(out.GetCurrentFuncDecl(), funcLit)
+	// the parameters hide the variables of the enclosing function in the body only
+	hidden := map[string]ast.Expr{}
+	renamed := map[string]string{}
+	out.Print("(")
+	idx := 0
+	for _, field := range funcLit.Type.Params.List {
+		names := field.Names
+		if len(names) == 0 {
+			names = []*ast.Ident{ast.NewIdent("_")}
+		}
+		for _, name := range names {
+			if idx > 0 {
+				out.Print(", ")
+			}
+			idx++
+			if name.Name == "_" {
+				// java has no blank parameters
+				out.Print("_", strconv.Itoa(idx-1))
+				continue
+			}
+			if _, has := hidden[name.Name]; !has {
+				hidden[name.Name] = out.GetVarType(name.Name)
+				renamed[name.Name] = out.blockInfo.Renames[name.Name]
+			}
+			out.AddVar(name.Name, field.Type)
+			javaName := name.Name
+			if declared[name.Name] {
+				javaName += "$"
+			}
+			out.Rename(name.Name, javaName)
+			out.Print(javaName)
+		}
+	}
+	out.Println(") -> {")
+	funcType := out.GetCurrentFuncType()
+	out.SetCurrentFuncType(funcLit.Type)
+	bodyOut := out.AddTab()
+	if defsFn := convertNamedResults(funcLit.Type); defsFn != nil {
+		defsFn(bodyOut)
+	}
+	for _, stmt := range funcLit.Body.List {
+		convertStmt(stmt, bodyOut)
+	}
+	out.Print("}")
+	out.SetCurrentFuncType(funcType)
+	for name, varType := range hidden {
+		out.AddVar(name, varType)
+		out.Rename(name, renamed[name])
+	}
+}
+
+// declaredNames returns the names of the parameters and the variables of a function outside of
+// one of its function literals
+func declaredNames(funcDecl *ast.FuncDecl, funcLit *ast.FuncLit) map[string]bool {
+	names := map[string]bool{}
+	if funcDecl == nil {
+		return names
+	}
+	addFields := func(fieldList *ast.FieldList) {
+		if fieldList == nil {
+			return
+		}
+		for _, field := range fieldList.List {
+			for _, name := range field.Names {
+				names[name.Name] = true
+			}
+		}
+	}
+	addIdent := func(expr ast.Expr) {
+		if ident, ok := expr.(*ast.Ident); ok {
+			names[ident.Name] = true
+		}
+	}
+	addFields(funcDecl.Type.Params)
+	addFields(funcDecl.Type.Results)
+	if funcDecl.Body == nil {
+		return names
+	}
+	ast.Inspect(funcDecl.Body, func(node ast.Node) bool {
+		switch tp := node.(type) {
+		case *ast.FuncLit:
+			if tp == funcLit {
+				return false
+			}
+			addFields(tp.Type.Params)
+			addFields(tp.Type.Results)
+		case *ast.AssignStmt:
+			if tp.Tok == token.DEFINE {
+				for _, lhs := range tp.Lhs {
+					addIdent(lhs)
+				}
+			}
+		case *ast.ValueSpec:
+			for _, name := range tp.Names {
+				names[name.Name] = true
+			}
+		case *ast.RangeStmt:
+			if tp.Tok == token.DEFINE {
+				addIdent(tp.Key)
+				addIdent(tp.Value)
+			}
+		}
+		return true
+	})
+	return names
+}
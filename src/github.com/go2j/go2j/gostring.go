@@ -0,0 +1,89 @@
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+)
+
+// string operations working on the UTF-8 bytes of go strings are translated to GoString calls
+
+func useGoString(out *Output) {
+	out.outSource.addSysImportName(JI_GO_STRING.typeName, JI_GO_STRING.qualifiedName)
+}
+
+func printGoStringCall(method string, out *Output, args ...ast.Expr) {
+	useGoString(out)
+	out.Print("GoString.")
+	out.Print(method)
+	out.Print("(")
+	for idx, arg := range args {
+		if idx > 0 {
+			out.Print(", ")
+		}
+		convertExpr(arg, out)
+	}
+	out.Print(")")
+}
+
+func convertStringIndex(indexExpr *ast.IndexExpr, out *Output) bool {
+	if !isStringType(typeOf(indexExpr.X, out)) {
+		return false
+	}
+	printGoStringCall("at", out, indexExpr.X, indexExpr.Index)
+	return true
+}
+
+func convertStringSlice(sliceExpr *ast.SliceExpr, out *Output) bool {
+	if !isStringType(typeOf(sliceExpr.X, out)) {
+		return false
+	}
+	low := sliceExpr.Low
+	if low == nil {
+		low = &ast.BasicLit{Kind: token.INT, Value: "0"}
+	}
+	if sliceExpr.High == nil {
+		printGoStringCall("slice", out, sliceExpr.X, low)
+	} else {
+		printGoStringCall("slice", out, sliceExpr.X, low, sliceExpr.High)
+	}
+	return true
+}
+
+func rangeVarName(expr ast.Expr) string {
+	if ident, ok := expr.(*ast.Ident); ok && ident.Name != "_" {
+		return ident.Name
+	}
+	return ""
+}
+
+// convertStringRangeStmt iterates the runes of a string with their byte offsets
+func convertStringRangeStmt(rangeStmt *ast.RangeStmt, out *Output) bool {
+	if !isStringType(typeOf(rangeStmt.X, out)) {
+		return false
+	}
+	useGoString(out)
+	// nested loops need distinct names
+	entryName := "indexedRune" + strconv.Itoa(out.tabs)
+	out.Print("for (GoString.IndexedRune ", entryName, " : GoString.range(")
+	convertExpr(rangeStmt.X, out)
+	out.Print("))")
+	isDef := rangeStmt.Tok == token.DEFINE
+	convertBlockStmt(rangeStmt.Body, out, func(out *Output) {
+		if name := rangeVarName(rangeStmt.Key); name != "" {
+			if isDef {
+				out.Print("int ")
+				out.AddVar(name, ast.NewIdent("int"))
+			}
+			out.Println(name, "=", entryName+".index;")
+		}
+		if name := rangeVarName(rangeStmt.Value); name != "" {
+			if isDef {
+				out.Print("int ")
+				out.AddVar(name, ast.NewIdent("rune"))
+			}
+			out.Println(name, "=", entryName+".rune;")
+		}
+	})
+	return true
+}
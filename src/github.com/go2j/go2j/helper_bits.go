@@ -0,0 +1,170 @@
+package main
+
+// GoBits has the functions of the math/bits package that java has not for the small and the
+// unsigned integers. uint is 32 bits like int, uint64 is the bits of a long
+var orgGo2jGoBits = `package org.go2j.util;
+
+import java.math.BigInteger;
+
+public final class GoBits {
+
+	public static final int UintSize = 32;
+
+	private static final long MASK32 = 0xffffffffL;
+
+	private GoBits() {
+	}
+
+	public static int LeadingZeros8(byte x) {
+		return Integer.numberOfLeadingZeros(x & 0xff) - 24;
+	}
+
+	public static int LeadingZeros16(short x) {
+		return Integer.numberOfLeadingZeros(x & 0xffff) - 16;
+	}
+
+	public static int TrailingZeros8(byte x) {
+		return x == 0 ? 8 : Integer.numberOfTrailingZeros(x);
+	}
+
+	public static int TrailingZeros16(short x) {
+		return x == 0 ? 16 : Integer.numberOfTrailingZeros(x);
+	}
+
+	public static int OnesCount8(byte x) {
+		return Integer.bitCount(x & 0xff);
+	}
+
+	public static int OnesCount16(short x) {
+		return Integer.bitCount(x & 0xffff);
+	}
+
+	public static byte RotateLeft8(byte x, int k) {
+		int s = k & 7;
+		return (byte) ((x & 0xff) << s | (x & 0xff) >>> (8 - s));
+	}
+
+	public static short RotateLeft16(short x, int k) {
+		int s = k & 15;
+		return (short) ((x & 0xffff) << s | (x & 0xffff) >>> (16 - s));
+	}
+
+	public static byte Reverse8(byte x) {
+		return (byte) (Integer.reverse(x) >>> 24);
+	}
+
+	public static short Reverse16(short x) {
+		return (short) (Integer.reverse(x) >>> 16);
+	}
+
+	public static int Len(int x) {
+		return 32 - Integer.numberOfLeadingZeros(x);
+	}
+
+	public static int Len8(byte x) {
+		return 32 - Integer.numberOfLeadingZeros(x & 0xff);
+	}
+
+	public static int Len16(short x) {
+		return 32 - Integer.numberOfLeadingZeros(x & 0xffff);
+	}
+
+	public static int Len64(long x) {
+		return 64 - Long.numberOfLeadingZeros(x);
+	}
+
+	public static Tuple2<Integer, Integer> Add32(int x, int y, int carry) {
+		long sum = (x & MASK32) + (y & MASK32) + (carry & MASK32);
+		return new Tuple2<>((int) sum, (int) (sum >>> 32));
+	}
+
+	public static Tuple2<Long, Long> Add64(long x, long y, long carry) {
+		long sum = x + y + carry;
+		long carryOut = ((x & y) | ((x | y) & ~sum)) >>> 63;
+		return new Tuple2<>(sum, carryOut);
+	}
+
+	public static Tuple2<Integer, Integer> Sub32(int x, int y, int borrow) {
+		int diff = x - y - borrow;
+		int borrowOut = ((~x & y) | (~(x ^ y) & diff)) >>> 31;
+		return new Tuple2<>(diff, borrowOut);
+	}
+
+	public static Tuple2<Long, Long> Sub64(long x, long y, long borrow) {
+		long diff = x - y - borrow;
+		long borrowOut = ((~x & y) | (~(x ^ y) & diff)) >>> 63;
+		return new Tuple2<>(diff, borrowOut);
+	}
+
+	// Mul32 and Mul64 return the high and the low half of the product
+	public static Tuple2<Integer, Integer> Mul32(int x, int y) {
+		long product = (x & MASK32) * (y & MASK32);
+		return new Tuple2<>((int) (product >>> 32), (int) product);
+	}
+
+	public static Tuple2<Long, Long> Mul64(long x, long y) {
+		long x0 = x & MASK32;
+		long x1 = x >>> 32;
+		long y0 = y & MASK32;
+		long y1 = y >>> 32;
+		long w0 = x0 * y0;
+		long t = x1 * y0 + (w0 >>> 32);
+		long w1 = t & MASK32;
+		long w2 = t >>> 32;
+		w1 += x0 * y1;
+		long hi = x1 * y1 + w2 + (w1 >>> 32);
+		return new Tuple2<>(hi, x * y);
+	}
+
+	// Div32 and Div64 panic for a zero divisor and for a quotient overflow
+	public static Tuple2<Integer, Integer> Div32(int hi, int lo, int y) {
+		if (y == 0) {
+			throw new ArithmeticException("integer divide by zero");
+		}
+		if (Integer.compareUnsigned(y, hi) <= 0) {
+			throw new ArithmeticException("integer overflow");
+		}
+		long z = (hi & MASK32) << 32 | (lo & MASK32);
+		return new Tuple2<>((int) Long.divideUnsigned(z, y & MASK32), (int) Long.remainderUnsigned(z, y & MASK32));
+	}
+
+	public static Tuple2<Long, Long> Div64(long hi, long lo, long y) {
+		if (y == 0) {
+			throw new ArithmeticException("integer divide by zero");
+		}
+		if (Long.compareUnsigned(y, hi) <= 0) {
+			throw new ArithmeticException("integer overflow");
+		}
+		BigInteger[] qr = unsigned(hi, lo).divideAndRemainder(unsigned(0, y));
+		return new Tuple2<>(qr[0].longValue(), qr[1].longValue());
+	}
+
+	public static int Rem32(int hi, int lo, int y) {
+		if (y == 0) {
+			throw new ArithmeticException("integer divide by zero");
+		}
+		return (int) Long.remainderUnsigned((hi & MASK32) << 32 | (lo & MASK32), y & MASK32);
+	}
+
+	public static long Rem64(long hi, long lo, long y) {
+		if (y == 0) {
+			throw new ArithmeticException("integer divide by zero");
+		}
+		return unsigned(hi, lo).remainder(unsigned(0, y)).longValue();
+	}
+
+	private static BigInteger unsigned(long hi, long lo) {
+		byte[] b = new byte[17];
+		for (int i = 0; i < 8; i++) {
+			b[8 - i] = (byte) (hi >>> (8 * i));
+			b[16 - i] = (byte) (lo >>> (8 * i));
+		}
+		return new BigInteger(b);
+	}
+
+}
+`
+
+func init() {
+	helperClasses["org/go2j/util/GoBits.java"] = orgGo2jGoBits
+}
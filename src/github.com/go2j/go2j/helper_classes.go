@@ -5,22 +5,14 @@ import (
 	"os"
 )
 
-var orgGo2jUtil = `package org.go2j.util;
-
-import java.util.Arrays;
-
-public class ArrayUtil {
-	
-	public static <T> T[] append(T[] original, T element) {
-		T[] copy = Arrays.copyOf(original, original.length + 1);
-		copy[original.length] = element;
-		return copy;
-	}
-
+// helperClasses holds the runtime classes by their path in the java source folder
+var helperClasses = map[string]string{
+	"org/go2j/util/GoString.java": orgGo2jGoString,
 }
-`
 
 func generateHelperClasses(projectSrcPath string) {
-	os.MkdirAll(projectSrcPath + "/org/go2j/util", 0755)
-	ioutil.WriteFile(projectSrcPath+"/org/go2j/util/ArrayUtil.java", []byte(orgGo2jUtil), 0644)
+	for path, source := range helperClasses {
+		os.MkdirAll(projectSrcPath+"/"+pathOf(path), 0755)
+		ioutil.WriteFile(projectSrcPath+"/"+path, []byte(source), 0644)
+	}
 }
@@ -0,0 +1,88 @@
+package main
+
+// GoObjects compares interface values: equal values have the same dynamic type,
+// comparing uncomparable dynamic types panics. It also prints values like go and copies the
+// struct and array values.
+var orgGo2jGoObjects = `package org.go2j.util;
+
+import java.lang.reflect.Array;
+import java.util.Arrays;
+import java.util.Map;
+import java.util.function.Function;
+
+public final class GoObjects {
+
+	private GoObjects() {
+	}
+
+	public static boolean equals(Object a, Object b) {
+		if (a == null || b == null || a instanceof Pointer) {
+			return a == b;
+		}
+		if (a.getClass() != b.getClass()) {
+			return false;
+		}
+		if (!isComparable(a)) {
+			throw new RuntimeException("runtime error: comparing uncomparable type " + a.getClass().getName());
+		}
+		if (a instanceof Double) {
+			// NaN is not equal to itself, -0.0 equals 0.0
+			return ((Double) a).doubleValue() == ((Double) b).doubleValue();
+		}
+		if (a instanceof Float) {
+			return ((Float) a).floatValue() == ((Float) b).floatValue();
+		}
+		if (a.getClass().isArray()) {
+			return Arrays.deepEquals(new Object[] { a }, new Object[] { b });
+		}
+		return a.equals(b);
+	}
+
+	// Value is implemented by the classes of structs, go copies their values on assignment
+	public interface Value {
+		Object copy$();
+	}
+
+	// Pointer is implemented by the classes of structs converted to interfaces as pointers,
+	// interfaces compare them by identity
+	public interface Pointer {
+	}
+
+	// copy returns a copy of a struct or an array, the structs and arrays of its elements are copied too
+	@SuppressWarnings("unchecked")
+	public static <T> T copy(T value) {
+		if (value instanceof Value) {
+			return (T) ((Value) value).copy$();
+		}
+		if (value == null || !value.getClass().isArray()) {
+			return value;
+		}
+		int n = Array.getLength(value);
+		Object c = Array.newInstance(value.getClass().getComponentType(), n);
+		System.arraycopy(value, 0, c, 0, n);
+		if (!value.getClass().getComponentType().isPrimitive()) {
+			Object[] elements = (Object[]) c;
+			for (int i = 0; i < n; i++) {
+				elements[i] = copy(elements[i]);
+			}
+		}
+		return (T) c;
+	}
+
+	// toString prints values like %v, java arrays like go arrays
+	public static String toString(Object a) {
+		return Fmt.Sprint(a);
+	}
+
+	static boolean isComparable(Object a) {
+		return !(a instanceof Map || a instanceof Function || a instanceof Slice || a instanceof BooleanSlice
+				|| a instanceof ByteSlice || a instanceof ShortSlice || a instanceof IntSlice || a instanceof LongSlice
+				|| a instanceof FloatSlice || a instanceof DoubleSlice);
+	}
+
+}
+`
+
+func init() {
+	helperClasses["org/go2j/util/GoObjects.java"] = orgGo2jGoObjects
+}
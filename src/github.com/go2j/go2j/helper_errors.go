@@ -0,0 +1,237 @@
+package main
+
+// GoError is the go error interface, the converted types with an Error() string method implement it.
+// Types with Unwrap or Is methods implement the nested interfaces errors.Is and errors.As follow.
+var orgGo2jGoError = `package org.go2j.util;
+
+public interface GoError {
+
+	String Error();
+
+	public interface Wrapper {
+		GoError Unwrap();
+	}
+
+	public interface MultiWrapper {
+		Slice<GoError> Unwrap();
+	}
+
+	public interface Matcher {
+		boolean Is(GoError target);
+	}
+
+}
+`
+
+// GoErrors implements the errors package, Fmt.Errorf creates the wrapping errors
+var orgGo2jGoErrors = `package org.go2j.util;
+
+import java.util.ArrayList;
+import java.util.List;
+
+public final class GoErrors {
+
+	private GoErrors() {
+	}
+
+	static class ErrorString implements GoError {
+		private final String s;
+
+		ErrorString(String s) {
+			this.s = s;
+		}
+
+		@Override
+		public String Error() {
+			return s;
+		}
+
+		@Override
+		public String toString() {
+			return s;
+		}
+	}
+
+	static class WrapError extends ErrorString implements GoError.Wrapper {
+		private final GoError err;
+
+		WrapError(String msg, GoError err) {
+			super(msg);
+			this.err = err;
+		}
+
+		@Override
+		public GoError Unwrap() {
+			return err;
+		}
+	}
+
+	static class WrapErrors extends ErrorString implements GoError.MultiWrapper {
+		private final GoError[] errs;
+
+		WrapErrors(String msg, GoError[] errs) {
+			super(msg);
+			this.errs = errs;
+		}
+
+		@Override
+		public Slice<GoError> Unwrap() {
+			return Slice.of(errs.clone());
+		}
+	}
+
+	static class JoinError implements GoError, GoError.MultiWrapper {
+		private final GoError[] errs;
+
+		JoinError(GoError[] errs) {
+			this.errs = errs;
+		}
+
+		@Override
+		public String Error() {
+			StringBuilder sb = new StringBuilder();
+			for (int i = 0; i < errs.length; i++) {
+				if (i > 0) {
+					sb.append('\n');
+				}
+				sb.append(errs[i].Error());
+			}
+			return sb.toString();
+		}
+
+		@Override
+		public Slice<GoError> Unwrap() {
+			return Slice.of(errs.clone());
+		}
+
+		@Override
+		public String toString() {
+			return Error();
+		}
+	}
+
+	public static GoError New(String text) {
+		return new ErrorString(text);
+	}
+
+	public static GoError Unwrap(GoError err) {
+		if (err instanceof GoError.Wrapper) {
+			return ((GoError.Wrapper) err).Unwrap();
+		}
+		return null;
+	}
+
+	public static boolean Is(GoError err, GoError target) {
+		if (err == null || target == null) {
+			return err == target;
+		}
+		return is(err, target, GoObjects.isComparable(target));
+	}
+
+	private static boolean is(GoError err, GoError target, boolean targetComparable) {
+		while (err != null) {
+			if (targetComparable && GoObjects.equals(err, target)) {
+				return true;
+			}
+			if (err instanceof GoError.Matcher && ((GoError.Matcher) err).Is(target)) {
+				return true;
+			}
+			if (err instanceof GoError.MultiWrapper) {
+				Slice<GoError> errs = ((GoError.MultiWrapper) err).Unwrap();
+				for (int i = 0; errs != null && i < Slice.len(errs); i++) {
+					if (is(errs.get(i), target, targetComparable)) {
+						return true;
+					}
+				}
+				return false;
+			}
+			err = Unwrap(err);
+		}
+		return false;
+	}
+
+	private static final ThreadLocal<Object> match = new ThreadLocal<Object>();
+
+	// found tells whether the tree of err has an instance of the target class, take returns it.
+	// errors.As(err, &target) assigns the target on a match only
+	public static boolean found(GoError err, Class<?> target) {
+		Object found = As(err, target);
+		match.set(found);
+		return found != null;
+	}
+
+	public static <T> T take(Class<T> target) {
+		Object found = match.get();
+		match.remove();
+		return target.cast(found);
+	}
+
+	// As returns the first error in the tree of err that is an instance of the target class, or null
+	public static <T> T As(GoError err, Class<T> target) {
+		while (err != null) {
+			if (target.isInstance(err)) {
+				return target.cast(err);
+			}
+			if (err instanceof GoError.MultiWrapper) {
+				Slice<GoError> errs = ((GoError.MultiWrapper) err).Unwrap();
+				for (int i = 0; errs != null && i < Slice.len(errs); i++) {
+					T found = As(errs.get(i), target);
+					if (found != null) {
+						return found;
+					}
+				}
+				return null;
+			}
+			err = Unwrap(err);
+		}
+		return null;
+	}
+
+	public static GoError Join(GoError... errs) {
+		List<GoError> nonNil = new ArrayList<GoError>();
+		for (GoError err : errs) {
+			if (err != null) {
+				nonNil.add(err);
+			}
+		}
+		if (nonNil.isEmpty()) {
+			return null;
+		}
+		return new JoinError(nonNil.toArray(new GoError[0]));
+	}
+
+}
+`
+
+// GoException carries a non-nil error of an exported function to java callers
+var orgGo2jGoException = `package org.go2j.util;
+
+public class GoException extends Exception {
+
+	private static final long serialVersionUID = 1L;
+
+	private final GoError error;
+
+	public GoException(GoError error) {
+		super(error.Error());
+		this.error = error;
+	}
+
+	public GoError getError() {
+		return error;
+	}
+
+	public static void check(GoError error) throws GoException {
+		if (error != null) {
+			throw new GoException(error);
+		}
+	}
+
+}
+`
+
+func init() {
+	helperClasses["org/go2j/util/GoError.java"] = orgGo2jGoError
+	helperClasses["org/go2j/util/GoErrors.java"] = orgGo2jGoErrors
+	helperClasses["org/go2j/util/GoException.java"] = orgGo2jGoException
+}
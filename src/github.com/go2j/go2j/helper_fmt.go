@@ -0,0 +1,1758 @@
+package main
+
+// Fmt formats like the go fmt package. The printf parser, the verbs and the flags follow fmt/print.go
+// and fmt/format.go, the float digits follow strconv.FormatFloat. Values of unsigned types and pointers
+// to composite values are marked by the converter with Fmt.uint and Fmt.pointer, struct fields keep these
+// types in a GoType annotation.
+var orgGo2jFmt = `package org.go2j.util;
+
+import java.io.IOException;
+import java.io.OutputStream;
+import java.lang.reflect.Array;
+import java.lang.reflect.Field;
+import java.lang.reflect.InvocationTargetException;
+import java.lang.reflect.Method;
+import java.lang.reflect.Modifier;
+import java.math.BigDecimal;
+import java.math.MathContext;
+import java.math.RoundingMode;
+import java.util.ArrayList;
+import java.util.Collections;
+import java.util.HashMap;
+import java.util.List;
+import java.util.Map;
+import java.util.Optional;
+import java.util.concurrent.ConcurrentHashMap;
+
+public final class Fmt {
+
+	// Unsigned is a value of an unsigned integer type, java has no unsigned types
+	public static final class Unsigned {
+		final long value;
+		final String type;
+
+		Unsigned(long value, String type) {
+			this.value = value;
+			this.type = type;
+		}
+
+		@Override
+		public String toString() {
+			return Long.toUnsignedString(value);
+		}
+	}
+
+	// Pointer is a pointer to a struct, an array, a slice or a map, printed as &{...} at the top level
+	public static final class Pointer {
+		final Object target;
+
+		Pointer(Object target) {
+			this.target = target;
+		}
+
+		@Override
+		public String toString() {
+			return Sprint(this);
+		}
+	}
+
+	private static final String LDIGITS = "0123456789abcdef";
+	private static final String UDIGITS = "0123456789ABCDEF";
+	private static final char BACKQUOTE = (char) 0x60;
+
+	private static final Map<Class<?>, String> typeNames = new HashMap<Class<?>, String>();
+	private static final Map<Class<?>, Field[]> structFields = new ConcurrentHashMap<Class<?>, Field[]>();
+	private static final Map<Class<?>, Optional<Method>> stringers = new ConcurrentHashMap<Class<?>, Optional<Method>>();
+
+	static {
+		typeNames.put(Boolean.class, "bool");
+		typeNames.put(boolean.class, "bool");
+		typeNames.put(Byte.class, "int8");
+		typeNames.put(byte.class, "uint8");
+		typeNames.put(Short.class, "int16");
+		typeNames.put(short.class, "int16");
+		typeNames.put(Character.class, "int32");
+		typeNames.put(char.class, "int32");
+		typeNames.put(Integer.class, "int");
+		typeNames.put(int.class, "int");
+		typeNames.put(Long.class, "int64");
+		typeNames.put(long.class, "int64");
+		typeNames.put(Float.class, "float32");
+		typeNames.put(float.class, "float32");
+		typeNames.put(Double.class, "float64");
+		typeNames.put(double.class, "float64");
+		typeNames.put(String.class, "string");
+		typeNames.put(Object.class, "interface {}");
+		typeNames.put(GoError.class, "error");
+		typeNames.put(BooleanSlice.class, "[]bool");
+		typeNames.put(ByteSlice.class, "[]uint8");
+		typeNames.put(ShortSlice.class, "[]int16");
+		typeNames.put(IntSlice.class, "[]int");
+		typeNames.put(LongSlice.class, "[]int64");
+		typeNames.put(FloatSlice.class, "[]float32");
+		typeNames.put(DoubleSlice.class, "[]float64");
+		typeNames.put(GoErrors.ErrorString.class, "*errors.errorString");
+		typeNames.put(GoErrors.JoinError.class, "*errors.joinError");
+		typeNames.put(GoErrors.WrapError.class, "*fmt.wrapError");
+		typeNames.put(GoErrors.WrapErrors.class, "*fmt.wrapErrors");
+		typeNames.put(GoStrings.Builder.class, "strings.Builder");
+		typeNames.put(GoStrings.Replacer.class, "*strings.Replacer");
+		typeNames.put(Strconv.NumError.class, "*strconv.NumError");
+	}
+
+	private final StringBuilder buf = new StringBuilder();
+
+	// flags of the verb being printed
+	private boolean plus, minus, sharp, space, zero, plusV, sharpV;
+	private boolean widPresent, precPresent;
+	private int wid, prec;
+
+	// the value being printed, bad verbs print it
+	private Object arg;
+	private boolean erroring;
+	private boolean reordered, goodArgNum;
+
+	// the argument indexes of the %w verbs of Errorf, null for the other functions
+	private List<Integer> wrappedArgs;
+
+	private Fmt() {
+	}
+
+	public static Unsigned uint(long value, String type) {
+		switch (type) {
+		case "uint8":
+			return new Unsigned(value & 0xFFL, type);
+		case "uint16":
+			return new Unsigned(value & 0xFFFFL, type);
+		case "uint":
+		case "uint32":
+			return new Unsigned(value & 0xFFFFFFFFL, type);
+		default:
+			return new Unsigned(value, type);
+		}
+	}
+
+	public static Object pointer(Object target) {
+		return target == null ? null : new Pointer(target);
+	}
+
+	public static String Sprintf(String format, Object... a) {
+		Fmt p = new Fmt();
+		p.doPrintf(format, args(a));
+		return p.buf.toString();
+	}
+
+	public static String Sprint(Object... a) {
+		Fmt p = new Fmt();
+		p.doPrint(args(a));
+		return p.buf.toString();
+	}
+
+	public static String Sprintln(Object... a) {
+		Fmt p = new Fmt();
+		p.doPrintln(args(a));
+		return p.buf.toString();
+	}
+
+	public static Tuple2<Integer, GoError> Fprintf(Object w, String format, Object... a) {
+		return write(w, Sprintf(format, a));
+	}
+
+	public static Tuple2<Integer, GoError> Fprint(Object w, Object... a) {
+		return write(w, Sprint(a));
+	}
+
+	public static Tuple2<Integer, GoError> Fprintln(Object w, Object... a) {
+		return write(w, Sprintln(a));
+	}
+
+	public static Tuple2<Integer, GoError> Printf(String format, Object... a) {
+		return write(System.out, Sprintf(format, a));
+	}
+
+	public static Tuple2<Integer, GoError> Print(Object... a) {
+		return write(System.out, Sprint(a));
+	}
+
+	public static Tuple2<Integer, GoError> Println(Object... a) {
+		return write(System.out, Sprintln(a));
+	}
+
+	// Errorf formats the message, the errors of %w verbs are wrapped
+	public static GoError Errorf(String format, Object... a) {
+		a = args(a);
+		Fmt p = new Fmt();
+		p.wrappedArgs = new ArrayList<Integer>();
+		p.doPrintf(format, a);
+		String s = p.buf.toString();
+		switch (p.wrappedArgs.size()) {
+		case 0:
+			return GoErrors.New(s);
+		case 1:
+			Object err = a[p.wrappedArgs.get(0)];
+			return new GoErrors.WrapError(s, err instanceof GoError ? (GoError) err : null);
+		default:
+			if (p.reordered) {
+				Collections.sort(p.wrappedArgs);
+			}
+			List<GoError> errs = new ArrayList<GoError>();
+			for (int i = 0; i < p.wrappedArgs.size(); i++) {
+				int argNum = p.wrappedArgs.get(i);
+				if (i > 0 && p.wrappedArgs.get(i - 1) == argNum) {
+					continue;
+				}
+				if (a[argNum] instanceof GoError) {
+					errs.add((GoError) a[argNum]);
+				}
+			}
+			return new GoErrors.WrapErrors(s, errs.toArray(new GoError[0]));
+		}
+	}
+
+	// args keeps a single null argument, java passes it as the varargs array
+	private static Object[] args(Object[] a) {
+		return a == null ? new Object[] { null } : a;
+	}
+
+	// write writes the UTF-8 bytes of s to a stream, an appendable or a value with a Write([]byte) method
+	@SuppressWarnings("unchecked")
+	static Tuple2<Integer, GoError> write(Object w, String s) {
+		byte[] b = GoString.bytesOf(s);
+		try {
+			if (w instanceof OutputStream) {
+				((OutputStream) w).write(b);
+				((OutputStream) w).flush();
+			} else if (w instanceof Appendable) {
+				((Appendable) w).append(s);
+			} else {
+				Method write = w.getClass().getMethod("Write", ByteSlice.class);
+				return (Tuple2<Integer, GoError>) write.invoke(w, ByteSlice.wrap(b.clone()));
+			}
+		} catch (IOException e) {
+			return new Tuple2<Integer, GoError>(0, GoErrors.New(e.getMessage()));
+		} catch (NoSuchMethodException | IllegalAccessException e) {
+			throw new IllegalArgumentException("not a writer: " + w.getClass().getName(), e);
+		} catch (InvocationTargetException e) {
+			if (e.getCause() instanceof RuntimeException) {
+				throw (RuntimeException) e.getCause();
+			}
+			throw new RuntimeException(e.getCause());
+		}
+		return new Tuple2<Integer, GoError>(b.length, null);
+	}
+
+	private void clearFlags() {
+		plus = minus = sharp = space = zero = plusV = sharpV = false;
+		widPresent = precPresent = false;
+		wid = prec = 0;
+	}
+
+	private void doPrint(Object[] a) {
+		boolean prevString = false;
+		for (int argNum = 0; argNum < a.length; argNum++) {
+			boolean isString = a[argNum] instanceof String;
+			// a space is added between operands when neither is a string
+			if (argNum > 0 && !isString && !prevString) {
+				buf.append(' ');
+			}
+			printArg(a[argNum], 'v');
+			prevString = isString;
+		}
+	}
+
+	private void doPrintln(Object[] a) {
+		for (int argNum = 0; argNum < a.length; argNum++) {
+			if (argNum > 0) {
+				buf.append(' ');
+			}
+			printArg(a[argNum], 'v');
+		}
+		buf.append('\n');
+	}
+
+	private void doPrintf(String format, Object[] a) {
+		int end = format.length();
+		int argNum = 0;
+		boolean afterIndex;
+		reordered = false;
+		for (int i = 0; i < end;) {
+			goodArgNum = true;
+			int lasti = i;
+			while (i < end && format.charAt(i) != '%') {
+				i++;
+			}
+			if (i > lasti) {
+				buf.append(format, lasti, i);
+			}
+			if (i >= end) {
+				break;
+			}
+			i++;
+			clearFlags();
+			flags:
+			for (; i < end; i++) {
+				switch (format.charAt(i)) {
+				case '#':
+					sharp = true;
+					break;
+				case '0':
+					// zero padding is allowed only to the left
+					zero = !minus;
+					break;
+				case '+':
+					plus = true;
+					break;
+				case '-':
+					minus = true;
+					zero = false;
+					break;
+				case ' ':
+					space = true;
+					break;
+				default:
+					break flags;
+				}
+			}
+
+			int[] index = argNumber(argNum, format, i, a.length);
+			argNum = index[0];
+			i = index[1];
+			afterIndex = index[2] != 0;
+
+			if (i < end && format.charAt(i) == '*') {
+				i++;
+				int[] n = intFromArg(a, argNum);
+				wid = n[0];
+				widPresent = n[1] != 0;
+				argNum = n[2];
+				if (!widPresent) {
+					buf.append("%!(BADWIDTH)");
+				}
+				if (wid < 0) {
+					wid = -wid;
+					minus = true;
+					zero = false;
+				}
+				afterIndex = false;
+			} else {
+				int[] n = parseNum(format, i, end);
+				wid = n[0];
+				widPresent = n[1] != 0;
+				i = n[2];
+				if (afterIndex && widPresent) {
+					goodArgNum = false;
+				}
+			}
+
+			if (i + 1 <= end && format.charAt(i) == '.') {
+				i++;
+				if (afterIndex) {
+					goodArgNum = false;
+				}
+				index = argNumber(argNum, format, i, a.length);
+				argNum = index[0];
+				i = index[1];
+				afterIndex = index[2] != 0;
+				if (i < end && format.charAt(i) == '*') {
+					i++;
+					int[] n = intFromArg(a, argNum);
+					prec = n[0];
+					precPresent = n[1] != 0;
+					argNum = n[2];
+					if (prec < 0) {
+						prec = 0;
+						precPresent = false;
+					}
+					if (!precPresent) {
+						buf.append("%!(BADPREC)");
+					}
+					afterIndex = false;
+				} else {
+					int[] n = parseNum(format, i, end);
+					prec = n[0];
+					precPresent = true;
+					i = n[2];
+				}
+			}
+
+			if (!afterIndex) {
+				index = argNumber(argNum, format, i, a.length);
+				argNum = index[0];
+				i = index[1];
+			}
+
+			if (i >= end) {
+				buf.append("%!(NOVERB)");
+				break;
+			}
+
+			int verb = format.codePointAt(i);
+			i += Character.charCount(verb);
+
+			if (verb == '%') {
+				// percent does not absorb operands and ignores the width and the precision
+				buf.append('%');
+				continue;
+			}
+			if (!goodArgNum) {
+				buf.append("%!").appendCodePoint(verb).append("(BADINDEX)");
+				continue;
+			}
+			if (argNum >= a.length) {
+				buf.append("%!").appendCodePoint(verb).append("(MISSING)");
+				continue;
+			}
+			if (verb == 'w' && wrappedArgs != null) {
+				wrappedArgs.add(argNum);
+			}
+			if (verb == 'v' || verb == 'w') {
+				// go syntax and struct field names
+				sharpV = sharp;
+				sharp = false;
+				plusV = plus;
+				plus = false;
+			}
+			printArg(a[argNum], verb);
+			argNum++;
+		}
+
+		if (!reordered && argNum < a.length) {
+			clearFlags();
+			buf.append("%!(EXTRA ");
+			for (int i = argNum; i < a.length; i++) {
+				if (i > argNum) {
+					buf.append(", ");
+				}
+				if (a[i] == null) {
+					buf.append("<nil>");
+				} else {
+					buf.append(typeName(a[i])).append('=');
+					printArg(a[i], 'v');
+				}
+			}
+			buf.append(')');
+		}
+	}
+
+	// argNumber parses an [n] argument index, it returns the argument, the next position and whether an index was found
+	private int[] argNumber(int argNum, String format, int i, int numArgs) {
+		if (format.length() <= i || format.charAt(i) != '[') {
+			return new int[] { argNum, i, 0 };
+		}
+		reordered = true;
+		int close = format.indexOf(']', i + 1);
+		if (close < 0 || close - i < 2) {
+			goodArgNum = false;
+			return new int[] { argNum, i + 1, 0 };
+		}
+		int[] n = parseNum(format, i + 1, close);
+		if (n[1] == 0 || n[2] != close) {
+			goodArgNum = false;
+			return new int[] { argNum, close + 1, 0 };
+		}
+		int index = n[0] - 1;
+		if (index >= 0 && index < numArgs) {
+			return new int[] { index, close + 1, 1 };
+		}
+		goodArgNum = false;
+		return new int[] { argNum, close + 1, 1 };
+	}
+
+	// parseNum returns the number, whether there was one and the next position
+	private static int[] parseNum(String s, int start, int end) {
+		int num = 0;
+		int isNum = 0;
+		int i = start;
+		for (; i < end && s.charAt(i) >= '0' && s.charAt(i) <= '9'; i++) {
+			if (num > 1000000) {
+				return new int[] { 0, 0, end };
+			}
+			num = num * 10 + s.charAt(i) - '0';
+			isNum = 1;
+		}
+		return new int[] { num, isNum, i };
+	}
+
+	// intFromArg returns the argument of a * width or precision, whether it is an int and the next argument
+	private static int[] intFromArg(Object[] a, int argNum) {
+		if (argNum >= a.length) {
+			return new int[] { 0, 0, argNum };
+		}
+		Object arg = a[argNum];
+		long n;
+		if (arg instanceof Integer || arg instanceof Long || arg instanceof Short || arg instanceof Byte) {
+			n = ((Number) arg).longValue();
+		} else if (arg instanceof Unsigned && ((Unsigned) arg).value >= 0) {
+			n = ((Unsigned) arg).value;
+		} else {
+			return new int[] { 0, 0, argNum + 1 };
+		}
+		if (n > 1000000 || n < -1000000) {
+			return new int[] { 0, 0, argNum + 1 };
+		}
+		return new int[] { (int) n, 1, argNum + 1 };
+	}
+
+	private void printArg(Object arg, int verb) {
+		this.arg = arg;
+		if (arg == null) {
+			if (verb == 'T' || verb == 'v') {
+				pad("<nil>");
+			} else {
+				badVerb(verb);
+			}
+			return;
+		}
+		if (verb == 'T') {
+			fmtS(typeName(arg));
+			return;
+		}
+		if (printBasic(arg, verb)) {
+			return;
+		}
+		if (verb == 'p') {
+			fmtPointer(arg, verb);
+			return;
+		}
+		byte[] bytes = bytes(arg);
+		if (bytes != null) {
+			printBytes(bytes, verb, typeName(arg));
+			return;
+		}
+		if (handleMethods(arg, verb)) {
+			return;
+		}
+		printValue(arg, verb, 0, true);
+	}
+
+	private boolean printBasic(Object value, int verb) {
+		if (value instanceof Boolean) {
+			printBool((Boolean) value, verb);
+		} else if (value instanceof Double) {
+			printFloat((Double) value, 64, verb);
+		} else if (value instanceof Float) {
+			printFloat((Float) value, 32, verb);
+		} else if (value instanceof Integer || value instanceof Long || value instanceof Short || value instanceof Byte) {
+			printInteger(((Number) value).longValue(), true, verb);
+		} else if (value instanceof Character) {
+			printInteger((Character) value, true, verb);
+		} else if (value instanceof Unsigned) {
+			printInteger(((Unsigned) value).value, false, verb);
+		} else if (value instanceof String) {
+			printString((String) value, verb);
+		} else {
+			return false;
+		}
+		return true;
+	}
+
+	// handleMethods prints the result of the Error or String method of a value
+	private boolean handleMethods(Object value, int verb) {
+		if (erroring) {
+			return false;
+		}
+		Object receiver = value instanceof Pointer ? ((Pointer) value).target : value;
+		if (verb == 'w') {
+			// only Errorf wraps errors
+			if (!(receiver instanceof GoError) || wrappedArgs == null) {
+				badVerb(verb);
+				return true;
+			}
+			verb = 'v';
+		}
+		if (sharpV) {
+			return false;
+		}
+		switch (verb) {
+		case 'v':
+		case 's':
+		case 'x':
+		case 'X':
+		case 'q':
+			break;
+		default:
+			return false;
+		}
+		String method = "Error";
+		String s;
+		try {
+			if (receiver instanceof GoError) {
+				s = ((GoError) receiver).Error();
+			} else {
+				Method stringer = stringer(receiver.getClass());
+				if (stringer == null) {
+					return false;
+				}
+				method = "String";
+				s = (String) stringer.invoke(receiver);
+			}
+		} catch (InvocationTargetException e) {
+			catchPanic(verb, method, e.getCause());
+			return true;
+		} catch (IllegalAccessException e) {
+			return false;
+		} catch (RuntimeException e) {
+			catchPanic(verb, method, e);
+			return true;
+		}
+		printString(s, verb);
+		return true;
+	}
+
+	private void catchPanic(int verb, String method, Throwable panic) {
+		clearFlags();
+		buf.append("%!").appendCodePoint(verb).append("(PANIC=").append(method).append(" method: ");
+		buf.append(panic.getMessage() != null ? panic.getMessage() : panic.toString());
+		buf.append(')');
+	}
+
+	private static Method stringer(Class<?> cls) {
+		Optional<Method> stringer = stringers.get(cls);
+		if (stringer == null) {
+			Method method = null;
+			try {
+				method = cls.getMethod("String");
+				if (method.getReturnType() != String.class || Modifier.isStatic(method.getModifiers())) {
+					method = null;
+				} else {
+					method.setAccessible(true);
+				}
+			} catch (NoSuchMethodException | RuntimeException e) {
+				method = null;
+			}
+			stringer = Optional.ofNullable(method);
+			stringers.put(cls, stringer);
+		}
+		return stringer.orElse(null);
+	}
+
+	private void printValue(Object value, int verb, int depth, boolean exported) {
+		// the methods of elements and exported fields are called like those of the arguments
+		if (depth > 0 && exported && value != null && handleMethods(value, verb)) {
+			return;
+		}
+		arg = value;
+		if (value == null) {
+			buf.append("<nil>");
+			return;
+		}
+		if (printBasic(value, verb)) {
+			return;
+		}
+		if (value instanceof Pointer) {
+			Object target = ((Pointer) value).target;
+			if (depth == 0 && isComposite(target)) {
+				buf.append('&');
+				printValue(target, verb, depth + 1, exported);
+				return;
+			}
+			fmtPointer(value, verb);
+			return;
+		}
+		if (value instanceof Map) {
+			printMap((Map<?, ?>) value, verb, depth, exported);
+			return;
+		}
+		Object elements = elements(value);
+		if (elements != null) {
+			printList(value, elements, verb, depth, exported);
+			return;
+		}
+		if (isFunc(value.getClass())) {
+			fmtPointer(value, verb);
+			return;
+		}
+		if (isStruct(value.getClass())) {
+			printStruct(value, verb, depth, exported);
+			return;
+		}
+		if (verb == 'v' || verb == 's') {
+			fmtS(String.valueOf(value));
+		} else {
+			badVerb(verb);
+		}
+	}
+
+	private void printMap(Map<?, ?> map, int verb, int depth, boolean exported) {
+		if (sharpV) {
+			buf.append(typeName(map)).append('{');
+		} else {
+			buf.append("map[");
+		}
+		List<Map.Entry<?, ?>> entries = new ArrayList<Map.Entry<?, ?>>(map.entrySet());
+		entries.sort((a, b) -> compareKeys(a.getKey(), b.getKey()));
+		for (int i = 0; i < entries.size(); i++) {
+			if (i > 0) {
+				buf.append(sharpV ? ", " : " ");
+			}
+			printValue(entries.get(i).getKey(), verb, depth + 1, exported);
+			buf.append(':');
+			printValue(entries.get(i).getValue(), verb, depth + 1, exported);
+		}
+		buf.append(sharpV ? '}' : ']');
+	}
+
+	private void printList(Object value, Object elements, int verb, int depth, boolean exported) {
+		boolean isBytes = elements instanceof byte[];
+		if (isBytes && (verb == 's' || verb == 'q' || verb == 'x' || verb == 'X')) {
+			printBytes((byte[]) elements, verb, typeName(value));
+			return;
+		}
+		int n = Array.getLength(elements);
+		if (sharpV) {
+			buf.append(typeName(value)).append('{');
+		} else {
+			buf.append('[');
+		}
+		for (int i = 0; i < n; i++) {
+			if (i > 0) {
+				buf.append(sharpV ? ", " : " ");
+			}
+			Object element = Array.get(elements, i);
+			if (isBytes) {
+				element = uint((Byte) element, "uint8");
+			}
+			printValue(element, verb, depth + 1, exported);
+		}
+		buf.append(sharpV ? '}' : ']');
+	}
+
+	private void printStruct(Object struct, int verb, int depth, boolean exported) {
+		if (sharpV) {
+			String name = typeName(struct);
+			buf.append(name.startsWith("*") ? "&" + name.substring(1) : name);
+		}
+		buf.append('{');
+		Field[] fields = fields(struct.getClass());
+		for (int i = 0; i < fields.length; i++) {
+			if (i > 0) {
+				buf.append(sharpV ? ", " : " ");
+			}
+			String name = fields[i].getName();
+			if (plusV || sharpV) {
+				buf.append(name).append(':');
+			}
+			printValue(fieldValue(struct, fields[i]), verb, depth + 1, exported && Character.isUpperCase(name.charAt(0)));
+		}
+		buf.append('}');
+	}
+
+	// fieldValue marks the values of pointer and unsigned fields
+	private static Object fieldValue(Object struct, Field field) {
+		Object value;
+		try {
+			value = field.get(struct);
+		} catch (IllegalAccessException e) {
+			throw new IllegalStateException(e);
+		}
+		GoType goType = field.getAnnotation(GoType.class);
+		if (goType == null || value == null) {
+			return value;
+		}
+		if (goType.value().startsWith("*")) {
+			return new Pointer(value);
+		}
+		if (value instanceof Number) {
+			return uint(((Number) value).longValue(), goType.value());
+		}
+		return value;
+	}
+
+	private static Field[] fields(Class<?> cls) {
+		Field[] fields = structFields.get(cls);
+		if (fields == null) {
+			List<Class<?>> classes = new ArrayList<Class<?>>();
+			for (Class<?> c = cls; c != null && c != Object.class; c = c.getSuperclass()) {
+				classes.add(0, c);
+			}
+			List<Field> list = new ArrayList<Field>();
+			for (Class<?> c : classes) {
+				for (Field field : c.getDeclaredFields()) {
+					if (Modifier.isStatic(field.getModifiers()) || field.isSynthetic()) {
+						continue;
+					}
+					field.setAccessible(true);
+					list.add(field);
+				}
+			}
+			fields = list.toArray(new Field[0]);
+			structFields.put(cls, fields);
+		}
+		return fields;
+	}
+
+	// elements returns the java array of the elements of slices and arrays, null for other values
+	private static Object elements(Object value) {
+		if (value.getClass().isArray()) {
+			return value;
+		}
+		if (value instanceof Slice) {
+			return ((Slice<?>) value).toArray();
+		}
+		if (value instanceof ByteSlice) {
+			return ((ByteSlice) value).toArray();
+		}
+		if (value instanceof IntSlice) {
+			return ((IntSlice) value).toArray();
+		}
+		if (value instanceof LongSlice) {
+			return ((LongSlice) value).toArray();
+		}
+		if (value instanceof DoubleSlice) {
+			return ((DoubleSlice) value).toArray();
+		}
+		if (value instanceof FloatSlice) {
+			return ((FloatSlice) value).toArray();
+		}
+		if (value instanceof ShortSlice) {
+			return ((ShortSlice) value).toArray();
+		}
+		if (value instanceof BooleanSlice) {
+			return ((BooleanSlice) value).toArray();
+		}
+		return null;
+	}
+
+	private static byte[] bytes(Object value) {
+		if (value instanceof byte[]) {
+			return (byte[]) value;
+		}
+		if (value instanceof ByteSlice) {
+			return ((ByteSlice) value).toArray();
+		}
+		return null;
+	}
+
+	private static boolean isComposite(Object value) {
+		return value instanceof Map || elements(value) != null || isStruct(value.getClass());
+	}
+
+	private static boolean isFunc(Class<?> cls) {
+		return cls.isSynthetic() || cls.getName().contains("$$Lambda");
+	}
+
+	private static boolean isStruct(Class<?> cls) {
+		if (cls.isArray() || cls.isPrimitive() || cls.isEnum() || cls.isInterface() || isFunc(cls)) {
+			return false;
+		}
+		String name = cls.getName();
+		return !name.startsWith("java.") && !name.startsWith("javax.");
+	}
+
+	// compareKeys orders map keys like go prints them
+	private static int compareKeys(Object a, Object b) {
+		if (a == null || b == null) {
+			return a == null ? (b == null ? 0 : -1) : 1;
+		}
+		if (a instanceof Unsigned && b instanceof Unsigned) {
+			return Long.compareUnsigned(((Unsigned) a).value, ((Unsigned) b).value);
+		}
+		if ((a instanceof Double || a instanceof Float) && (b instanceof Double || b instanceof Float)) {
+			double x = ((Number) a).doubleValue();
+			double y = ((Number) b).doubleValue();
+			if (Double.isNaN(x) || Double.isNaN(y)) {
+				// NaN sorts first
+				return Double.isNaN(x) ? (Double.isNaN(y) ? 0 : -1) : 1;
+			}
+			return Double.compare(x, y);
+		}
+		if (a instanceof Number && b instanceof Number) {
+			return Long.compare(((Number) a).longValue(), ((Number) b).longValue());
+		}
+		if (a instanceof String && b instanceof String) {
+			return GoString.compare((String) a, (String) b);
+		}
+		if (a instanceof Boolean && b instanceof Boolean) {
+			return Boolean.compare((Boolean) a, (Boolean) b);
+		}
+		if (a.getClass() == b.getClass() && isStruct(a.getClass())) {
+			for (Field field : fields(a.getClass())) {
+				int c = compareKeys(fieldValue(a, field), fieldValue(b, field));
+				if (c != 0) {
+					return c;
+				}
+			}
+			return 0;
+		}
+		return Integer.compare(System.identityHashCode(a), System.identityHashCode(b));
+	}
+
+	// typeName returns the go name of the type of a value
+	static String typeName(Object value) {
+		if (value == null) {
+			return "<nil>";
+		}
+		if (value instanceof Unsigned) {
+			return ((Unsigned) value).type;
+		}
+		if (value instanceof Pointer) {
+			return "*" + typeName(((Pointer) value).target);
+		}
+		if (value.getClass().isArray()) {
+			return "[" + Array.getLength(value) + "]" + classTypeName(value.getClass().getComponentType());
+		}
+		if (value instanceof Slice) {
+			for (Object element : ((Slice<?>) value).toArray()) {
+				if (element != null) {
+					return "[]" + typeName(element);
+				}
+			}
+			return "[]interface {}";
+		}
+		if (value instanceof Map) {
+			for (Map.Entry<?, ?> entry : ((Map<?, ?>) value).entrySet()) {
+				if (entry.getKey() != null && entry.getValue() != null) {
+					return "map[" + typeName(entry.getKey()) + "]" + typeName(entry.getValue());
+				}
+			}
+			return "map[interface {}]interface {}";
+		}
+		return classTypeName(value.getClass());
+	}
+
+	private static String classTypeName(Class<?> cls) {
+		String name = typeNames.get(cls);
+		if (name != null) {
+			return name;
+		}
+		if (cls.isArray()) {
+			return "[]" + classTypeName(cls.getComponentType());
+		}
+		if (isFunc(cls)) {
+			return "func()";
+		}
+		name = cls.getSimpleName();
+		if (cls.getEnclosingClass() != null && !name.isEmpty()) {
+			// unexported types are nested in the class of their package
+			name = Character.toLowerCase(name.charAt(0)) + name.substring(1);
+		}
+		String pkg = cls.getPackage() == null ? "" : cls.getPackage().getName();
+		pkg = pkg.substring(pkg.lastIndexOf('.') + 1);
+		return pkg.isEmpty() ? name : pkg + "." + name;
+	}
+
+	private void badVerb(int verb) {
+		erroring = true;
+		buf.append("%!").appendCodePoint(verb).append('(');
+		if (arg != null) {
+			buf.append(typeName(arg)).append('=');
+			printArg(arg, 'v');
+		} else {
+			buf.append("<nil>");
+		}
+		buf.append(')');
+		erroring = false;
+	}
+
+	private void printBool(boolean v, int verb) {
+		if (verb == 't' || verb == 'v') {
+			pad(v ? "true" : "false");
+		} else {
+			badVerb(verb);
+		}
+	}
+
+	private void printInteger(long v, boolean isSigned, int verb) {
+		switch (verb) {
+		case 'v':
+			if (sharpV && !isSigned) {
+				fmt0x64(v, true);
+			} else {
+				fmtInteger(v, 10, isSigned, verb, LDIGITS);
+			}
+			break;
+		case 'd':
+			fmtInteger(v, 10, isSigned, verb, LDIGITS);
+			break;
+		case 'b':
+			fmtInteger(v, 2, isSigned, verb, LDIGITS);
+			break;
+		case 'o':
+		case 'O':
+			fmtInteger(v, 8, isSigned, verb, LDIGITS);
+			break;
+		case 'x':
+			fmtInteger(v, 16, isSigned, verb, LDIGITS);
+			break;
+		case 'X':
+			fmtInteger(v, 16, isSigned, verb, UDIGITS);
+			break;
+		case 'c':
+			fmtC(v);
+			break;
+		case 'q':
+			fmtQc(v);
+			break;
+		case 'U':
+			fmtUnicode(v);
+			break;
+		default:
+			badVerb(verb);
+		}
+	}
+
+	private void printFloat(double v, int size, int verb) {
+		switch (verb) {
+		case 'v':
+			fmtFloat(v, size, 'g', -1);
+			break;
+		case 'b':
+		case 'g':
+		case 'G':
+		case 'x':
+		case 'X':
+			fmtFloat(v, size, verb, -1);
+			break;
+		case 'f':
+		case 'e':
+		case 'E':
+			fmtFloat(v, size, verb, 6);
+			break;
+		case 'F':
+			fmtFloat(v, size, 'f', 6);
+			break;
+		default:
+			badVerb(verb);
+		}
+	}
+
+	private void printString(String v, int verb) {
+		switch (verb) {
+		case 'v':
+			if (sharpV) {
+				fmtQ(v);
+			} else {
+				fmtS(v);
+			}
+			break;
+		case 's':
+			fmtS(v);
+			break;
+		case 'x':
+			fmtSbx(GoString.bytesOf(v), LDIGITS);
+			break;
+		case 'X':
+			fmtSbx(GoString.bytesOf(v), UDIGITS);
+			break;
+		case 'q':
+			fmtQ(v);
+			break;
+		default:
+			badVerb(verb);
+		}
+	}
+
+	private void printBytes(byte[] v, int verb, String typeString) {
+		switch (verb) {
+		case 'v':
+		case 'd':
+			if (sharpV) {
+				buf.append(typeString).append('{');
+				for (int i = 0; i < v.length; i++) {
+					if (i > 0) {
+						buf.append(", ");
+					}
+					fmt0x64(v[i] & 0xFF, true);
+				}
+				buf.append('}');
+			} else {
+				buf.append('[');
+				for (int i = 0; i < v.length; i++) {
+					if (i > 0) {
+						buf.append(' ');
+					}
+					fmtInteger(v[i] & 0xFF, 10, false, verb, LDIGITS);
+				}
+				buf.append(']');
+			}
+			break;
+		case 's':
+			fmtS(GoString.decode(v, 0, v.length));
+			break;
+		case 'x':
+			fmtSbx(v, LDIGITS);
+			break;
+		case 'X':
+			fmtSbx(v, UDIGITS);
+			break;
+		case 'q':
+			fmtQ(GoString.decode(v, 0, v.length));
+			break;
+		default:
+			printValue(v, verb, 0, true);
+		}
+	}
+
+	private void fmtPointer(Object value, int verb) {
+		Object target = value instanceof Pointer ? ((Pointer) value).target : value;
+		long u = target == null ? 0 : System.identityHashCode(target) & 0xFFFFFFFFL;
+		switch (verb) {
+		case 'v':
+			if (sharpV) {
+				buf.append('(').append(typeName(value)).append(")(");
+				if (u == 0) {
+					buf.append("nil");
+				} else {
+					fmt0x64(u, true);
+				}
+				buf.append(')');
+			} else if (u == 0) {
+				pad("<nil>");
+			} else {
+				fmt0x64(u, !sharp);
+			}
+			break;
+		case 'p':
+			fmt0x64(u, !sharp);
+			break;
+		case 'b':
+		case 'o':
+		case 'd':
+		case 'x':
+		case 'X':
+			printInteger(u, false, verb);
+			break;
+		default:
+			badVerb(verb);
+		}
+	}
+
+	private void fmt0x64(long v, boolean leading0x) {
+		boolean oldSharp = sharp;
+		sharp = leading0x;
+		fmtInteger(v, 16, false, 'v', LDIGITS);
+		sharp = oldSharp;
+	}
+
+	// pad writes s padded to the width, the width counts runes
+	private void pad(String s) {
+		if (!widPresent || wid == 0) {
+			buf.append(s);
+			return;
+		}
+		int width = wid - s.codePointCount(0, s.length());
+		if (!minus) {
+			padding(width);
+			buf.append(s);
+		} else {
+			buf.append(s);
+			padding(width);
+		}
+	}
+
+	private void padding(int n) {
+		char c = zero ? '0' : ' ';
+		for (int i = 0; i < n; i++) {
+			buf.append(c);
+		}
+	}
+
+	private void fmtInteger(long u, int base, boolean isSigned, int verb, String digits) {
+		boolean negative = isSigned && u < 0;
+		if (negative) {
+			u = -u;
+		}
+		// %.3d and %03d ask for leading zeros, with both the zero flag is ignored
+		int prec = 0;
+		if (precPresent) {
+			prec = this.prec;
+			if (prec == 0 && u == 0) {
+				boolean oldZero = zero;
+				zero = false;
+				padding(wid);
+				zero = oldZero;
+				return;
+			}
+		} else if (zero && !minus && widPresent) {
+			prec = wid;
+			if (negative || plus || space) {
+				prec--;
+			}
+		}
+		String s = Long.toUnsignedString(u, base);
+		if (digits == UDIGITS) {
+			s = s.toUpperCase();
+		}
+		StringBuilder sb = new StringBuilder();
+		for (int i = s.length(); i < prec; i++) {
+			sb.append('0');
+		}
+		sb.append(s);
+		if (sharp) {
+			switch (base) {
+			case 2:
+				sb.insert(0, "0b");
+				break;
+			case 8:
+				if (sb.charAt(0) != '0') {
+					sb.insert(0, '0');
+				}
+				break;
+			case 16:
+				sb.insert(0, digits == UDIGITS ? "0X" : "0x");
+				break;
+			}
+		}
+		if (verb == 'O') {
+			sb.insert(0, "0o");
+		}
+		if (negative) {
+			sb.insert(0, '-');
+		} else if (plus) {
+			sb.insert(0, '+');
+		} else if (space) {
+			sb.insert(0, ' ');
+		}
+		// the zero padding is already done
+		boolean oldZero = zero;
+		zero = false;
+		pad(sb.toString());
+		zero = oldZero;
+	}
+
+	private static int toRune(long c) {
+		if (Long.compareUnsigned(c, 0x10FFFF) > 0 || (c >= 0xD800 && c <= 0xDFFF)) {
+			return GoString.RUNE_ERROR;
+		}
+		return (int) c;
+	}
+
+	private void fmtC(long c) {
+		pad(new String(Character.toChars(toRune(c))));
+	}
+
+	private void fmtQc(long c) {
+		StringBuilder sb = new StringBuilder("'");
+		appendEscapedRune(sb, toRune(c), '\'', plus);
+		pad(sb.append('\'').toString());
+	}
+
+	private void fmtUnicode(long u) {
+		int prec = 4;
+		if (precPresent && this.prec > 4) {
+			prec = this.prec;
+		}
+		String hex = Long.toHexString(u).toUpperCase();
+		StringBuilder sb = new StringBuilder("U+");
+		for (int i = hex.length(); i < prec; i++) {
+			sb.append('0');
+		}
+		sb.append(hex);
+		if (sharp && u >= 0 && u <= 0x10FFFF && isPrint((int) u)) {
+			sb.append(" '").appendCodePoint((int) u).append('\'');
+		}
+		boolean oldZero = zero;
+		zero = false;
+		pad(sb.toString());
+		zero = oldZero;
+	}
+
+	// truncate keeps the runes of the precision
+	private String truncate(String s) {
+		if (precPresent && prec < s.codePointCount(0, s.length())) {
+			return s.substring(0, s.offsetByCodePoints(0, prec));
+		}
+		return s;
+	}
+
+	private void fmtS(String s) {
+		pad(truncate(s));
+	}
+
+	private void fmtQ(String s) {
+		s = truncate(s);
+		if (sharp && canBackquote(s)) {
+			pad(BACKQUOTE + s + BACKQUOTE);
+		} else {
+			pad(quote(s, '"', plus));
+		}
+	}
+
+	private void fmtSbx(byte[] b, String digits) {
+		int length = b.length;
+		if (precPresent && prec < length) {
+			length = prec;
+		}
+		StringBuilder sb = new StringBuilder();
+		for (int i = 0; i < length; i++) {
+			if (space && i > 0) {
+				sb.append(' ');
+			}
+			if (sharp && (space || i == 0)) {
+				sb.append('0').append(digits == UDIGITS ? 'X' : 'x');
+			}
+			sb.append(digits.charAt((b[i] >> 4) & 0xF)).append(digits.charAt(b[i] & 0xF));
+		}
+		pad(sb.toString());
+	}
+
+	private void fmtFloat(double v, int size, int verb, int prec) {
+		if (precPresent) {
+			prec = this.prec;
+		}
+		String num = formatFloat(v, (char) verb, prec, size);
+		if (num.charAt(0) != '-' && num.charAt(0) != '+') {
+			num = "+" + num;
+		}
+		if (space && num.charAt(0) == '+' && !plus) {
+			num = " " + num.substring(1);
+		}
+		// infinities and NaN are not padded with zeros
+		if (num.charAt(1) == 'I' || num.charAt(1) == 'N') {
+			boolean oldZero = zero;
+			zero = false;
+			if (num.charAt(1) == 'N' && !space && !plus) {
+				num = num.substring(1);
+			}
+			pad(num);
+			zero = oldZero;
+			return;
+		}
+		// the sharp flag forces a decimal point and keeps the trailing zeros of %g
+		if (sharp && verb != 'b') {
+			int digits = 0;
+			if (verb == 'v' || verb == 'g' || verb == 'G' || verb == 'x') {
+				digits = prec;
+				if (digits == -1) {
+					digits = 6;
+				}
+			}
+			String tail = "";
+			boolean hasDecimalPoint = false;
+			boolean sawNonzeroDigit = false;
+			StringBuilder sb = new StringBuilder(num);
+			scan:
+			for (int i = 1; i < sb.length(); i++) {
+				char c = sb.charAt(i);
+				switch (c) {
+				case '.':
+					hasDecimalPoint = true;
+					break;
+				case 'p':
+				case 'P':
+					tail = sb.substring(i);
+					sb.setLength(i);
+					break scan;
+				case 'e':
+				case 'E':
+					if (verb != 'x' && verb != 'X') {
+						tail = sb.substring(i);
+						sb.setLength(i);
+						break scan;
+					}
+					// hex digit
+				default:
+					if (c != '0') {
+						sawNonzeroDigit = true;
+					}
+					if (sawNonzeroDigit) {
+						digits--;
+					}
+				}
+			}
+			if (!hasDecimalPoint) {
+				if (sb.length() == 2 && sb.charAt(1) == '0') {
+					digits--;
+				}
+				sb.append('.');
+			}
+			for (; digits > 0; digits--) {
+				sb.append('0');
+			}
+			num = sb.append(tail).toString();
+		}
+		if (plus || num.charAt(0) != '+') {
+			// the sign goes before the zero padding
+			if (zero && !minus && widPresent && wid > num.length()) {
+				buf.append(num.charAt(0));
+				padding(wid - num.length());
+				buf.append(num, 1, num.length());
+				return;
+			}
+			pad(num);
+			return;
+		}
+		pad(num.substring(1));
+	}
+
+	// shortest returns the decimal of the fewest digits parsing to v, the closest one of them.
+	// Double.toString gives more digits before java 19
+	static BigDecimal shortest(double v, int bitSize) {
+		BigDecimal exact = new BigDecimal(v);
+		if (v == 0) {
+			return exact;
+		}
+		for (int p = 1;; p++) {
+			BigDecimal d = exact.round(new MathContext(p, RoundingMode.HALF_EVEN));
+			if (parsesTo(d, v, bitSize)) {
+				return d;
+			}
+			// the interval parsing to a power of two is narrower below it, the other neighbor may parse to v
+			for (RoundingMode mode : new RoundingMode[] { RoundingMode.UP, RoundingMode.DOWN }) {
+				d = exact.round(new MathContext(p, mode));
+				if (parsesTo(d, v, bitSize)) {
+					return d;
+				}
+			}
+		}
+	}
+
+	private static boolean parsesTo(BigDecimal d, double v, int bitSize) {
+		String s = d.toString();
+		return bitSize == 32 ? Float.parseFloat(s) == (float) v : Double.parseDouble(s) == v;
+	}
+
+	// formatFloat formats like strconv.FormatFloat, a negative precision gives the shortest representation
+	static String formatFloat(double v, char fmt, int prec, int bitSize) {
+		if (Double.isNaN(v)) {
+			return "NaN";
+		}
+		if (Double.isInfinite(v)) {
+			return v > 0 ? "+Inf" : "-Inf";
+		}
+		if (bitSize == 32) {
+			v = (float) v;
+		}
+		boolean neg = v < 0 || (v == 0 && 1 / v < 0);
+		double abs = Math.abs(v);
+		if (fmt == 'b') {
+			return formatBinary(abs, neg, bitSize);
+		}
+		if (fmt == 'x' || fmt == 'X') {
+			return formatHex(abs, neg, fmt, prec, bitSize);
+		}
+		boolean shortest = prec < 0;
+		BigDecimal d;
+		if (shortest) {
+			d = shortest(abs, bitSize);
+		} else {
+			d = new BigDecimal(abs);
+			switch (fmt) {
+			case 'e':
+			case 'E':
+				d = d.round(new MathContext(prec + 1, RoundingMode.HALF_EVEN));
+				break;
+			case 'f':
+				d = d.setScale(prec, RoundingMode.HALF_EVEN);
+				break;
+			case 'g':
+			case 'G':
+				if (prec == 0) {
+					prec = 1;
+				}
+				d = d.round(new MathContext(prec, RoundingMode.HALF_EVEN));
+				break;
+			}
+		}
+		// the digits without trailing zeros and the position of the decimal point
+		String digs = "";
+		int dp = 0;
+		if (d.signum() != 0) {
+			BigDecimal stripped = d.stripTrailingZeros();
+			digs = stripped.unscaledValue().toString();
+			dp = digs.length() - stripped.scale();
+		}
+		int nd = digs.length();
+		if (shortest) {
+			switch (fmt) {
+			case 'e':
+			case 'E':
+				prec = Math.max(nd - 1, 0);
+				break;
+			case 'f':
+				prec = Math.max(nd - dp, 0);
+				break;
+			case 'g':
+			case 'G':
+				prec = nd;
+				break;
+			}
+		}
+		StringBuilder sb = new StringBuilder();
+		if (neg) {
+			sb.append('-');
+		}
+		switch (fmt) {
+		case 'e':
+		case 'E':
+			fmtE(sb, digs, dp, prec, fmt);
+			break;
+		case 'f':
+			fmtF(sb, digs, dp, prec);
+			break;
+		case 'g':
+		case 'G':
+			int eprec = prec;
+			if (eprec > nd && nd >= dp) {
+				eprec = nd;
+			}
+			// %e is used if the exponent is less than -4 or not less than the precision, 6 for the shortest
+			if (shortest) {
+				eprec = 6;
+			}
+			int exp = dp - 1;
+			if (nd > 0 && (exp < -4 || exp >= eprec)) {
+				if (prec > nd) {
+					prec = nd;
+				}
+				fmtE(sb, digs, dp, prec - 1, fmt == 'g' ? 'e' : 'E');
+			} else {
+				if (prec > dp) {
+					prec = nd;
+				}
+				fmtF(sb, digs, dp, Math.max(prec - dp, 0));
+			}
+			break;
+		default:
+			return "%" + fmt;
+		}
+		return sb.toString();
+	}
+
+	private static void fmtE(StringBuilder sb, String digs, int dp, int prec, char fmt) {
+		sb.append(digs.isEmpty() ? '0' : digs.charAt(0));
+		if (prec > 0) {
+			sb.append('.');
+			int i = 1;
+			int m = Math.min(digs.length(), prec + 1);
+			if (i < m) {
+				sb.append(digs, i, m);
+				i = m;
+			}
+			for (; i <= prec; i++) {
+				sb.append('0');
+			}
+		}
+		sb.append(fmt);
+		int exp = digs.isEmpty() ? 0 : dp - 1;
+		sb.append(exp < 0 ? '-' : '+');
+		exp = Math.abs(exp);
+		if (exp < 10) {
+			sb.append('0');
+		}
+		sb.append(exp);
+	}
+
+	private static void fmtF(StringBuilder sb, String digs, int dp, int prec) {
+		if (dp > 0) {
+			int m = Math.min(digs.length(), dp);
+			sb.append(digs, 0, m);
+			for (; m < dp; m++) {
+				sb.append('0');
+			}
+		} else {
+			sb.append('0');
+		}
+		if (prec > 0) {
+			sb.append('.');
+			for (int i = 1; i <= prec; i++) {
+				int j = dp + i - 1;
+				sb.append(j >= 0 && j < digs.length() ? digs.charAt(j) : '0');
+			}
+		}
+	}
+
+	// formatBinary formats the mantissa and the binary exponent, like 4503599627370496p-52
+	private static String formatBinary(double abs, boolean neg, int bitSize) {
+		int mantBits = bitSize == 32 ? 23 : 52;
+		int expBits = bitSize == 32 ? 8 : 11;
+		int bias = bitSize == 32 ? -127 : -1023;
+		long bits = bitSize == 32 ? Float.floatToRawIntBits((float) abs) & 0xFFFFFFFFL : Double.doubleToRawLongBits(abs);
+		int exp = (int) (bits >>> mantBits) & ((1 << expBits) - 1);
+		long mant = bits & ((1L << mantBits) - 1);
+		if (exp == 0) {
+			exp++;
+		} else {
+			mant |= 1L << mantBits;
+		}
+		exp += bias - mantBits;
+		return (neg ? "-" : "") + mant + "p" + (exp >= 0 ? "+" : "") + exp;
+	}
+
+	// formatHex formats like %x, a hexadecimal mantissa and a binary exponent
+	private static String formatHex(double abs, boolean neg, char fmt, int prec, int bitSize) {
+		int mantBits = bitSize == 32 ? 23 : 52;
+		int expBits = bitSize == 32 ? 8 : 11;
+		int bias = bitSize == 32 ? -127 : -1023;
+		long bits = bitSize == 32 ? Float.floatToRawIntBits((float) abs) & 0xFFFFFFFFL : Double.doubleToRawLongBits(abs);
+		int exp = (int) (bits >>> mantBits) & ((1 << expBits) - 1);
+		long mant = bits & ((1L << mantBits) - 1);
+		if (exp == 0) {
+			exp++;
+		} else {
+			mant |= 1L << mantBits;
+		}
+		exp += bias;
+		if (mant == 0) {
+			exp = 0;
+		}
+		// the leading 1 is shifted to bit 60
+		mant <<= 60 - mantBits;
+		while (mant != 0 && (mant & (1L << 60)) == 0) {
+			mant <<= 1;
+			exp--;
+		}
+		if (prec >= 0 && prec < 15) {
+			int shift = prec * 4;
+			long extra = (mant << shift) & ((1L << 60) - 1);
+			mant >>>= 60 - shift;
+			if ((extra | (mant & 1)) > 1L << 59) {
+				mant++;
+			}
+			mant <<= 60 - shift;
+			if ((mant & (1L << 61)) != 0) {
+				mant >>>= 1;
+				exp++;
+			}
+		}
+		String hex = fmt == 'X' ? UDIGITS : LDIGITS;
+		StringBuilder sb = new StringBuilder();
+		if (neg) {
+			sb.append('-');
+		}
+		sb.append('0').append(fmt).append((char) ('0' + ((mant >>> 60) & 1)));
+		mant <<= 4;
+		if (prec < 0 && mant != 0) {
+			sb.append('.');
+			while (mant != 0) {
+				sb.append(hex.charAt((int) ((mant >>> 60) & 15)));
+				mant <<= 4;
+			}
+		} else if (prec > 0) {
+			sb.append('.');
+			for (int i = 0; i < prec; i++) {
+				sb.append(hex.charAt((int) ((mant >>> 60) & 15)));
+				mant <<= 4;
+			}
+		}
+		sb.append(fmt == 'X' ? 'P' : 'p');
+		sb.append(exp < 0 ? '-' : '+');
+		exp = Math.abs(exp);
+		if (exp < 10) {
+			sb.append('0');
+		}
+		return sb.append(exp).toString();
+	}
+
+	// quote quotes like strconv.Quote, ascii escapes the runes outside of ASCII like strconv.QuoteToASCII
+	static String quote(String s, char q, boolean ascii) {
+		StringBuilder sb = new StringBuilder().append(q);
+		byte[] b = GoString.bytesOf(s);
+		for (int i = 0; i < b.length;) {
+			long decoded = GoString.decodeRune(b, i, b.length);
+			int r = (int) decoded;
+			int size = (int) (decoded >>> 32);
+			if (size == 1 && r == GoString.RUNE_ERROR) {
+				sb.append("\\x").append(LDIGITS.charAt((b[i] >> 4) & 0xF)).append(LDIGITS.charAt(b[i] & 0xF));
+				i++;
+				continue;
+			}
+			appendEscapedRune(sb, r, q, ascii);
+			i += size;
+		}
+		return sb.append(q).toString();
+	}
+
+	private static void appendEscapedRune(StringBuilder sb, int r, char q, boolean ascii) {
+		if (r == q || r == '\\') {
+			sb.append('\\').append((char) r);
+			return;
+		}
+		if (ascii ? r < 0x80 && isPrint(r) : isPrint(r)) {
+			sb.appendCodePoint(r);
+			return;
+		}
+		switch (r) {
+		case 0x07:
+			sb.append("\\a");
+			break;
+		case '\b':
+			sb.append("\\b");
+			break;
+		case '\f':
+			sb.append("\\f");
+			break;
+		case '\n':
+			sb.append("\\n");
+			break;
+		case '\r':
+			sb.append("\\r");
+			break;
+		case '\t':
+			sb.append("\\t");
+			break;
+		case 0x0B:
+			sb.append("\\v");
+			break;
+		default:
+			if (r < ' ' || r == 0x7F) {
+				sb.append("\\x").append(LDIGITS.charAt(r >> 4)).append(LDIGITS.charAt(r & 0xF));
+			} else if (r < 0x10000) {
+				sb.append("\\u").append(hexDigits(r, 4));
+			} else {
+				sb.append("\\U").append(hexDigits(r, 8));
+			}
+		}
+	}
+
+	private static String hexDigits(int r, int n) {
+		String hex = Integer.toHexString(r);
+		StringBuilder sb = new StringBuilder();
+		for (int i = hex.length(); i < n; i++) {
+			sb.append('0');
+		}
+		return sb.append(hex).toString();
+	}
+
+	// isPrint reports the letters, marks, numbers, punctuation, symbols and the ASCII space like strconv.IsPrint
+	static boolean isPrint(int r) {
+		if (r < 0x80) {
+			return r >= 0x20 && r < 0x7F;
+		}
+		switch (Character.getType(r)) {
+		case Character.UNASSIGNED:
+		case Character.CONTROL:
+		case Character.FORMAT:
+		case Character.PRIVATE_USE:
+		case Character.SURROGATE:
+		case Character.SPACE_SEPARATOR:
+		case Character.LINE_SEPARATOR:
+		case Character.PARAGRAPH_SEPARATOR:
+			return false;
+		default:
+			return true;
+		}
+	}
+
+	static boolean canBackquote(String s) {
+		for (int i = 0; i < s.length(); i++) {
+			char c = s.charAt(i);
+			if ((c < ' ' && c != '\t') || c == BACKQUOTE || c == 0x7F || c == 0xFEFF || (c >= 0xDC80 && c <= 0xDCFF)) {
+				return false;
+			}
+		}
+		return true;
+	}
+
+}
+`
+
+// GoType keeps the go type of a field when java loses it, pointers and unsigned integers
+var orgGo2jGoType = `package org.go2j.util;
+
+import java.lang.annotation.ElementType;
+import java.lang.annotation.Retention;
+import java.lang.annotation.RetentionPolicy;
+import java.lang.annotation.Target;
+
+@Retention(RetentionPolicy.RUNTIME)
+@Target(ElementType.FIELD)
+public @interface GoType {
+	String value();
+}
+`
+
+func init() {
+	helperClasses["org/go2j/util/Fmt.java"] = orgGo2jFmt
+	helperClasses["org/go2j/util/GoType.java"] = orgGo2jGoType
+}
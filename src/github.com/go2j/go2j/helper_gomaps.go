@@ -0,0 +1,90 @@
+package main
+
+// GoMaps implements the maps package. Keys and Values return slices like golang.org/x/exp/maps,
+// in the order of the java map. keys and values of primitive types are unboxed to the arrays of
+// the primitive slice classes
+var orgGo2jGoMaps = `package org.go2j.util;
+
+import java.lang.reflect.Array;
+import java.util.Collection;
+import java.util.HashMap;
+import java.util.Map;
+import java.util.function.BiPredicate;
+
+public final class GoMaps {
+
+	private GoMaps() {
+	}
+
+	public static <K> Slice<K> Keys(Map<K, ?> m) {
+		return slice(m == null ? null : m.keySet());
+	}
+
+	public static <V> Slice<V> Values(Map<?, V> m) {
+		return slice(m == null ? null : m.values());
+	}
+
+	public static Object keyArray(Map<?, ?> m, Class<?> type) {
+		return array(m == null ? null : m.keySet(), type);
+	}
+
+	public static Object valueArray(Map<?, ?> m, Class<?> type) {
+		return array(m == null ? null : m.values(), type);
+	}
+
+	public static <K, V> Map<K, V> Clone(Map<K, V> m) {
+		return m == null ? null : new HashMap<K, V>(m);
+	}
+
+	public static <K, V> void Copy(Map<K, V> dst, Map<? extends K, ? extends V> src) {
+		if (src == null) {
+			return;
+		}
+		for (Map.Entry<? extends K, ? extends V> e : src.entrySet()) {
+			GoMap.put(dst, e.getKey(), e.getValue());
+		}
+	}
+
+	public static <K, V> void DeleteFunc(Map<K, V> m, BiPredicate<? super K, ? super V> del) {
+		if (m != null) {
+			m.entrySet().removeIf(e -> del.test(e.getKey(), e.getValue()));
+		}
+	}
+
+	public static boolean Equal(Map<?, ?> m1, Map<?, ?> m2) {
+		if (GoMap.len(m1) != GoMap.len(m2)) {
+			return false;
+		}
+		if (m1 == null) {
+			return true;
+		}
+		for (Map.Entry<?, ?> e : m1.entrySet()) {
+			if (!m2.containsKey(e.getKey()) || !GoObjects.equals(e.getValue(), m2.get(e.getKey()))) {
+				return false;
+			}
+		}
+		return true;
+	}
+
+	private static <T> Slice<T> slice(Collection<T> c) {
+		Object[] array = c == null ? new Object[0] : c.toArray();
+		return new Slice<T>(array, 0, array.length, array.length);
+	}
+
+	private static Object array(Collection<?> c, Class<?> type) {
+		Object array = Array.newInstance(type, c == null ? 0 : c.size());
+		if (c != null) {
+			int i = 0;
+			for (Object value : c) {
+				Array.set(array, i++, value);
+			}
+		}
+		return array;
+	}
+
+}
+`
+
+func init() {
+	helperClasses["org/go2j/util/GoMaps.java"] = orgGo2jGoMaps
+}
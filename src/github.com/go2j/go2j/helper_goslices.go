@@ -0,0 +1,319 @@
+package main
+
+import "strings"
+
+// GoSlices implements the slices package and cmp.Compare. the sorts are stable, strings are ordered
+// by their bytes and NaN is less than the other floats like in go. byte slices are ordered as
+// unsigned []byte, the other integer slices as signed integers
+var orgGo2jGoSlices = `package org.go2j.util;
+
+import java.lang.reflect.Array;
+import java.util.Arrays;
+import java.util.Comparator;
+import java.util.function.Predicate;
+
+public final class GoSlices {
+
+	private GoSlices() {
+	}
+
+	@SuppressWarnings("unchecked")
+	public static int Compare(Object a, Object b) {
+		if (a instanceof String) {
+			return Integer.signum(GoString.compare((String) a, (String) b));
+		}
+		if (a instanceof Byte && b instanceof Byte) {
+			return Integer.compare((Byte) a & 0xff, (Byte) b & 0xff);
+		}
+		if (a instanceof Number && b instanceof Number) {
+			if (a instanceof Double || a instanceof Float || b instanceof Double || b instanceof Float) {
+				return compare(((Number) a).doubleValue(), ((Number) b).doubleValue());
+			}
+			return Long.compare(((Number) a).longValue(), ((Number) b).longValue());
+		}
+		return Integer.signum(((Comparable<Object>) a).compareTo(b));
+	}
+
+	public static boolean Less(Object a, Object b) {
+		return Compare(a, b) < 0;
+	}
+
+	private static int compare(double x, double y) {
+		if (Double.isNaN(x)) {
+			return Double.isNaN(y) ? 0 : -1;
+		}
+		if (Double.isNaN(y)) {
+			return 1;
+		}
+		return x < y ? -1 : x > y ? 1 : 0;
+	}
+
+	public static <T> void Sort(Slice<T> s) {
+		if (s != null) {
+			Arrays.sort(s.array, s.offset, s.offset + s.len, GoSlices::Compare);
+		}
+	}
+
+	@SuppressWarnings("unchecked")
+	public static <T> void SortFunc(Slice<T> s, Comparator<? super T> cmp) {
+		if (s != null) {
+			Arrays.sort((T[]) s.array, s.offset, s.offset + s.len, cmp);
+		}
+	}
+
+	public static <T> void SortStableFunc(Slice<T> s, Comparator<? super T> cmp) {
+		SortFunc(s, cmp);
+	}
+
+	public static <T> boolean IsSorted(Slice<T> s) {
+		for (int i = Slice.len(s) - 1; i > 0; i--) {
+			if (Compare(s.get(i), s.get(i - 1)) < 0) {
+				return false;
+			}
+		}
+		return true;
+	}
+
+	public static <T> boolean IsSortedFunc(Slice<T> s, Comparator<? super T> cmp) {
+		for (int i = Slice.len(s) - 1; i > 0; i--) {
+			if (cmp.compare(s.get(i), s.get(i - 1)) < 0) {
+				return false;
+			}
+		}
+		return true;
+	}
+
+	public static <T> Slice<T> Sorted(Slice<T> s) {
+		Slice<T> sorted = Collect(s);
+		Sort(sorted);
+		return sorted;
+	}
+
+	public static <T> int Index(Slice<T> s, T v) {
+		for (int i = 0, n = Slice.len(s); i < n; i++) {
+			if (GoObjects.equals(s.get(i), v)) {
+				return i;
+			}
+		}
+		return -1;
+	}
+
+	public static <T> boolean Contains(Slice<T> s, T v) {
+		return Index(s, v) >= 0;
+	}
+
+	public static <T> int IndexFunc(Slice<T> s, Predicate<? super T> f) {
+		for (int i = 0, n = Slice.len(s); i < n; i++) {
+			if (f.test(s.get(i))) {
+				return i;
+			}
+		}
+		return -1;
+	}
+
+	public static <T> boolean ContainsFunc(Slice<T> s, Predicate<? super T> f) {
+		return IndexFunc(s, f) >= 0;
+	}
+
+	public static <T> void Reverse(Slice<T> s) {
+		for (int i = 0, j = Slice.len(s) - 1; i < j; i++, j--) {
+			Object tmp = s.array[s.offset + i];
+			s.array[s.offset + i] = s.array[s.offset + j];
+			s.array[s.offset + j] = tmp;
+		}
+	}
+
+	// Collect copies the slices of maps.Keys and maps.Values
+	public static <T> Slice<T> Collect(Slice<T> s) {
+		if (s == null) {
+			return null;
+		}
+		return new Slice<T>(Arrays.copyOfRange(s.array, s.offset, s.offset + s.len), 0, s.len, s.len);
+	}
+{{primitives}}
+	// unsigned bytes, the negative ones follow the others
+	private static void sortRange(byte[] a, int from, int to) {
+		Arrays.sort(a, from, to);
+		int k = from;
+		while (k < to && a[k] < 0) {
+			k++;
+		}
+		rotate(a, from, k, to);
+	}
+
+	private static void sortRange(short[] a, int from, int to) {
+		Arrays.sort(a, from, to);
+	}
+
+	private static void sortRange(int[] a, int from, int to) {
+		Arrays.sort(a, from, to);
+	}
+
+	private static void sortRange(long[] a, int from, int to) {
+		Arrays.sort(a, from, to);
+	}
+
+	// java sorts NaN last, go first
+	private static void sortRange(float[] a, int from, int to) {
+		Arrays.sort(a, from, to);
+		int k = to;
+		while (k > from && Float.isNaN(a[k - 1])) {
+			k--;
+		}
+		rotate(a, from, k, to);
+	}
+
+	private static void sortRange(double[] a, int from, int to) {
+		Arrays.sort(a, from, to);
+		int k = to;
+		while (k > from && Double.isNaN(a[k - 1])) {
+			k--;
+		}
+		rotate(a, from, k, to);
+	}
+
+	// rotate moves a[m:to] before a[from:m]
+	private static void rotate(Object a, int from, int m, int to) {
+		if (from == m || m == to) {
+			return;
+		}
+		int n = m - from;
+		Object head = Array.newInstance(a.getClass().getComponentType(), n);
+		System.arraycopy(a, from, head, 0, n);
+		System.arraycopy(a, m, a, from, to - m);
+		System.arraycopy(head, 0, a, to - n, n);
+	}
+
+}
+`
+
+// orgGo2jGoSlicesPrimitiveTempl has the functions of the primitive slice classes
+var orgGo2jGoSlicesPrimitiveTempl = `
+	public static int Index({{Slice}} s, {{setType}} v) {
+		for (int i = 0, n = {{Slice}}.len(s); i < n; i++) {
+			if (s.array[s.offset + i] == ({{type}}) v) {
+				return i;
+			}
+		}
+		return -1;
+	}
+
+	public static boolean Contains({{Slice}} s, {{setType}} v) {
+		return Index(s, v) >= 0;
+	}
+
+	public static int IndexFunc({{Slice}} s, Predicate<{{Object}}> f) {
+		for (int i = 0, n = {{Slice}}.len(s); i < n; i++) {
+			if (f.test(s.array[s.offset + i])) {
+				return i;
+			}
+		}
+		return -1;
+	}
+
+	public static boolean ContainsFunc({{Slice}} s, Predicate<{{Object}}> f) {
+		return IndexFunc(s, f) >= 0;
+	}
+
+	public static void Reverse({{Slice}} s) {
+		for (int i = 0, j = {{Slice}}.len(s) - 1; i < j; i++, j--) {
+			{{type}} tmp = s.array[s.offset + i];
+			s.array[s.offset + i] = s.array[s.offset + j];
+			s.array[s.offset + j] = tmp;
+		}
+	}
+
+	public static {{Slice}} Collect({{Slice}} s) {
+		if (s == null) {
+			return null;
+		}
+		return {{Slice}}.wrap(Arrays.copyOfRange(s.array, s.offset, s.offset + s.len));
+	}
+`
+
+// orgGo2jGoSlicesOrderedTempl has the sort functions of the ordered primitive slice classes
+var orgGo2jGoSlicesOrderedTempl = `
+	public static void Sort({{Slice}} s) {
+		if (s != null) {
+			sortRange(s.array, s.offset, s.offset + s.len);
+		}
+	}
+
+	public static void SortFunc({{Slice}} s, Comparator<{{Object}}> cmp) {
+		int n = {{Slice}}.len(s);
+		{{Object}}[] boxed = new {{Object}}[n];
+		for (int i = 0; i < n; i++) {
+			boxed[i] = s.array[s.offset + i];
+		}
+		Arrays.sort(boxed, cmp);
+		for (int i = 0; i < n; i++) {
+			s.array[s.offset + i] = boxed[i];
+		}
+	}
+
+	public static void SortStableFunc({{Slice}} s, Comparator<{{Object}}> cmp) {
+		SortFunc(s, cmp);
+	}
+
+	public static boolean IsSorted({{Slice}} s) {
+		for (int i = {{Slice}}.len(s) - 1; i > 0; i--) {
+			if (Compare(s.array[s.offset + i], s.array[s.offset + i - 1]) < 0) {
+				return false;
+			}
+		}
+		return true;
+	}
+
+	public static boolean IsSortedFunc({{Slice}} s, Comparator<{{Object}}> cmp) {
+		for (int i = {{Slice}}.len(s) - 1; i > 0; i--) {
+			if (cmp.compare(s.array[s.offset + i], s.array[s.offset + i - 1]) < 0) {
+				return false;
+			}
+		}
+		return true;
+	}
+
+	public static {{Slice}} Sorted({{Slice}} s) {
+		{{Slice}} sorted = Collect(s);
+		Sort(sorted);
+		return sorted;
+	}
+`
+
+// goSlicesPrimitives are the java element types of the primitive slice classes in a stable order
+var goSlicesPrimitives = []string{"boolean", "byte", "short", "int", "long", "float", "double"}
+
+var javaBoxedTypes = map[string]string{
+	"boolean": "Boolean",
+	"byte":    "Byte",
+	"short":   "Short",
+	"int":     "Integer",
+	"long":    "Long",
+	"float":   "Float",
+	"double":  "Double",
+}
+
+func goSlicesSource() string {
+	primitives := ""
+	for _, javaType := range goSlicesPrimitives {
+		templ := orgGo2jGoSlicesPrimitiveTempl
+		if javaType != "boolean" {
+			// booleans are not ordered
+			templ += orgGo2jGoSlicesOrderedTempl
+		}
+		setType := javaType
+		if javaType == "byte" || javaType == "short" {
+			// untyped constants are ints
+			setType = "int"
+		}
+		source := strings.Replace(templ, "{{Slice}}", primitiveSlices[javaType], -1)
+		source = strings.Replace(source, "{{setType}}", setType, -1)
+		source = strings.Replace(source, "{{Object}}", javaBoxedTypes[javaType], -1)
+		primitives += strings.Replace(source, "{{type}}", javaType, -1)
+	}
+	return strings.Replace(orgGo2jGoSlices, "{{primitives}}", primitives, 1)
+}
+
+func init() {
+	helperClasses["org/go2j/util/GoSlices.java"] = goSlicesSource()
+}
@@ -0,0 +1,253 @@
+package main
+
+// go strings are byte sequences, the translated code keeps them in java strings.
+// Bytes of invalid UTF-8 sequences are kept as the lone surrogates U+DC80..U+DCFF,
+// so any go string survives the round trip through []byte.
+var orgGo2jGoString = `package org.go2j.util;
+
+import java.util.ArrayList;
+import java.util.Arrays;
+import java.util.Collections;
+import java.util.List;
+import java.util.Map;
+import java.util.WeakHashMap;
+
+public class GoString {
+
+	public static final int RUNE_ERROR = 0xFFFD;
+
+	private static final Map<String, byte[]> cache = Collections.synchronizedMap(new WeakHashMap<String, byte[]>());
+
+	public static class IndexedRune {
+		public final int index;
+		public final int rune;
+
+		IndexedRune(int index, int rune) {
+			this.index = index;
+			this.rune = rune;
+		}
+	}
+
+	// bytesOf returns the cached UTF-8 bytes of s, the array must not be modified
+	static byte[] bytesOf(String s) {
+		byte[] b = cache.get(s);
+		if (b == null) {
+			b = encode(s);
+			cache.put(s, b);
+		}
+		return b;
+	}
+
+	static byte[] encode(String s) {
+		byte[] b = new byte[s.length() * 3];
+		int n = 0;
+		for (int i = 0; i < s.length(); i++) {
+			char c = s.charAt(i);
+			if (c < 0x80) {
+				b[n++] = (byte) c;
+			} else if (c >= 0xDC80 && c <= 0xDCFF) {
+				// raw byte of an invalid sequence
+				b[n++] = (byte) (c - 0xDC00);
+			} else if (Character.isHighSurrogate(c) && i + 1 < s.length() && Character.isLowSurrogate(s.charAt(i + 1))) {
+				n = encodeRune(b, n, Character.toCodePoint(c, s.charAt(i + 1)));
+				i++;
+			} else if (Character.isSurrogate(c)) {
+				n = encodeRune(b, n, RUNE_ERROR);
+			} else {
+				n = encodeRune(b, n, c);
+			}
+		}
+		return Arrays.copyOf(b, n);
+	}
+
+	static int encodeRune(byte[] b, int n, int r) {
+		if (r < 0 || r > 0x10FFFF || (r >= 0xD800 && r <= 0xDFFF)) {
+			r = RUNE_ERROR;
+		}
+		if (r < 0x80) {
+			b[n++] = (byte) r;
+		} else if (r < 0x800) {
+			b[n++] = (byte) (0xC0 | (r >> 6));
+			b[n++] = (byte) (0x80 | (r & 0x3F));
+		} else if (r < 0x10000) {
+			b[n++] = (byte) (0xE0 | (r >> 12));
+			b[n++] = (byte) (0x80 | ((r >> 6) & 0x3F));
+			b[n++] = (byte) (0x80 | (r & 0x3F));
+		} else {
+			b[n++] = (byte) (0xF0 | (r >> 18));
+			b[n++] = (byte) (0x80 | ((r >> 12) & 0x3F));
+			b[n++] = (byte) (0x80 | ((r >> 6) & 0x3F));
+			b[n++] = (byte) (0x80 | (r & 0x3F));
+		}
+		return n;
+	}
+
+	// decodeRune decodes the rune starting at b[i] like utf8.DecodeRune,
+	// the rune is returned in the low, its size in the high 32 bits
+	static long decodeRune(byte[] b, int i, int end) {
+		int b0 = b[i] & 0xFF;
+		if (b0 < 0x80) {
+			return pack(b0, 1);
+		}
+		int size;
+		int min;
+		int r;
+		if (b0 < 0xC2) {
+			return pack(RUNE_ERROR, 1);
+		} else if (b0 < 0xE0) {
+			size = 2;
+			min = 0x80;
+			r = b0 & 0x1F;
+		} else if (b0 < 0xF0) {
+			size = 3;
+			min = 0x800;
+			r = b0 & 0x0F;
+		} else if (b0 < 0xF5) {
+			size = 4;
+			min = 0x10000;
+			r = b0 & 0x07;
+		} else {
+			return pack(RUNE_ERROR, 1);
+		}
+		if (i + size > end) {
+			return pack(RUNE_ERROR, 1);
+		}
+		for (int k = 1; k < size; k++) {
+			int bk = b[i + k] & 0xFF;
+			if ((bk & 0xC0) != 0x80) {
+				return pack(RUNE_ERROR, 1);
+			}
+			r = (r << 6) | (bk & 0x3F);
+		}
+		if (r < min || r > 0x10FFFF || (r >= 0xD800 && r <= 0xDFFF)) {
+			return pack(RUNE_ERROR, 1);
+		}
+		return pack(r, size);
+	}
+
+	private static long pack(int r, int size) {
+		return ((long) size << 32) | r;
+	}
+
+	static String decode(byte[] b, int from, int to) {
+		StringBuilder sb = new StringBuilder(to - from);
+		for (int i = from; i < to;) {
+			long decoded = decodeRune(b, i, to);
+			int r = (int) decoded;
+			int size = (int) (decoded >> 32);
+			if (r == RUNE_ERROR && size == 1 && (b[i] & 0xFF) >= 0x80) {
+				sb.append((char) (0xDC00 + (b[i] & 0xFF)));
+			} else {
+				sb.appendCodePoint(r);
+			}
+			i += size;
+		}
+		return sb.toString();
+	}
+
+	public static int len(String s) {
+		return bytesOf(s).length;
+	}
+
+	public static byte at(String s, int i) {
+		return bytesOf(s)[i];
+	}
+
+	public static String slice(String s, int low) {
+		return slice(s, low, len(s));
+	}
+
+	public static String slice(String s, int low, int high) {
+		byte[] b = bytesOf(s);
+		if (low < 0 || high < low || high > b.length) {
+			throw new IndexOutOfBoundsException("slice bounds out of range [" + low + ":" + high + "] with length " + b.length);
+		}
+		if (low == 0 && high == b.length) {
+			return s;
+		}
+		return decode(b, low, high);
+	}
+
+	public static List<IndexedRune> range(String s) {
+		byte[] b = bytesOf(s);
+		List<IndexedRune> runes = new ArrayList<IndexedRune>();
+		for (int i = 0; i < b.length;) {
+			long decoded = decodeRune(b, i, b.length);
+			runes.add(new IndexedRune(i, (int) decoded));
+			i += (int) (decoded >> 32);
+		}
+		return runes;
+	}
+
+	public static ByteSlice bytes(String s) {
+		return ByteSlice.wrap(bytesOf(s).clone());
+	}
+
+	public static IntSlice runes(String s) {
+		List<IndexedRune> runes = range(s);
+		int[] r = new int[runes.size()];
+		for (int i = 0; i < r.length; i++) {
+			r[i] = runes.get(i).rune;
+		}
+		return IntSlice.wrap(r);
+	}
+
+	public static String fromBytes(ByteSlice b) {
+		if (b == null) {
+			return "";
+		}
+		return decode(b.array, b.offset, b.offset + b.len);
+	}
+
+	public static String fromRunes(IntSlice runes) {
+		int n = IntSlice.len(runes);
+		byte[] b = new byte[n * 4];
+		int size = 0;
+		for (int i = 0; i < n; i++) {
+			size = encodeRune(b, size, runes.get(i));
+		}
+		return decode(b, 0, size);
+	}
+
+	// compare orders strings by their bytes like go
+	public static int compare(String a, String b) {
+		byte[] x = bytesOf(a);
+		byte[] y = bytesOf(b);
+		for (int i = 0; i < x.length && i < y.length; i++) {
+			if (x[i] != y[i]) {
+				return (x[i] & 0xFF) - (y[i] & 0xFF);
+			}
+		}
+		return x.length - y.length;
+	}
+
+	public static String min(String first, String... more) {
+		String min = first;
+		for (String s : more) {
+			if (compare(s, min) < 0) {
+				min = s;
+			}
+		}
+		return min;
+	}
+
+	public static String max(String first, String... more) {
+		String max = first;
+		for (String s : more) {
+			if (compare(s, max) > 0) {
+				max = s;
+			}
+		}
+		return max;
+	}
+
+	public static String fromRune(long r) {
+		if (r < 0 || r > 0x10FFFF) {
+			r = RUNE_ERROR;
+		}
+		byte[] b = new byte[4];
+		return decode(b, 0, encodeRune(b, 0, (int) r));
+	}
+
+}
+`
@@ -0,0 +1,129 @@
+package main
+
+// go maps are java maps, GoMap adds the semantics of nil maps and missing keys
+var orgGo2jGoMap = `package org.go2j.util;
+
+import java.util.ArrayList;
+import java.util.Arrays;
+import java.util.HashMap;
+import java.util.List;
+import java.util.Map;
+
+public final class GoMap {
+
+	private GoMap() {
+	}
+
+	// get returns the zero value for missing keys and nil maps
+	public static <K, V> V get(Map<K, V> m, Object key, V zero) {
+		if (m == null) {
+			return zero;
+		}
+		V value = m.get(key);
+		if (value == null && !m.containsKey(key)) {
+			return zero;
+		}
+		return value;
+	}
+
+	public static boolean has(Map<?, ?> m, Object key) {
+		return m != null && m.containsKey(key);
+	}
+
+	public static <K, V> void put(Map<K, V> m, K key, V value) {
+		if (m == null) {
+			throw new NullPointerException("assignment to entry in nil map");
+		}
+		m.put(key, value);
+	}
+
+	public static void delete(Map<?, ?> m, Object key) {
+		if (m != null) {
+			m.remove(key);
+		}
+	}
+
+	public static void clear(Map<?, ?> m) {
+		if (m != null) {
+			m.clear();
+		}
+	}
+
+	public static int len(Map<?, ?> m) {
+		return m == null ? 0 : m.size();
+	}
+
+	public static <K, V> Range<K, V> range(Map<K, V> m) {
+		return new Range<K, V>(m);
+	}
+
+	// Range iterates the keys present at the start, entries deleted during the iteration are skipped like in go
+	public static final class Range<K, V> {
+		private final Map<K, V> m;
+		private final List<K> keys;
+		private int i;
+		public K key;
+		public V value;
+
+		Range(Map<K, V> m) {
+			this.m = m;
+			this.keys = m == null ? new ArrayList<K>() : new ArrayList<K>(m.keySet());
+		}
+
+		public boolean next() {
+			while (i < keys.size()) {
+				K k = keys.get(i++);
+				if (m.containsKey(k)) {
+					key = k;
+					value = m.get(k);
+					return true;
+				}
+			}
+			return false;
+		}
+	}
+
+	// key wraps an array key, the map keeps a copy like go
+	public static <A> Key<A> key(A array) {
+		return new Key<A>(GoObjects.copy(array));
+	}
+
+	// Key compares array keys by their elements like go arrays
+	public static final class Key<A> {
+		public final A array;
+
+		Key(A array) {
+			this.array = array;
+		}
+
+		@Override
+		public boolean equals(Object o) {
+			return o instanceof Key && Arrays.deepEquals(new Object[] { array }, new Object[] { ((Key<?>) o).array });
+		}
+
+		@Override
+		public int hashCode() {
+			return Arrays.deepHashCode(new Object[] { array });
+		}
+	}
+
+	// Builder creates the maps of map literals
+	public static final class Builder<K, V> {
+		private final Map<K, V> m = new HashMap<K, V>();
+
+		public Builder<K, V> put(K key, V value) {
+			m.put(key, value);
+			return this;
+		}
+
+		public Map<K, V> build() {
+			return m;
+		}
+	}
+
+}
+`
+
+func init() {
+	helperClasses["org/go2j/util/GoMap.java"] = orgGo2jGoMap
+}
@@ -0,0 +1,137 @@
+package main
+
+// GoMath has the functions of the math package whose special cases differ from java.lang.Math,
+// the others are converted to Math and Double directly
+var orgGo2jGoMath = `package org.go2j.util;
+
+public final class GoMath {
+
+	public static final double Phi = 1.618033988749895;
+	public static final double Sqrt2 = 1.4142135623730951;
+	public static final double SqrtE = 1.6487212707001282;
+	public static final double SqrtPi = 1.772453850905516;
+	public static final double SqrtPhi = 1.272019649514069;
+	public static final double Ln2 = 0.6931471805599453;
+	public static final double Log2E = 1.4426950408889634;
+	public static final double Ln10 = 2.302585092994046;
+	public static final double Log10E = 0.4342944819032518;
+
+	private GoMath() {
+	}
+
+	public static double Inf(int sign) {
+		return sign >= 0 ? Double.POSITIVE_INFINITY : Double.NEGATIVE_INFINITY;
+	}
+
+	public static double NaN() {
+		return Double.NaN;
+	}
+
+	public static boolean IsInf(double f, int sign) {
+		return sign >= 0 && f == Double.POSITIVE_INFINITY || sign <= 0 && f == Double.NEGATIVE_INFINITY;
+	}
+
+	public static boolean Signbit(double x) {
+		return Double.doubleToRawLongBits(x) < 0;
+	}
+
+	// Mod is the remainder of the truncated division like % of java, the arguments are doubles
+	// even for untyped integer constants
+	public static double Mod(double x, double y) {
+		return x % y;
+	}
+
+	public static double Dim(double x, double y) {
+		double v = x - y;
+		return v <= 0 ? 0 : v;
+	}
+
+	public static double Trunc(double x) {
+		return x < 0 ? Math.ceil(x) : Math.floor(x);
+	}
+
+	// Round rounds half away from zero, Math.round rounds half up
+	public static double Round(double x) {
+		if (Double.isNaN(x) || Double.isInfinite(x)) {
+			return x;
+		}
+		double t = Trunc(x);
+		if (Math.abs(x - t) >= 0.5) {
+			t += Math.copySign(1, x);
+		}
+		return t;
+	}
+
+	// Max and Min return an infinity before NaN
+	public static double Max(double x, double y) {
+		if (x == Double.POSITIVE_INFINITY || y == Double.POSITIVE_INFINITY) {
+			return Double.POSITIVE_INFINITY;
+		}
+		return Math.max(x, y);
+	}
+
+	public static double Min(double x, double y) {
+		if (x == Double.NEGATIVE_INFINITY || y == Double.NEGATIVE_INFINITY) {
+			return Double.NEGATIVE_INFINITY;
+		}
+		return Math.min(x, y);
+	}
+
+	// Pow(1, y) and Pow(-1, ±Inf) are 1 in go, NaN in java
+	public static double Pow(double x, double y) {
+		if (x == 1 || x == -1 && Double.isInfinite(y)) {
+			return 1;
+		}
+		return Math.pow(x, y);
+	}
+
+	public static double Pow10(int n) {
+		if (n < -323) {
+			return 0;
+		}
+		if (n > 308) {
+			return Double.POSITIVE_INFINITY;
+		}
+		return Double.parseDouble("1e" + n);
+	}
+
+	// Log2 is exact for the powers of 2
+	public static double Log2(double x) {
+		Tuple2<Double, Integer> fe = Frexp(x);
+		if (fe.r0 == 0.5) {
+			return fe.r1 - 1;
+		}
+		return Math.log(fe.r0) * Log2E + fe.r1;
+	}
+
+	public static Tuple2<Double, Integer> Frexp(double f) {
+		if (f == 0 || Double.isNaN(f) || Double.isInfinite(f)) {
+			return new Tuple2<>(f, 0);
+		}
+		int exp = 0;
+		if (Math.abs(f) < Double.MIN_NORMAL) {
+			f *= 0x1p52;
+			exp = -52;
+		}
+		long x = Double.doubleToRawLongBits(f);
+		exp += (int) ((x >>> 52) & 0x7ff) - 1022;
+		x &= ~(0x7ffL << 52);
+		x |= 1022L << 52;
+		return new Tuple2<>(Double.longBitsToDouble(x), exp);
+	}
+
+	// Modf returns the integer and the fractional part, both have the sign of f
+	public static Tuple2<Double, Double> Modf(double f) {
+		if (Double.isInfinite(f)) {
+			return new Tuple2<>(f, Double.NaN);
+		}
+		double i = Trunc(f);
+		return new Tuple2<>(i, Math.copySign(f - i, f));
+	}
+
+}
+`
+
+func init() {
+	helperClasses["org/go2j/util/GoMath.java"] = orgGo2jGoMath
+}
@@ -0,0 +1,33 @@
+package main
+
+// GoNumbers converts between unsigned 64 bit integers and floating point numbers, java has no unsigned long
+var orgGo2jGoNumbers = `package org.go2j.util;
+
+public final class GoNumbers {
+
+	private static final double TWO_TO_63 = 0x1p63;
+
+	private GoNumbers() {
+	}
+
+	public static double unsignedToDouble(long x) {
+		if (x >= 0) {
+			return (double) x;
+		}
+		// halve keeping the lowest bit for the rounding
+		return (double) ((x >>> 1) | (x & 1)) * 2.0;
+	}
+
+	public static long doubleToUnsigned(double d) {
+		if (d < TWO_TO_63) {
+			return (long) d;
+		}
+		return (long) (d - TWO_TO_63) + Long.MIN_VALUE;
+	}
+
+}
+`
+
+func init() {
+	helperClasses["org/go2j/util/GoNumbers.java"] = orgGo2jGoNumbers
+}
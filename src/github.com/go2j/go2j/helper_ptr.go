@@ -0,0 +1,27 @@
+package main
+
+// pointers to values java copies are GoPtr cells, see pointers.go
+var orgGo2jGoPtr = `package org.go2j.util;
+
+public final class GoPtr<T> {
+
+	private T value;
+
+	public GoPtr(T value) {
+		this.value = value;
+	}
+
+	public T get() {
+		return value;
+	}
+
+	public void set(T value) {
+		this.value = value;
+	}
+
+}
+`
+
+func init() {
+	helperClasses["org/go2j/util/GoPtr.java"] = orgGo2jGoPtr
+}
@@ -0,0 +1,154 @@
+package main
+
+// GoRand is the Rand of the math/rand package on a java.util.Random source. the functions of
+// go are computed from Int63 like in go, the sequences differ from go as the sources differ
+var orgGo2jGoRand = `package org.go2j.util;
+
+import java.util.Random;
+import java.util.function.BiConsumer;
+
+public final class GoRand {
+
+	// global is the source of the top level functions of math/rand
+	public static final GoRand global = new GoRand(new Random());
+
+	private final Random src;
+
+	public GoRand(Random src) {
+		this.src = src;
+	}
+
+	public static Random NewSource(long seed) {
+		return new Random(seed);
+	}
+
+	public static GoRand New(Random src) {
+		return new GoRand(src);
+	}
+
+	public void Seed(long seed) {
+		src.setSeed(seed);
+	}
+
+	public long Int63() {
+		return src.nextLong() >>> 1;
+	}
+
+	public int Uint32() {
+		return (int) (Int63() >>> 31);
+	}
+
+	public long Uint64() {
+		return src.nextLong();
+	}
+
+	public int Int31() {
+		return (int) (Int63() >>> 32);
+	}
+
+	public int Int() {
+		return (int) Int63() & Integer.MAX_VALUE;
+	}
+
+	public long Int63n(long n) {
+		if (n <= 0) {
+			throw new IllegalArgumentException("invalid argument to Int63n");
+		}
+		if ((n & (n - 1)) == 0) {
+			return Int63() & (n - 1);
+		}
+		long max = Long.MAX_VALUE - Long.remainderUnsigned(Long.MIN_VALUE, n);
+		long v = Int63();
+		while (v > max) {
+			v = Int63();
+		}
+		return v % n;
+	}
+
+	public int Int31n(int n) {
+		if (n <= 0) {
+			throw new IllegalArgumentException("invalid argument to Int31n");
+		}
+		return int31n(n);
+	}
+
+	public int Intn(int n) {
+		if (n <= 0) {
+			throw new IllegalArgumentException("invalid argument to Intn");
+		}
+		return int31n(n);
+	}
+
+	private int int31n(int n) {
+		if ((n & (n - 1)) == 0) {
+			return Int31() & (n - 1);
+		}
+		int max = (int) ((1L << 31) - 1 - (1L << 31) % n);
+		int v = Int31();
+		while (v > max) {
+			v = Int31();
+		}
+		return v % n;
+	}
+
+	public double Float64() {
+		while (true) {
+			double f = (double) Int63() / 0x1p63;
+			if (f != 1) {
+				return f;
+			}
+		}
+	}
+
+	public float Float32() {
+		while (true) {
+			float f = (float) Float64();
+			if (f != 1) {
+				return f;
+			}
+		}
+	}
+
+	public double NormFloat64() {
+		return src.nextGaussian();
+	}
+
+	public IntSlice Perm(int n) {
+		int[] m = new int[n];
+		for (int i = 0; i < n; i++) {
+			int j = Intn(i + 1);
+			m[i] = m[j];
+			m[j] = i;
+		}
+		return IntSlice.wrap(m);
+	}
+
+	public void Shuffle(int n, BiConsumer<Integer, Integer> swap) {
+		if (n < 0) {
+			throw new IllegalArgumentException("invalid argument to Shuffle");
+		}
+		for (int i = n - 1; i > 0; i--) {
+			swap.accept(i, uniform(i + 1));
+		}
+	}
+
+	// uniform is the faster int31n of Shuffle, without the bias of the modulo
+	private int uniform(int n) {
+		long prod = (Uint32() & 0xffffffffL) * n;
+		long low = prod & 0xffffffffL;
+		if (low < n) {
+			long thresh = ((1L << 32) - n) % n;
+			while (low < thresh) {
+				prod = (Uint32() & 0xffffffffL) * n;
+				low = prod & 0xffffffffL;
+			}
+		}
+		return (int) (prod >>> 32);
+	}
+
+}
+`
+
+func init() {
+	helperClasses["org/go2j/util/GoRand.java"] = orgGo2jGoRand
+}
@@ -0,0 +1,445 @@
+package main
+
+import (
+	"strings"
+)
+
+// go slices are views of a backing array with offset, length and capacity.
+// Slice holds objects, the primitive element types have their own classes
+// generated from orgGo2jPrimitiveSliceTempl.
+
+var orgGo2jPrimitiveSliceTempl = `package org.go2j.util;
+
+public final class {{Slice}} {
+
+	final {{type}}[] array;
+	final int offset;
+	final int len;
+	final int cap;
+
+	{{Slice}}({{type}}[] array, int offset, int len, int cap) {
+		this.array = array;
+		this.offset = offset;
+		this.len = len;
+		this.cap = cap;
+	}
+
+	public static {{Slice}} make(int len) {
+		return make(len, len);
+	}
+
+	public static {{Slice}} make(int len, int cap) {
+		if (len < 0) {
+			throw new IllegalArgumentException("makeslice: len out of range");
+		}
+		if (cap < len) {
+			throw new IllegalArgumentException("makeslice: cap out of range");
+		}
+		return new {{Slice}}(new {{type}}[cap], 0, len, cap);
+	}
+
+	public static {{Slice}} of({{type}}... values) {
+		return new {{Slice}}(values, 0, values.length, values.length);
+	}
+
+	// wrap slices a java array, the slice shares the array
+	public static {{Slice}} wrap({{type}}[] array) {
+		return new {{Slice}}(array, 0, array.length, array.length);
+	}
+
+	public static int len({{Slice}} s) {
+		return s == null ? 0 : s.len;
+	}
+
+	public static int cap({{Slice}} s) {
+		return s == null ? 0 : s.cap;
+	}
+
+	public {{type}} get(int i) {
+		if (i < 0 || i >= len) {
+			throw new IndexOutOfBoundsException("index out of range [" + i + "] with length " + len);
+		}
+		return array[offset + i];
+	}
+
+	public void set(int i, {{setType}} value) {
+		if (i < 0 || i >= len) {
+			throw new IndexOutOfBoundsException("index out of range [" + i + "] with length " + len);
+		}
+		array[offset + i] = ({{type}}) value;
+	}
+
+	public {{Slice}} slice(int low) {
+		return slice(low, len, cap);
+	}
+
+	public {{Slice}} slice(int low, int high) {
+		return slice(low, high, cap);
+	}
+
+	public {{Slice}} slice(int low, int high, int max) {
+		if (low < 0 || high < low || max < high || max > cap) {
+			throw new IndexOutOfBoundsException("slice bounds out of range [" + low + ":" + high + ":" + max + "] with capacity " + cap);
+		}
+		return new {{Slice}}(array, offset + low, high - low, max - low);
+	}
+
+	public static {{Slice}} append({{Slice}} s, {{type}}... values) {
+		return append(s, values, 0, values.length);
+	}
+
+	public static {{Slice}} appendSlice({{Slice}} s, {{Slice}} other) {
+		if (other == null) {
+			return s;
+		}
+		return append(s, other.array, other.offset, other.len);
+	}
+
+	static {{Slice}} append({{Slice}} s, {{type}}[] values, int from, int n) {
+		if (s == null) {
+			if (n == 0) {
+				return null;
+			}
+			s = new {{Slice}}(new {{type}}[0], 0, 0, 0);
+		}
+		int newLen = s.len + n;
+		if (newLen <= s.cap) {
+			// the appended values are visible through every slice of the backing array
+			System.arraycopy(values, from, s.array, s.offset + s.len, n);
+			return new {{Slice}}(s.array, s.offset, newLen, s.cap);
+		}
+		int newCap = Slice.grow(s.cap, newLen);
+		{{type}}[] array = new {{type}}[newCap];
+		System.arraycopy(s.array, s.offset, array, 0, s.len);
+		System.arraycopy(values, from, array, s.len, n);
+		return new {{Slice}}(array, 0, newLen, newCap);
+	}
+
+	public static int copy({{Slice}} dst, {{Slice}} src) {
+		int n = Math.min(len(dst), len(src));
+		if (n > 0) {
+			System.arraycopy(src.array, src.offset, dst.array, dst.offset, n);
+		}
+		return n;
+	}
+
+	public static void clear({{Slice}} s) {
+		if (s != null) {
+			System.arraycopy(new {{type}}[s.len], 0, s.array, s.offset, s.len);
+		}
+	}
+{{extra}}
+	public {{type}}[] toArray() {
+		{{type}}[] values = new {{type}}[len];
+		System.arraycopy(array, offset, values, 0, len);
+		return values;
+	}
+
+	// spread passes a slice to varargs, the array is shared when the slice covers all of it
+	public static {{type}}[] spread({{Slice}} s) {
+		if (s == null) {
+			return new {{type}}[0];
+		}
+		if (s.offset == 0 && s.len == s.array.length) {
+			return s.array;
+		}
+		return s.toArray();
+	}
+
+	public static Range range({{Slice}} s) {
+		return new Range(s);
+	}
+
+	// Range iterates a slice, the length is evaluated once like in a go range statement
+	public static final class Range {
+		private final {{Slice}} s;
+		private final int n;
+		public int index = -1;
+		public {{type}} value;
+
+		Range({{Slice}} s) {
+			this.s = s;
+			this.n = len(s);
+		}
+
+		public boolean next() {
+			if (++index >= n) {
+				return false;
+			}
+			value = s.array[s.offset + index];
+			return true;
+		}
+	}
+
+	@Override
+	public String toString() {
+		return Fmt.Sprint(this);
+	}
+
+}
+`
+
+var orgGo2jByteSliceExtra = `
+	public static ByteSlice appendString(ByteSlice s, String str) {
+		byte[] values = GoString.bytesOf(str);
+		return append(s, values, 0, values.length);
+	}
+
+	public static int copyString(ByteSlice dst, String src) {
+		byte[] values = GoString.bytesOf(src);
+		int n = Math.min(len(dst), values.length);
+		System.arraycopy(values, 0, dst.array, dst.offset, n);
+		return n;
+	}
+`
+
+var orgGo2jSlice = `package org.go2j.util;
+
+import java.util.Arrays;
+import java.util.function.Supplier;
+
+public final class Slice<T> {
+
+	final Object[] array;
+	final int offset;
+	final int len;
+	final int cap;
+
+	Slice(Object[] array, int offset, int len, int cap) {
+		this.array = array;
+		this.offset = offset;
+		this.len = len;
+		this.cap = cap;
+	}
+
+	// grow computes the capacity of a grown slice like the go runtime, without rounding to size classes
+	static int grow(int oldCap, int newLen) {
+		int doubleCap = oldCap + oldCap;
+		if (newLen > doubleCap) {
+			return newLen;
+		}
+		if (oldCap < 256) {
+			return doubleCap;
+		}
+		int newCap = oldCap;
+		while (newCap < newLen) {
+			newCap += (newCap + 3 * 256) >> 2;
+		}
+		return newCap;
+	}
+
+	public static <T> Slice<T> make(int len) {
+		return make(len, len);
+	}
+
+	public static <T> Slice<T> make(int len, int cap) {
+		if (len < 0) {
+			throw new IllegalArgumentException("makeslice: len out of range");
+		}
+		if (cap < len) {
+			throw new IllegalArgumentException("makeslice: cap out of range");
+		}
+		return new Slice<T>(new Object[cap], 0, len, cap);
+	}
+
+	public static <T> Slice<T> make(int len, Supplier<T> zero) {
+		return make(len, len, zero);
+	}
+
+	// make fills the slice with zero values, strings and structs are not null in go
+	public static <T> Slice<T> make(int len, int cap, Supplier<T> zero) {
+		Slice<T> s = make(len, cap);
+		for (int i = 0; i < cap; i++) {
+			s.array[i] = zero.get();
+		}
+		return s;
+	}
+
+	@SafeVarargs
+	public static <T> Slice<T> of(T... values) {
+		Object[] array = new Object[values.length];
+		System.arraycopy(values, 0, array, 0, values.length);
+		return new Slice<T>(array, 0, values.length, values.length);
+	}
+
+	// wrap slices a java array, the slice shares the array
+	public static <T> Slice<T> wrap(T[] array) {
+		return new Slice<T>(array, 0, array.length, array.length);
+	}
+
+	public static int len(Slice<?> s) {
+		return s == null ? 0 : s.len;
+	}
+
+	public static int cap(Slice<?> s) {
+		return s == null ? 0 : s.cap;
+	}
+
+	@SuppressWarnings("unchecked")
+	public T get(int i) {
+		if (i < 0 || i >= len) {
+			throw new IndexOutOfBoundsException("index out of range [" + i + "] with length " + len);
+		}
+		return (T) array[offset + i];
+	}
+
+	public void set(int i, T value) {
+		if (i < 0 || i >= len) {
+			throw new IndexOutOfBoundsException("index out of range [" + i + "] with length " + len);
+		}
+		array[offset + i] = value;
+	}
+
+	public Slice<T> slice(int low) {
+		return slice(low, len, cap);
+	}
+
+	public Slice<T> slice(int low, int high) {
+		return slice(low, high, cap);
+	}
+
+	public Slice<T> slice(int low, int high, int max) {
+		if (low < 0 || high < low || max < high || max > cap) {
+			throw new IndexOutOfBoundsException("slice bounds out of range [" + low + ":" + high + ":" + max + "] with capacity " + cap);
+		}
+		return new Slice<T>(array, offset + low, high - low, max - low);
+	}
+
+	@SafeVarargs
+	public static <T> Slice<T> append(Slice<T> s, T... values) {
+		return append(s, values, 0, values.length);
+	}
+
+	public static <T> Slice<T> appendSlice(Slice<T> s, Slice<? extends T> other) {
+		if (other == null) {
+			return s;
+		}
+		return append(s, other.array, other.offset, other.len);
+	}
+
+	static <T> Slice<T> append(Slice<T> s, Object[] values, int from, int n) {
+		if (s == null) {
+			if (n == 0) {
+				return null;
+			}
+			s = new Slice<T>(new Object[0], 0, 0, 0);
+		}
+		int newLen = s.len + n;
+		if (newLen <= s.cap) {
+			// the appended values are visible through every slice of the backing array
+			System.arraycopy(values, from, s.array, s.offset + s.len, n);
+			return new Slice<T>(s.array, s.offset, newLen, s.cap);
+		}
+		int newCap = grow(s.cap, newLen);
+		Object[] array = new Object[newCap];
+		System.arraycopy(s.array, s.offset, array, 0, s.len);
+		System.arraycopy(values, from, array, s.len, n);
+		return new Slice<T>(array, 0, newLen, newCap);
+	}
+
+	public static <T> int copy(Slice<T> dst, Slice<? extends T> src) {
+		int n = Math.min(len(dst), len(src));
+		if (n > 0) {
+			System.arraycopy(src.array, src.offset, dst.array, dst.offset, n);
+		}
+		return n;
+	}
+
+	// clear sets the elements to null, slices of strings and structs are cleared with a zero supplier
+	public static void clear(Slice<?> s) {
+		if (s != null) {
+			Arrays.fill(s.array, s.offset, s.offset + s.len, null);
+		}
+	}
+
+	public static <T> void clear(Slice<T> s, Supplier<T> zero) {
+		for (int i = 0; i < len(s); i++) {
+			s.array[s.offset + i] = zero.get();
+		}
+	}
+
+	public Object[] toArray() {
+		Object[] values = new Object[len];
+		System.arraycopy(array, offset, values, 0, len);
+		return values;
+	}
+
+	// spread passes a slice to varargs, the array is shared when the slice covers all of it and has the element type
+	@SuppressWarnings("unchecked")
+	public static <T> T[] spread(Slice<? extends T> s, T[] empty) {
+		if (s == null) {
+			return empty;
+		}
+		if (s.offset == 0 && s.len == s.array.length && empty.getClass().isInstance(s.array)) {
+			return (T[]) s.array;
+		}
+		return (T[]) Arrays.copyOfRange(s.array, s.offset, s.offset + s.len, empty.getClass());
+	}
+
+	public static <T> Range<T> range(Slice<T> s) {
+		return new Range<T>(s);
+	}
+
+	// Range iterates a slice, the length is evaluated once like in a go range statement
+	public static final class Range<T> {
+		private final Slice<T> s;
+		private final int n;
+		public int index = -1;
+		public T value;
+
+		Range(Slice<T> s) {
+			this.s = s;
+			this.n = len(s);
+		}
+
+		@SuppressWarnings("unchecked")
+		public boolean next() {
+			if (++index >= n) {
+				return false;
+			}
+			value = (T) s.array[s.offset + index];
+			return true;
+		}
+	}
+
+	@Override
+	public String toString() {
+		return Fmt.Sprint(this);
+	}
+
+}
+`
+
+// primitiveSlices maps the java element types to their slice classes
+var primitiveSlices = map[string]string{
+	"boolean": "BooleanSlice",
+	"byte":    "ByteSlice",
+	"short":   "ShortSlice",
+	"int":     "IntSlice",
+	"long":    "LongSlice",
+	"float":   "FloatSlice",
+	"double":  "DoubleSlice",
+}
+
+func primitiveSliceSource(javaType string) string {
+	setType := javaType
+	if javaType == "byte" || javaType == "short" {
+		// arithmetic on bytes and shorts results in int
+		setType = "int"
+	}
+	extra := ""
+	if javaType == "byte" {
+		extra = orgGo2jByteSliceExtra
+	}
+	source := strings.Replace(orgGo2jPrimitiveSliceTempl, "{{Slice}}", primitiveSlices[javaType], -1)
+	source = strings.Replace(source, "{{setType}}", setType, -1)
+	source = strings.Replace(source, "{{type}}", javaType, -1)
+	return strings.Replace(source, "{{extra}}", extra, -1)
+}
+
+func init() {
+	helperClasses["org/go2j/util/Slice.java"] = orgGo2jSlice
+	for javaType, className := range primitiveSlices {
+		helperClasses["org/go2j/util/"+className+".java"] = primitiveSliceSource(javaType)
+	}
+}
@@ -0,0 +1,295 @@
+package main
+
+// GoSort implements the sort package. Sort and Slice are stable like Stable and SliceStable, go
+// does not guarantee the order of equal elements for them. the less functions are lambdas
+var orgGo2jGoSort = `package org.go2j.util;
+
+import java.lang.reflect.Array;
+import java.util.Arrays;
+import java.util.function.BiPredicate;
+import java.util.function.IntPredicate;
+
+public final class GoSort {
+
+	// Interface is sort.Interface, the types with its methods implement it
+	public interface Interface {
+		int Len();
+
+		boolean Less(int i, int j);
+
+		void Swap(int i, int j);
+	}
+
+	private GoSort() {
+	}
+
+	public static void Sort(Interface data) {
+		Stable(data);
+	}
+
+	// Stable is the insertion sort and the SymMerge of go
+	public static void Stable(Interface data) {
+		int n = data.Len();
+		int blockSize = 20;
+		int a = 0;
+		int b = blockSize;
+		while (b <= n) {
+			insertionSort(data, a, b);
+			a = b;
+			b += blockSize;
+		}
+		insertionSort(data, a, n);
+		while (blockSize < n) {
+			a = 0;
+			b = 2 * blockSize;
+			while (b <= n) {
+				symMerge(data, a, a + blockSize, b);
+				a = b;
+				b += 2 * blockSize;
+			}
+			int m = a + blockSize;
+			if (m < n) {
+				symMerge(data, a, m, n);
+			}
+			blockSize *= 2;
+		}
+	}
+
+	public static boolean IsSorted(Interface data) {
+		for (int i = data.Len() - 1; i > 0; i--) {
+			if (data.Less(i, i - 1)) {
+				return false;
+			}
+		}
+		return true;
+	}
+
+	public static Interface Reverse(Interface data) {
+		return new Interface() {
+			@Override
+			public int Len() {
+				return data.Len();
+			}
+
+			@Override
+			public boolean Less(int i, int j) {
+				return data.Less(j, i);
+			}
+
+			@Override
+			public void Swap(int i, int j) {
+				data.Swap(i, j);
+			}
+		};
+	}
+
+	// Slice sorts the indexes with less first, the elements are moved once
+	public static void Slice(Object x, BiPredicate<Integer, Integer> less) {
+		Backing b = backing(x);
+		int n = b.len;
+		if (n < 2) {
+			return;
+		}
+		Integer[] perm = new Integer[n];
+		for (int i = 0; i < n; i++) {
+			perm[i] = i;
+		}
+		Arrays.sort(perm, (i, j) -> less.test(i, j) ? -1 : less.test(j, i) ? 1 : 0);
+		Object old = Array.newInstance(b.array.getClass().getComponentType(), n);
+		System.arraycopy(b.array, b.offset, old, 0, n);
+		for (int i = 0; i < n; i++) {
+			System.arraycopy(old, perm[i], b.array, b.offset + i, 1);
+		}
+	}
+
+	public static void SliceStable(Object x, BiPredicate<Integer, Integer> less) {
+		Slice(x, less);
+	}
+
+	public static boolean SliceIsSorted(Object x, BiPredicate<Integer, Integer> less) {
+		for (int i = backing(x).len - 1; i > 0; i--) {
+			if (less.test(i, i - 1)) {
+				return false;
+			}
+		}
+		return true;
+	}
+
+	public static int Search(int n, IntPredicate f) {
+		int i = 0;
+		int j = n;
+		while (i < j) {
+			int h = (i + j) >>> 1;
+			if (!f.test(h)) {
+				i = h + 1;
+			} else {
+				j = h;
+			}
+		}
+		return i;
+	}
+
+	public static int SearchInts(IntSlice a, int x) {
+		return Search(IntSlice.len(a), i -> a.get(i) >= x);
+	}
+
+	public static int SearchFloat64s(DoubleSlice a, double x) {
+		return Search(DoubleSlice.len(a), i -> a.get(i) >= x);
+	}
+
+	public static int SearchStrings(Slice<String> a, String x) {
+		return Search(Slice.len(a), i -> GoString.compare(a.get(i), x) >= 0);
+	}
+
+	private static void insertionSort(Interface data, int a, int b) {
+		for (int i = a + 1; i < b; i++) {
+			for (int j = i; j > a && data.Less(j, j - 1); j--) {
+				data.Swap(j, j - 1);
+			}
+		}
+	}
+
+	private static void symMerge(Interface data, int a, int m, int b) {
+		if (m - a == 1) {
+			int i = m;
+			int j = b;
+			while (i < j) {
+				int h = (i + j) >>> 1;
+				if (data.Less(h, a)) {
+					i = h + 1;
+				} else {
+					j = h;
+				}
+			}
+			for (int k = a; k < i - 1; k++) {
+				data.Swap(k, k + 1);
+			}
+			return;
+		}
+		if (b - m == 1) {
+			int i = a;
+			int j = m;
+			while (i < j) {
+				int h = (i + j) >>> 1;
+				if (!data.Less(m, h)) {
+					i = h + 1;
+				} else {
+					j = h;
+				}
+			}
+			for (int k = m; k > i; k--) {
+				data.Swap(k, k - 1);
+			}
+			return;
+		}
+		int mid = (a + b) >>> 1;
+		int n = mid + m;
+		int start;
+		int r;
+		if (m > mid) {
+			start = n - b;
+			r = mid;
+		} else {
+			start = a;
+			r = m;
+		}
+		int p = n - 1;
+		while (start < r) {
+			int c = (start + r) >>> 1;
+			if (!data.Less(p - c, c)) {
+				start = c + 1;
+			} else {
+				r = c;
+			}
+		}
+		int end = n - start;
+		if (start < m && m < end) {
+			rotate(data, start, m, end);
+		}
+		if (a < start && start < mid) {
+			symMerge(data, a, start, mid);
+		}
+		if (mid < end && end < b) {
+			symMerge(data, mid, end, b);
+		}
+	}
+
+	private static void swapRange(Interface data, int a, int b, int n) {
+		for (int i = 0; i < n; i++) {
+			data.Swap(a + i, b + i);
+		}
+	}
+
+	private static void rotate(Interface data, int a, int m, int b) {
+		int i = m - a;
+		int j = b - m;
+		while (i != j) {
+			if (i > j) {
+				swapRange(data, m - i, m, j);
+				i -= j;
+			} else {
+				swapRange(data, m - i, m + j - i, i);
+				j -= i;
+			}
+		}
+		swapRange(data, m - i, m, i);
+	}
+
+	// Backing is the array of a slice class, sort.Slice takes any slice
+	private static final class Backing {
+		final Object array;
+		final int offset;
+		final int len;
+
+		Backing(Object array, int offset, int len) {
+			this.array = array;
+			this.offset = offset;
+			this.len = len;
+		}
+	}
+
+	private static Backing backing(Object x) {
+		if (x == null) {
+			return new Backing(null, 0, 0);
+		}
+		if (x instanceof Slice) {
+			Slice<?> s = (Slice<?>) x;
+			return new Backing(s.array, s.offset, s.len);
+		}
+		if (x instanceof BooleanSlice) {
+			BooleanSlice s = (BooleanSlice) x;
+			return new Backing(s.array, s.offset, s.len);
+		}
+		if (x instanceof ByteSlice) {
+			ByteSlice s = (ByteSlice) x;
+			return new Backing(s.array, s.offset, s.len);
+		}
+		if (x instanceof ShortSlice) {
+			ShortSlice s = (ShortSlice) x;
+			return new Backing(s.array, s.offset, s.len);
+		}
+		if (x instanceof IntSlice) {
+			IntSlice s = (IntSlice) x;
+			return new Backing(s.array, s.offset, s.len);
+		}
+		if (x instanceof LongSlice) {
+			LongSlice s = (LongSlice) x;
+			return new Backing(s.array, s.offset, s.len);
+		}
+		if (x instanceof FloatSlice) {
+			FloatSlice s = (FloatSlice) x;
+			return new Backing(s.array, s.offset, s.len);
+		}
+		if (x instanceof DoubleSlice) {
+			DoubleSlice s = (DoubleSlice) x;
+			return new Backing(s.array, s.offset, s.len);
+		}
+		throw new IllegalArgumentException("reflect: call of Swapper on " + x.getClass().getName() + " Value");
+	}
+
+}
+`
+
+func init() {
+	helperClasses["org/go2j/util/GoSort.java"] = orgGo2jGoSort
+}
@@ -0,0 +1,564 @@
+package main
+
+// Strconv implements the strconv package. the parse functions return the value and a *NumError tuple
+// like go, int is 32 bits in java so Atoi and the bit size 0 parse 32 bit integers
+var orgGo2jStrconv = `package org.go2j.util;
+
+public final class Strconv {
+
+	private Strconv() {
+	}
+
+	public static final int IntSize = 32;
+
+	private static final byte BACKQUOTE = 0x60;
+
+	public static final GoError ErrRange = GoErrors.New("value out of range");
+	public static final GoError ErrSyntax = GoErrors.New("invalid syntax");
+
+	public static class NumError implements GoError, GoError.Wrapper {
+		public String Func;
+		public String Num;
+		public GoError Err;
+
+		public NumError(String Func, String Num, GoError Err) {
+			this.Func = Func;
+			this.Num = Num;
+			this.Err = Err;
+		}
+
+		@Override
+		public String Error() {
+			return "strconv." + Func + ": parsing " + Quote(Num) + ": " + Err.Error();
+		}
+
+		@Override
+		public GoError Unwrap() {
+			return Err;
+		}
+
+		@Override
+		public String toString() {
+			return Error();
+		}
+	}
+
+	static NumError syntaxError(String fn, String str) {
+		return new NumError(fn, str, ErrSyntax);
+	}
+
+	static NumError rangeError(String fn, String str) {
+		return new NumError(fn, str, ErrRange);
+	}
+
+	static NumError baseError(String fn, String str, int base) {
+		return new NumError(fn, str, GoErrors.New("invalid base " + base));
+	}
+
+	static NumError bitSizeError(String fn, String str, int bitSize) {
+		return new NumError(fn, str, GoErrors.New("invalid bit size " + bitSize));
+	}
+
+	private static int lower(int c) {
+		return c | ('x' - 'X');
+	}
+
+	public static Tuple2<Boolean, GoError> ParseBool(String str) {
+		switch (str) {
+		case "1":
+		case "t":
+		case "T":
+		case "true":
+		case "TRUE":
+		case "True":
+			return new Tuple2<Boolean, GoError>(true, null);
+		case "0":
+		case "f":
+		case "F":
+		case "false":
+		case "FALSE":
+		case "False":
+			return new Tuple2<Boolean, GoError>(false, null);
+		}
+		return new Tuple2<Boolean, GoError>(false, syntaxError("ParseBool", str));
+	}
+
+	public static String FormatBool(boolean b) {
+		return b ? "true" : "false";
+	}
+
+	// ParseUint returns the uint64 value in the bits of a long
+	public static Tuple2<Long, GoError> ParseUint(String str, int base, int bitSize) {
+		String fn = "ParseUint";
+		byte[] s = GoString.bytesOf(str);
+		if (s.length == 0) {
+			return new Tuple2<Long, GoError>(0L, syntaxError(fn, str));
+		}
+		boolean base0 = base == 0;
+		int i = 0;
+		if (2 <= base && base <= 36) {
+			// valid base
+		} else if (base == 0) {
+			base = 10;
+			if (s[0] == '0') {
+				if (s.length >= 3 && lower(s[1]) == 'b') {
+					base = 2;
+					i = 2;
+				} else if (s.length >= 3 && lower(s[1]) == 'o') {
+					base = 8;
+					i = 2;
+				} else if (s.length >= 3 && lower(s[1]) == 'x') {
+					base = 16;
+					i = 2;
+				} else {
+					base = 8;
+					i = 1;
+				}
+			}
+		} else {
+			return new Tuple2<Long, GoError>(0L, baseError(fn, str, base));
+		}
+		if (bitSize == 0) {
+			bitSize = IntSize;
+		} else if (bitSize < 0 || bitSize > 64) {
+			return new Tuple2<Long, GoError>(0L, bitSizeError(fn, str, bitSize));
+		}
+		// the smallest number such that cutoff*base overflows
+		long cutoff = Long.divideUnsigned(-1L, base) + 1;
+		long maxVal = bitSize == 64 ? -1L : (1L << bitSize) - 1;
+		boolean underscores = false;
+		long n = 0;
+		for (; i < s.length; i++) {
+			int c = s[i] & 0xFF;
+			int d;
+			if (c == '_' && base0) {
+				underscores = true;
+				continue;
+			} else if ('0' <= c && c <= '9') {
+				d = c - '0';
+			} else if ('a' <= lower(c) && lower(c) <= 'z') {
+				d = lower(c) - 'a' + 10;
+			} else {
+				return new Tuple2<Long, GoError>(0L, syntaxError(fn, str));
+			}
+			if (d >= base) {
+				return new Tuple2<Long, GoError>(0L, syntaxError(fn, str));
+			}
+			if (Long.compareUnsigned(n, cutoff) >= 0) {
+				return new Tuple2<Long, GoError>(maxVal, rangeError(fn, str));
+			}
+			n *= base;
+			long n1 = n + d;
+			if (Long.compareUnsigned(n1, n) < 0 || Long.compareUnsigned(n1, maxVal) > 0) {
+				return new Tuple2<Long, GoError>(maxVal, rangeError(fn, str));
+			}
+			n = n1;
+		}
+		if (underscores && !underscoreOK(s, s.length)) {
+			return new Tuple2<Long, GoError>(0L, syntaxError(fn, str));
+		}
+		return new Tuple2<Long, GoError>(n, null);
+	}
+
+	public static Tuple2<Long, GoError> ParseInt(String str, int base, int bitSize) {
+		String fn = "ParseInt";
+		if (str.isEmpty()) {
+			return new Tuple2<Long, GoError>(0L, syntaxError(fn, str));
+		}
+		boolean neg = str.charAt(0) == '-';
+		String s = str.charAt(0) == '+' || neg ? str.substring(1) : str;
+		Tuple2<Long, GoError> un = ParseUint(s, base, bitSize);
+		if (un.r1 != null && ((NumError) un.r1).Err != ErrRange) {
+			NumError err = (NumError) un.r1;
+			err.Func = fn;
+			err.Num = str;
+			return new Tuple2<Long, GoError>(0L, err);
+		}
+		if (bitSize == 0) {
+			bitSize = IntSize;
+		}
+		long cutoff = 1L << (bitSize - 1);
+		if (!neg && Long.compareUnsigned(un.r0, cutoff) >= 0) {
+			return new Tuple2<Long, GoError>(cutoff - 1, rangeError(fn, str));
+		}
+		if (neg && Long.compareUnsigned(un.r0, cutoff) > 0) {
+			return new Tuple2<Long, GoError>(-cutoff, rangeError(fn, str));
+		}
+		return new Tuple2<Long, GoError>(neg ? -un.r0 : un.r0, null);
+	}
+
+	public static Tuple2<Integer, GoError> Atoi(String s) {
+		Tuple2<Long, GoError> i = ParseInt(s, 10, 0);
+		if (i.r1 != null) {
+			((NumError) i.r1).Func = "Atoi";
+		}
+		return new Tuple2<Integer, GoError>((int) (long) i.r0, i.r1);
+	}
+
+	// underscoreOK tells whether the underscores separate digits like in go literals
+	static boolean underscoreOK(byte[] s, int end) {
+		char saw = '^';
+		int i = 0;
+		if (end >= 1 && (s[0] == '-' || s[0] == '+')) {
+			i++;
+		}
+		boolean hex = false;
+		if (end - i >= 2 && s[i] == '0' && (lower(s[i + 1]) == 'b' || lower(s[i + 1]) == 'o' || lower(s[i + 1]) == 'x')) {
+			hex = lower(s[i + 1]) == 'x';
+			i += 2;
+			// the base prefix counts as a digit
+			saw = '0';
+		}
+		for (; i < end; i++) {
+			int c = s[i] & 0xFF;
+			if ('0' <= c && c <= '9' || hex && 'a' <= lower(c) && lower(c) <= 'f') {
+				saw = '0';
+				continue;
+			}
+			if (c == '_') {
+				if (saw != '0') {
+					return false;
+				}
+				saw = '_';
+				continue;
+			}
+			if (saw == '_') {
+				return false;
+			}
+			saw = '!';
+		}
+		return saw != '_';
+	}
+
+	public static String Itoa(long i) {
+		return Long.toString(i);
+	}
+
+	public static String FormatInt(long i, int base) {
+		checkBase(base, "FormatInt");
+		return Long.toString(i, base);
+	}
+
+	public static String FormatUint(long i, int base) {
+		checkBase(base, "FormatUint");
+		return Long.toUnsignedString(i, base);
+	}
+
+	private static void checkBase(int base, String fn) {
+		if (base < 2 || base > 36) {
+			throw new IllegalArgumentException("strconv: illegal AppendInt/" + fn + " base");
+		}
+	}
+
+	// ParseFloat accepts the go float literals, inf, infinity and nan. a value out of range is a signed infinity
+	public static Tuple2<Double, GoError> ParseFloat(String str, int bitSize) {
+		String fn = "ParseFloat";
+		byte[] s = GoString.bytesOf(str);
+		Double special = special(s);
+		if (special != null) {
+			return new Tuple2<Double, GoError>(special, null);
+		}
+		if (readFloat(s) != s.length) {
+			return new Tuple2<Double, GoError>(0.0, syntaxError(fn, str));
+		}
+		String literal = str.replace("_", "");
+		double v = bitSize == 32 ? Float.parseFloat(literal) : Double.parseDouble(literal);
+		if (Double.isInfinite(v)) {
+			return new Tuple2<Double, GoError>(v, rangeError(fn, str));
+		}
+		return new Tuple2<Double, GoError>(v, null);
+	}
+
+	// special returns the infinities and nan when they are the whole string
+	private static Double special(byte[] s) {
+		if (s.length == 0) {
+			return null;
+		}
+		int i = 0;
+		double sign = 1;
+		if (s[0] == '+' || s[0] == '-') {
+			sign = s[0] == '-' ? -1 : 1;
+			i++;
+		} else if (equalsIgnoreCase(s, 0, "nan")) {
+			return Double.NaN;
+		}
+		if (equalsIgnoreCase(s, i, "inf") || equalsIgnoreCase(s, i, "infinity")) {
+			return sign * Double.POSITIVE_INFINITY;
+		}
+		return null;
+	}
+
+	private static boolean equalsIgnoreCase(byte[] s, int from, String word) {
+		if (s.length - from != word.length()) {
+			return false;
+		}
+		for (int i = 0; i < word.length(); i++) {
+			if (lower(s[from + i]) != word.charAt(i)) {
+				return false;
+			}
+		}
+		return true;
+	}
+
+	// readFloat returns the end of the float literal at the start of s, or -1
+	static int readFloat(byte[] s) {
+		int i = 0;
+		if (i < s.length && (s[i] == '+' || s[i] == '-')) {
+			i++;
+		}
+		boolean hex = false;
+		char expChar = 'e';
+		if (i + 2 < s.length && s[i] == '0' && lower(s[i + 1]) == 'x') {
+			hex = true;
+			expChar = 'p';
+			i += 2;
+		}
+		boolean underscores = false;
+		boolean sawdot = false;
+		boolean sawdigits = false;
+		for (; i < s.length; i++) {
+			int c = s[i] & 0xFF;
+			if (c == '_') {
+				underscores = true;
+			} else if (c == '.') {
+				if (sawdot) {
+					break;
+				}
+				sawdot = true;
+			} else if ('0' <= c && c <= '9' || hex && 'a' <= lower(c) && lower(c) <= 'f') {
+				sawdigits = true;
+			} else {
+				break;
+			}
+		}
+		if (!sawdigits) {
+			return -1;
+		}
+		if (i < s.length && lower(s[i]) == expChar) {
+			i++;
+			if (i < s.length && (s[i] == '+' || s[i] == '-')) {
+				i++;
+			}
+			if (i >= s.length || s[i] < '0' || s[i] > '9') {
+				return -1;
+			}
+			for (; i < s.length && ('0' <= s[i] && s[i] <= '9' || s[i] == '_'); i++) {
+				if (s[i] == '_') {
+					underscores = true;
+				}
+			}
+		} else if (hex) {
+			// a hexadecimal mantissa requires a p exponent
+			return -1;
+		}
+		if (underscores && !underscoreOK(s, i)) {
+			return -1;
+		}
+		return i;
+	}
+
+	// FormatFloat formats like %e, %f, %g, %b and %x, a negative precision gives the shortest representation
+	public static String FormatFloat(double f, int fmt, int prec, int bitSize) {
+		return Fmt.formatFloat(f, (char) fmt, prec, bitSize);
+	}
+
+	public static String Quote(String s) {
+		return Fmt.quote(s, '"', false);
+	}
+
+	public static String QuoteToASCII(String s) {
+		return Fmt.quote(s, '"', true);
+	}
+
+	public static String QuoteRune(int r) {
+		return Fmt.quote(GoString.fromRune(r), '\'', false);
+	}
+
+	public static String QuoteRuneToASCII(int r) {
+		return Fmt.quote(GoString.fromRune(r), '\'', true);
+	}
+
+	public static boolean CanBackquote(String s) {
+		return Fmt.canBackquote(s);
+	}
+
+	public static boolean IsPrint(int r) {
+		return Fmt.isPrint(r);
+	}
+
+	// Unquote interprets a double quoted, single quoted or back quoted go literal
+	public static Tuple2<String, GoError> Unquote(String str) {
+		byte[] in = GoString.bytesOf(str);
+		Tuple2<String, GoError> invalid = new Tuple2<String, GoError>("", ErrSyntax);
+		if (in.length < 2) {
+			return invalid;
+		}
+		byte quote = in[0];
+		if (quote == BACKQUOTE) {
+			if (in[in.length - 1] != quote) {
+				return invalid;
+			}
+			byte[] out = new byte[in.length - 2];
+			int n = 0;
+			for (int i = 1; i < in.length - 1; i++) {
+				if (in[i] == quote) {
+					return invalid;
+				}
+				// carriage returns are discarded from raw strings
+				if (in[i] != '\r') {
+					out[n++] = in[i];
+				}
+			}
+			return new Tuple2<String, GoError>(GoString.decode(out, 0, n), null);
+		}
+		if (quote != '"' && quote != '\'') {
+			return invalid;
+		}
+		byte[] out = new byte[in.length * 3];
+		int n = 0;
+		int i = 1;
+		while (i < in.length && in[i] != quote) {
+			if (in[i] == '\n') {
+				return invalid;
+			}
+			long[] c = unquoteChar(in, i, quote);
+			if (c == null) {
+				return invalid;
+			}
+			int r = (int) c[0];
+			if (c[1] != 0) {
+				n = GoString.encodeRune(out, n, r);
+			} else {
+				out[n++] = (byte) r;
+			}
+			i = (int) c[2];
+			// single quoted strings must be a single character
+			if (quote == '\'') {
+				break;
+			}
+		}
+		if (i != in.length - 1 || in[i] != quote) {
+			return invalid;
+		}
+		return new Tuple2<String, GoError>(GoString.decode(out, 0, n), null);
+	}
+
+	// unquoteChar decodes the character or escape at s[i] like strconv.UnquoteChar. it returns the value,
+	// 1 when it is a multibyte rune and the end of the character, or null on a syntax error
+	static long[] unquoteChar(byte[] s, int i, byte quote) {
+		int c = s[i] & 0xFF;
+		if (c == quote) {
+			return null;
+		}
+		if (c >= 0x80) {
+			long decoded = GoString.decodeRune(s, i, s.length);
+			return new long[] {(int) decoded, 1, i + (int) (decoded >> 32)};
+		}
+		if (c != '\\') {
+			return new long[] {c, 0, i + 1};
+		}
+		if (i + 1 >= s.length) {
+			return null;
+		}
+		c = s[i + 1] & 0xFF;
+		i += 2;
+		switch (c) {
+		case 'a':
+			return new long[] {0x07, 0, i};
+		case 'b':
+			return new long[] {'\b', 0, i};
+		case 'f':
+			return new long[] {'\f', 0, i};
+		case 'n':
+			return new long[] {'\n', 0, i};
+		case 'r':
+			return new long[] {'\r', 0, i};
+		case 't':
+			return new long[] {'\t', 0, i};
+		case 'v':
+			return new long[] {0x0B, 0, i};
+		case 'x':
+		case 'u':
+		case 'U':
+			int n = c == 'x' ? 2 : c == 'u' ? 4 : 8;
+			if (i + n > s.length) {
+				return null;
+			}
+			int v = 0;
+			for (int j = 0; j < n; j++) {
+				int x = Character.digit(s[i + j], 16);
+				if (x < 0) {
+					return null;
+				}
+				v = v << 4 | x;
+			}
+			if (c == 'x') {
+				// a single byte, possibly not UTF-8
+				return new long[] {v, 0, i + n};
+			}
+			if (v < 0 || v > 0x10FFFF || (v >= 0xD800 && v <= 0xDFFF)) {
+				return null;
+			}
+			return new long[] {v, 1, i + n};
+		case '0':
+		case '1':
+		case '2':
+		case '3':
+		case '4':
+		case '5':
+		case '6':
+		case '7':
+			int o = c - '0';
+			if (i + 2 > s.length) {
+				return null;
+			}
+			for (int j = 0; j < 2; j++) {
+				int x = s[i + j] - '0';
+				if (x < 0 || x > 7) {
+					return null;
+				}
+				o = (o << 3) | x;
+			}
+			if (o > 255) {
+				return null;
+			}
+			return new long[] {o, 0, i + 2};
+		case '\\':
+			return new long[] {'\\', 0, i};
+		case '\'':
+		case '"':
+			if (c != quote) {
+				return null;
+			}
+			return new long[] {c, 0, i};
+		default:
+			return null;
+		}
+	}
+
+	public static ByteSlice AppendInt(ByteSlice dst, long i, int base) {
+		return ByteSlice.appendString(dst, FormatInt(i, base));
+	}
+
+	public static ByteSlice AppendUint(ByteSlice dst, long i, int base) {
+		return ByteSlice.appendString(dst, FormatUint(i, base));
+	}
+
+	public static ByteSlice AppendFloat(ByteSlice dst, double f, int fmt, int prec, int bitSize) {
+		return ByteSlice.appendString(dst, FormatFloat(f, fmt, prec, bitSize));
+	}
+
+	public static ByteSlice AppendBool(ByteSlice dst, boolean b) {
+		return ByteSlice.appendString(dst, FormatBool(b));
+	}
+
+	public static ByteSlice AppendQuote(ByteSlice dst, String s) {
+		return ByteSlice.appendString(dst, Quote(s));
+	}
+
+}
+`
+
+func init() {
+	helperClasses["org/go2j/util/Strconv.java"] = orgGo2jStrconv
+}
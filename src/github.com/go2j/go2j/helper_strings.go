@@ -0,0 +1,618 @@
+package main
+
+// GoStrings implements the strings package on the UTF-8 bytes of the strings, so the byte offsets,
+// the invalid UTF-8 sequences and the unicode white space are handled like in go.
+var orgGo2jGoStrings = `package org.go2j.util;
+
+import java.util.ArrayList;
+import java.util.Arrays;
+import java.util.List;
+
+public class GoStrings {
+
+	// Builder collects the bytes of the written strings
+	public static class Builder {
+		private byte[] buf = new byte[0];
+		private int len;
+
+		public Tuple2<Integer, GoError> Write(ByteSlice p) {
+			int n = ByteSlice.len(p);
+			if (n > 0) {
+				append(p.array, p.offset, n);
+			}
+			return new Tuple2<Integer, GoError>(n, null);
+		}
+
+		public GoError WriteByte(int c) {
+			ensure(1);
+			buf[len++] = (byte) c;
+			return null;
+		}
+
+		public Tuple2<Integer, GoError> WriteRune(int r) {
+			ensure(4);
+			int n = len;
+			len = GoString.encodeRune(buf, len, r);
+			return new Tuple2<Integer, GoError>(len - n, null);
+		}
+
+		public Tuple2<Integer, GoError> WriteString(String s) {
+			byte[] b = GoString.bytesOf(s);
+			append(b, 0, b.length);
+			return new Tuple2<Integer, GoError>(b.length, null);
+		}
+
+		void append(byte[] b, int from, int n) {
+			ensure(n);
+			System.arraycopy(b, from, buf, len, n);
+			len += n;
+		}
+
+		private void ensure(int n) {
+			if (buf.length - len < n) {
+				buf = Arrays.copyOf(buf, 2 * buf.length + n);
+			}
+		}
+
+		public void Grow(int n) {
+			if (n < 0) {
+				throw new IllegalArgumentException("strings.Builder.Grow: negative count");
+			}
+			ensure(n);
+		}
+
+		public int Len() {
+			return len;
+		}
+
+		public int Cap() {
+			return buf.length;
+		}
+
+		public void Reset() {
+			buf = new byte[0];
+			len = 0;
+		}
+
+		public String String() {
+			return GoString.decode(buf, 0, len);
+		}
+
+		@Override
+		public String toString() {
+			return String();
+		}
+	}
+
+	// Replacer replaces the old strings at each position in argument order, without overlapping matches
+	public static class Replacer {
+		private final byte[][] olds;
+		private final byte[][] news;
+
+		Replacer(String... oldnew) {
+			olds = new byte[oldnew.length / 2][];
+			news = new byte[oldnew.length / 2][];
+			for (int i = 0; i < olds.length; i++) {
+				olds[i] = GoString.bytesOf(oldnew[2 * i]);
+				news[i] = GoString.bytesOf(oldnew[2 * i + 1]);
+			}
+		}
+
+		public String Replace(String str) {
+			byte[] s = GoString.bytesOf(str);
+			Builder b = null;
+			int last = 0;
+			boolean prevMatchEmpty = false;
+			for (int i = 0; i <= s.length;) {
+				int match = -1;
+				for (int k = 0; k < olds.length; k++) {
+					// an empty old string matches once at each position
+					if (!(olds[k].length == 0 && prevMatchEmpty) && hasPrefixAt(s, i, olds[k])) {
+						match = k;
+						break;
+					}
+				}
+				prevMatchEmpty = match >= 0 && olds[match].length == 0;
+				if (match < 0) {
+					i++;
+					continue;
+				}
+				if (b == null) {
+					b = new Builder();
+				}
+				b.append(s, last, i - last);
+				b.append(news[match], 0, news[match].length);
+				i += olds[match].length;
+				last = i;
+			}
+			if (b == null) {
+				return str;
+			}
+			b.append(s, last, s.length - last);
+			return b.String();
+		}
+
+		public Tuple2<Integer, GoError> WriteString(Object w, String s) {
+			return Fmt.write(w, Replace(s));
+		}
+	}
+
+	public static Replacer NewReplacer(String... oldnew) {
+		if (oldnew.length % 2 == 1) {
+			throw new IllegalArgumentException("strings.NewReplacer: odd argument count");
+		}
+		return new Replacer(oldnew);
+	}
+
+	static boolean hasPrefixAt(byte[] s, int i, byte[] prefix) {
+		if (i + prefix.length > s.length) {
+			return false;
+		}
+		for (int k = 0; k < prefix.length; k++) {
+			if (s[i + k] != prefix[k]) {
+				return false;
+			}
+		}
+		return true;
+	}
+
+	static int indexOf(byte[] s, byte[] sep, int from) {
+		for (int i = from; i + sep.length <= s.length; i++) {
+			if (hasPrefixAt(s, i, sep)) {
+				return i;
+			}
+		}
+		return -1;
+	}
+
+	static int runeCount(byte[] s) {
+		int n = 0;
+		for (int i = 0; i < s.length; n++) {
+			i += (int) (GoString.decodeRune(s, i, s.length) >> 32);
+		}
+		return n;
+	}
+
+	// decodeLastRune decodes the rune ending at b[end] like utf8.DecodeLastRune
+	static long decodeLastRune(byte[] b, int end) {
+		int start = end - 1;
+		if ((b[start] & 0xFF) < 0x80) {
+			return (1L << 32) | (b[start] & 0xFF);
+		}
+		int lim = Math.max(end - 4, 0);
+		for (start--; start >= lim; start--) {
+			if ((b[start] & 0xC0) != 0x80) {
+				break;
+			}
+		}
+		start = Math.max(start, 0);
+		long decoded = GoString.decodeRune(b, start, end);
+		if (start + (int) (decoded >> 32) != end) {
+			return (1L << 32) | GoString.RUNE_ERROR;
+		}
+		return decoded;
+	}
+
+	static boolean isSpace(int r) {
+		if (r <= 0xFF) {
+			return r == '\t' || r == '\n' || r == 0x0B || r == '\f' || r == '\r' || r == ' ' || r == 0x85 || r == 0xA0;
+		}
+		return r == 0x1680 || (r >= 0x2000 && r <= 0x200A) || r == 0x2028 || r == 0x2029 || r == 0x202F || r == 0x205F || r == 0x3000;
+	}
+
+	public static boolean Contains(String s, String substr) {
+		return Index(s, substr) >= 0;
+	}
+
+	public static boolean ContainsAny(String s, String chars) {
+		return IndexAny(s, chars) >= 0;
+	}
+
+	public static boolean ContainsRune(String s, int r) {
+		return IndexRune(s, r) >= 0;
+	}
+
+	public static int Count(String s, String substr) {
+		byte[] b = GoString.bytesOf(s);
+		byte[] sep = GoString.bytesOf(substr);
+		if (sep.length == 0) {
+			return runeCount(b) + 1;
+		}
+		int n = 0;
+		for (int i = indexOf(b, sep, 0); i >= 0; i = indexOf(b, sep, i + sep.length)) {
+			n++;
+		}
+		return n;
+	}
+
+	public static boolean HasPrefix(String s, String prefix) {
+		return hasPrefixAt(GoString.bytesOf(s), 0, GoString.bytesOf(prefix));
+	}
+
+	public static boolean HasSuffix(String s, String suffix) {
+		byte[] b = GoString.bytesOf(s);
+		byte[] end = GoString.bytesOf(suffix);
+		return b.length >= end.length && hasPrefixAt(b, b.length - end.length, end);
+	}
+
+	public static int Index(String s, String substr) {
+		return indexOf(GoString.bytesOf(s), GoString.bytesOf(substr), 0);
+	}
+
+	public static int LastIndex(String s, String substr) {
+		byte[] b = GoString.bytesOf(s);
+		byte[] sep = GoString.bytesOf(substr);
+		for (int i = b.length - sep.length; i >= 0; i--) {
+			if (hasPrefixAt(b, i, sep)) {
+				return i;
+			}
+		}
+		return -1;
+	}
+
+	public static int IndexByte(String s, int c) {
+		byte[] b = GoString.bytesOf(s);
+		for (int i = 0; i < b.length; i++) {
+			if (b[i] == (byte) c) {
+				return i;
+			}
+		}
+		return -1;
+	}
+
+	public static int LastIndexByte(String s, int c) {
+		byte[] b = GoString.bytesOf(s);
+		for (int i = b.length - 1; i >= 0; i--) {
+			if (b[i] == (byte) c) {
+				return i;
+			}
+		}
+		return -1;
+	}
+
+	// IndexRune finds the invalid UTF-8 sequences too when r is utf8.RuneError
+	public static int IndexRune(String s, int r) {
+		if (r >= 0 && r < 0x80) {
+			return IndexByte(s, r);
+		}
+		if (r == GoString.RUNE_ERROR) {
+			byte[] b = GoString.bytesOf(s);
+			for (int i = 0; i < b.length;) {
+				long decoded = GoString.decodeRune(b, i, b.length);
+				if ((int) decoded == GoString.RUNE_ERROR) {
+					return i;
+				}
+				i += (int) (decoded >> 32);
+			}
+			return -1;
+		}
+		if (r < 0 || r > 0x10FFFF || (r >= 0xD800 && r <= 0xDFFF)) {
+			return -1;
+		}
+		return Index(s, GoString.fromRune(r));
+	}
+
+	public static int IndexAny(String s, String chars) {
+		byte[] b = GoString.bytesOf(s);
+		for (int i = 0; i < b.length;) {
+			long decoded = GoString.decodeRune(b, i, b.length);
+			if (IndexRune(chars, (int) decoded) >= 0) {
+				return i;
+			}
+			i += (int) (decoded >> 32);
+		}
+		return -1;
+	}
+
+	public static Slice<String> Split(String s, String sep) {
+		return genSplit(s, sep, false, -1);
+	}
+
+	public static Slice<String> SplitN(String s, String sep, int n) {
+		return genSplit(s, sep, false, n);
+	}
+
+	public static Slice<String> SplitAfter(String s, String sep) {
+		return genSplit(s, sep, true, -1);
+	}
+
+	public static Slice<String> SplitAfterN(String s, String sep, int n) {
+		return genSplit(s, sep, true, n);
+	}
+
+	static Slice<String> genSplit(String str, String sepStr, boolean sepSave, int n) {
+		if (n == 0) {
+			return null;
+		}
+		byte[] s = GoString.bytesOf(str);
+		byte[] sep = GoString.bytesOf(sepStr);
+		if (sep.length == 0) {
+			return explode(s, n);
+		}
+		if (n < 0) {
+			n = Count(str, sepStr) + 1;
+		}
+		if (n > s.length + 1) {
+			n = s.length + 1;
+		}
+		String[] a = new String[n];
+		int i = 0;
+		int start = 0;
+		while (i < n - 1) {
+			int m = indexOf(s, sep, start);
+			if (m < 0) {
+				break;
+			}
+			a[i++] = GoString.decode(s, start, sepSave ? m + sep.length : m);
+			start = m + sep.length;
+		}
+		a[i] = GoString.decode(s, start, s.length);
+		return new Slice<String>(a, 0, i + 1, n);
+	}
+
+	// explode splits into UTF-8 sequences, an invalid byte is a sequence
+	static Slice<String> explode(byte[] s, int n) {
+		int l = runeCount(s);
+		if (n < 0 || n > l) {
+			n = l;
+		}
+		String[] a = new String[n];
+		int start = 0;
+		for (int i = 0; i < n - 1; i++) {
+			int size = (int) (GoString.decodeRune(s, start, s.length) >> 32);
+			a[i] = GoString.decode(s, start, start + size);
+			start += size;
+		}
+		if (n > 0) {
+			a[n - 1] = GoString.decode(s, start, s.length);
+		}
+		return Slice.wrap(a);
+	}
+
+	// Fields splits around runs of unicode white space
+	public static Slice<String> Fields(String str) {
+		byte[] s = GoString.bytesOf(str);
+		List<String> fields = new ArrayList<String>();
+		int start = -1;
+		for (int i = 0; i < s.length;) {
+			long decoded = GoString.decodeRune(s, i, s.length);
+			if (isSpace((int) decoded)) {
+				if (start >= 0) {
+					fields.add(GoString.decode(s, start, i));
+					start = -1;
+				}
+			} else if (start < 0) {
+				start = i;
+			}
+			i += (int) (decoded >> 32);
+		}
+		if (start >= 0) {
+			fields.add(GoString.decode(s, start, s.length));
+		}
+		return Slice.wrap(fields.toArray(new String[fields.size()]));
+	}
+
+	public static String Join(Slice<String> elems, String sep) {
+		int n = Slice.len(elems);
+		if (n == 0) {
+			return "";
+		}
+		if (n == 1) {
+			return elems.get(0);
+		}
+		Builder b = new Builder();
+		for (int i = 0; i < n; i++) {
+			if (i > 0) {
+				b.WriteString(sep);
+			}
+			b.WriteString(elems.get(i));
+		}
+		return b.String();
+	}
+
+	public static String Repeat(String s, int count) {
+		if (count == 0) {
+			return "";
+		}
+		if (count < 0) {
+			throw new IllegalArgumentException("strings: negative Repeat count");
+		}
+		byte[] b = GoString.bytesOf(s);
+		if ((long) b.length * count > Integer.MAX_VALUE) {
+			throw new IllegalArgumentException("strings: Repeat output length overflow");
+		}
+		byte[] r = new byte[b.length * count];
+		for (int i = 0; i < count; i++) {
+			System.arraycopy(b, 0, r, i * b.length, b.length);
+		}
+		return GoString.decode(r, 0, r.length);
+	}
+
+	// Replace replaces the first n non-overlapping old strings, all of them when n < 0.
+	// an empty old string matches at the start and after each UTF-8 sequence
+	public static String Replace(String str, String oldStr, String newStr, int n) {
+		if (oldStr.equals(newStr) || n == 0) {
+			return str;
+		}
+		int m = Count(str, oldStr);
+		if (m == 0) {
+			return str;
+		}
+		if (n < 0 || m < n) {
+			n = m;
+		}
+		byte[] s = GoString.bytesOf(str);
+		byte[] old = GoString.bytesOf(oldStr);
+		byte[] nw = GoString.bytesOf(newStr);
+		Builder b = new Builder();
+		int start = 0;
+		for (int i = 0; i < n; i++) {
+			int j = start;
+			if (old.length == 0) {
+				if (i > 0) {
+					j += (int) (GoString.decodeRune(s, start, s.length) >> 32);
+				}
+			} else {
+				j = indexOf(s, old, start);
+			}
+			b.append(s, start, j - start);
+			b.append(nw, 0, nw.length);
+			start = j + old.length;
+		}
+		b.append(s, start, s.length - start);
+		return b.String();
+	}
+
+	public static String ReplaceAll(String s, String oldStr, String newStr) {
+		return Replace(s, oldStr, newStr, -1);
+	}
+
+	// ToUpper maps the runes with the simple case mapping, invalid bytes become utf8.RuneError
+	public static String ToUpper(String s) {
+		return mapRunes(s, true);
+	}
+
+	public static String ToLower(String s) {
+		return mapRunes(s, false);
+	}
+
+	static String mapRunes(String str, boolean upper) {
+		byte[] s = GoString.bytesOf(str);
+		Builder b = new Builder();
+		for (int i = 0; i < s.length;) {
+			long decoded = GoString.decodeRune(s, i, s.length);
+			int r = (int) decoded;
+			b.WriteRune(upper ? Character.toUpperCase(r) : Character.toLowerCase(r));
+			i += (int) (decoded >> 32);
+		}
+		return b.String();
+	}
+
+	public static String TrimSpace(String str) {
+		byte[] s = GoString.bytesOf(str);
+		int start = 0;
+		while (start < s.length) {
+			long decoded = GoString.decodeRune(s, start, s.length);
+			if (!isSpace((int) decoded)) {
+				break;
+			}
+			start += (int) (decoded >> 32);
+		}
+		int end = s.length;
+		while (end > start) {
+			long decoded = decodeLastRune(s, end);
+			if (!isSpace((int) decoded)) {
+				break;
+			}
+			end -= (int) (decoded >> 32);
+		}
+		return GoString.slice(str, start, end);
+	}
+
+	public static String Trim(String s, String cutset) {
+		return TrimRight(TrimLeft(s, cutset), cutset);
+	}
+
+	public static String TrimLeft(String str, String cutset) {
+		byte[] s = GoString.bytesOf(str);
+		int start = 0;
+		while (start < s.length) {
+			long decoded = GoString.decodeRune(s, start, s.length);
+			if (!ContainsRune(cutset, (int) decoded)) {
+				break;
+			}
+			start += (int) (decoded >> 32);
+		}
+		return GoString.slice(str, start);
+	}
+
+	public static String TrimRight(String str, String cutset) {
+		byte[] s = GoString.bytesOf(str);
+		int end = s.length;
+		while (end > 0) {
+			long decoded = decodeLastRune(s, end);
+			if (!ContainsRune(cutset, (int) decoded)) {
+				break;
+			}
+			end -= (int) (decoded >> 32);
+		}
+		return GoString.slice(str, 0, end);
+	}
+
+	public static String TrimPrefix(String s, String prefix) {
+		if (HasPrefix(s, prefix)) {
+			return GoString.slice(s, GoString.len(prefix));
+		}
+		return s;
+	}
+
+	public static String TrimSuffix(String s, String suffix) {
+		if (HasSuffix(s, suffix)) {
+			return GoString.slice(s, 0, GoString.len(s) - GoString.len(suffix));
+		}
+		return s;
+	}
+
+	public static Tuple3<String, String, Boolean> Cut(String s, String sep) {
+		int i = Index(s, sep);
+		if (i < 0) {
+			return new Tuple3<String, String, Boolean>(s, "", false);
+		}
+		return new Tuple3<String, String, Boolean>(GoString.slice(s, 0, i), GoString.slice(s, i + GoString.len(sep)), true);
+	}
+
+	public static Tuple2<String, Boolean> CutPrefix(String s, String prefix) {
+		if (!HasPrefix(s, prefix)) {
+			return new Tuple2<String, Boolean>(s, false);
+		}
+		return new Tuple2<String, Boolean>(GoString.slice(s, GoString.len(prefix)), true);
+	}
+
+	public static Tuple2<String, Boolean> CutSuffix(String s, String suffix) {
+		if (!HasSuffix(s, suffix)) {
+			return new Tuple2<String, Boolean>(s, false);
+		}
+		return new Tuple2<String, Boolean>(GoString.slice(s, 0, GoString.len(s) - GoString.len(suffix)), true);
+	}
+
+	// EqualFold compares under simple unicode case folding, invalid bytes are equal as utf8.RuneError
+	public static boolean EqualFold(String s, String t) {
+		byte[] a = GoString.bytesOf(s);
+		byte[] b = GoString.bytesOf(t);
+		int i = 0;
+		int j = 0;
+		while (i < a.length && j < b.length) {
+			long da = GoString.decodeRune(a, i, a.length);
+			long db = GoString.decodeRune(b, j, b.length);
+			if (!foldEquals((int) da, (int) db)) {
+				return false;
+			}
+			i += (int) (da >> 32);
+			j += (int) (db >> 32);
+		}
+		return i == a.length && j == b.length;
+	}
+
+	static boolean foldEquals(int r, int q) {
+		if (r == q) {
+			return true;
+		}
+		if (r == 0x130 || r == 0x131 || q == 0x130 || q == 0x131) {
+			// the dotted and dotless i fold only to themselves
+			return false;
+		}
+		int ur = Character.toUpperCase(r);
+		int uq = Character.toUpperCase(q);
+		return ur == uq || Character.toLowerCase(ur) == Character.toLowerCase(uq) || Character.toLowerCase(r) == Character.toLowerCase(q);
+	}
+
+	public static int Compare(String a, String b) {
+		return Integer.signum(GoString.compare(a, b));
+	}
+
+}
+`
+
+func init() {
+	helperClasses["org/go2j/util/GoStrings.java"] = orgGo2jGoStrings
+}
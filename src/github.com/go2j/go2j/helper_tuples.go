@@ -0,0 +1,57 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+)
+
+// functions with several results return a tuple of the results, the callers assign its fields.
+// The tuple classes are generated from orgGo2jTupleTempl.
+
+const maxTupleSize = 6
+
+var orgGo2jTupleTempl = `package org.go2j.util;
+
+public final class {{Tuple}}<{{params}}> {
+{{fields}}
+	public {{Tuple}}({{args}}) {
+{{assigns}}	}
+
+	@Override
+	public String toString() {
+		return {{print}};
+	}
+
+}
+`
+
+func tupleClass(size int) string {
+	return "Tuple" + strconv.Itoa(size)
+}
+
+func tupleSource(size int) string {
+	params, args, prints := []string{}, []string{}, []string{}
+	fields, assigns := "", ""
+	for idx := 0; idx < size; idx++ {
+		param, field := "T"+strconv.Itoa(idx), "r"+strconv.Itoa(idx)
+		params = append(params, param)
+		args = append(args, param+" "+field)
+		prints = append(prints, "GoObjects.toString("+field+")")
+		fields += "\tpublic final " + param + " " + field + ";\n"
+		assigns += "\t\tthis." + field + " = " + field + ";\n"
+	}
+	return strings.NewReplacer(
+		"{{Tuple}}", tupleClass(size),
+		"{{params}}", strings.Join(params, ", "),
+		"{{fields}}", fields,
+		"{{args}}", strings.Join(args, ", "),
+		"{{assigns}}", assigns,
+		"{{print}}", strings.Join(prints, ` + " " + `),
+	).Replace(orgGo2jTupleTempl)
+}
+
+func init() {
+	for size := 2; size <= maxTupleSize; size++ {
+		helperClasses["org/go2j/util/"+tupleClass(size)+".java"] = tupleSource(size)
+	}
+}
@@ -0,0 +1,249 @@
+package main
+
+import (
+	"go/ast"
+	"strconv"
+)
+
+// go initializes the imported packages first, then the package variables in dependency order,
+// then runs the init functions in file order. the package class does the same in a static
+// initializer at its end, package variables with a constant or self contained value keep their
+// field initializer
+
+type PackageInit struct {
+	className string
+	vars      []*VarInit
+	inits     []string
+	imports   []string
+	funcs     map[string]*ast.FuncDecl
+	endOut    *Output
+}
+
+// VarInit is the converted assignment of a package variable
+type VarInit struct {
+	name  string
+	value ast.Expr
+	out   *InsertableOut
+}
+
+var oPackageInits = map[string]*PackageInit{}
+var oPackageInitOrder = []string{}
+
+// initPackageName is the empty method other packages call to initialize the package first
+const initPackageName = "init$"
+
+func packageInitOf(out *Output) *PackageInit {
+	className := out.outSource.getFullyQualifiedName()
+	pkgInit := oPackageInits[className]
+	if pkgInit == nil {
+		pkgInit = &PackageInit{className: className, funcs: map[string]*ast.FuncDecl{}}
+		oPackageInits[className] = pkgInit
+		oPackageInitOrder = append(oPackageInitOrder, className)
+	}
+	return pkgInit
+}
+
+// setPackageEnd remembers the end of the package class, the last converted file of the package sets it
+func setPackageEnd(out *Output) {
+	packageInitOf(out).endOut = out
+}
+
+func addPackageImport(className string, out *Output) {
+	pkgInit := packageInitOf(out)
+	for _, name := range pkgInit.imports {
+		if name == className {
+			return
+		}
+	}
+	pkgInit.imports = append(pkgInit.imports, className)
+}
+
+// registerPackageFunc records the functions of the package, variables depend on the variables their functions use
+func registerPackageFunc(funcDecl *ast.FuncDecl, out *Output) {
+	if funcDecl.Recv == nil && funcDecl.Body != nil {
+		packageInitOf(out).funcs[funcDecl.Name.Name] = funcDecl
+	}
+}
+
+// initFuncName names an init function, a package may have several
+func initFuncName(out *Output) string {
+	pkgInit := packageInitOf(out)
+	name := "init$" + strconv.Itoa(len(pkgInit.inits)+1)
+	pkgInit.inits = append(pkgInit.inits, name)
+	return name
+}
+
+// isPackageValue tells whether an identifier may refer to a package variable or function
+func isPackageValue(ident *ast.Ident, out *Output) bool {
+	if ident.Obj != nil {
+		// the locals and parameters of the same name are not package values
+		return (ident.Obj.Kind == ast.Var || ident.Obj.Kind == ast.Fun) && oPackageObjects[ident.Obj]
+	}
+	switch ident.Name {
+	case "nil", "true", "false", "iota", "_":
+		return false
+	}
+	// declared in an other file of the package
+	return !isTypeName(ident.Name) && !isConstExpr(ident) && !isImportName(ident.Name, out)
+}
+
+// packageValues calls fn with the identifiers of an expression that may be package variables or functions
+func packageValues(node ast.Node, out *Output, fn func(ident *ast.Ident)) {
+	ast.Inspect(node, func(node ast.Node) bool {
+		switch tp := node.(type) {
+		case *ast.SelectorExpr:
+			if ident, ok := tp.X.(*ast.Ident); ok && isImportName(ident.Name, out) {
+				// imported packages are initialized before
+				return false
+			}
+			packageValues(tp.X, out, fn)
+			return false
+		case *ast.KeyValueExpr:
+			if ident, ok := tp.Key.(*ast.Ident); !ok || ident.Obj != nil {
+				packageValues(tp.Key, out, fn)
+			}
+			packageValues(tp.Value, out, fn)
+			return false
+		case *ast.Ident:
+			if isPackageValue(tp, out) {
+				fn(tp)
+			}
+		}
+		return true
+	})
+}
+
+// needsStaticInit tells whether the value of a package variable depends on other package values or has side effects
+func needsStaticInit(value ast.Expr, out *Output) bool {
+	if isConstExpr(value) {
+		return false
+	}
+	if hasCall(value, out) {
+		return true
+	}
+	found := false
+	packageValues(value, out, func(ident *ast.Ident) {
+		found = true
+	})
+	return found
+}
+
+// addVarInit converts the assignment of a package variable for the static initializer
+func addVarInit(name *ast.Ident, value ast.Expr, out *Output) {
+	initOut := out.NewIndependentOutput().AddTab().AddTab()
+	convertIdent(name, initOut)
+	initOut.Print(" = ")
+	convertExpr(value, initOut)
+	convertStmtEnd(initOut)
+	pkgInit := packageInitOf(out)
+	pkgInit.vars = append(pkgInit.vars, &VarInit{name.Name, value, initOut.out})
+}
+
+// dependencies returns the package variables a value uses directly or through the functions it calls
+func (pkgInit *PackageInit) dependencies(varInit *VarInit, names map[string]bool, out *Output) map[string]bool {
+	deps := map[string]bool{}
+	visited := map[string]bool{}
+	var visit func(node ast.Node)
+	visit = func(node ast.Node) {
+		packageValues(node, out, func(ident *ast.Ident) {
+			if names[ident.Name] {
+				deps[ident.Name] = true
+			} else if funcDecl := pkgInit.funcs[ident.Name]; funcDecl != nil && !visited[ident.Name] {
+				visited[ident.Name] = true
+				visit(funcDecl.Body)
+			}
+		})
+	}
+	visit(varInit.value)
+	return deps
+}
+
+// sortedVars orders the variables like go: the earliest declared variable whose dependencies are initialized is next
+func (pkgInit *PackageInit) sortedVars() []*VarInit {
+	names := map[string]bool{}
+	for _, varInit := range pkgInit.vars {
+		names[varInit.name] = true
+	}
+	deps := map[*VarInit]map[string]bool{}
+	for _, varInit := range pkgInit.vars {
+		deps[varInit] = pkgInit.dependencies(varInit, names, pkgInit.endOut)
+	}
+	sorted := []*VarInit{}
+	done := map[*VarInit]bool{}
+	for len(sorted) < len(pkgInit.vars) {
+		var next *VarInit
+		for _, varInit := range pkgInit.vars {
+			if done[varInit] {
+				continue
+			}
+			ready := true
+			for name := range deps[varInit] {
+				ready = ready && (!names[name] || name == varInit.name)
+			}
+			if ready {
+				next = varInit
+				break
+			}
+		}
+		if next == nil {
+			// initialization cycle, go rejects it
+			for _, varInit := range pkgInit.vars {
+				if !done[varInit] {
+					next = varInit
+					break
+				}
+			}
+		}
+		done[next] = true
+		delete(names, next.name)
+		sorted = append(sorted, next)
+	}
+	return sorted
+}
+
+// hasInit tells whether a package class has a static initializer
+func hasInit(className string, visiting map[string]bool) bool {
+	pkgInit := oPackageInits[className]
+	if pkgInit == nil || visiting[className] {
+		return false
+	}
+	if len(pkgInit.vars) > 0 || len(pkgInit.inits) > 0 {
+		return true
+	}
+	visiting[className] = true
+	for _, importName := range pkgInit.imports {
+		if hasInit(importName, visiting) {
+			return true
+		}
+	}
+	return false
+}
+
+// convertPackageInits adds the static initializers to the end of the package classes
+func convertPackageInits() {
+	for _, className := range oPackageInitOrder {
+		pkgInit := oPackageInits[className]
+		if pkgInit.endOut == nil || !hasInit(className, map[string]bool{}) {
+			continue
+		}
+		out := pkgInit.endOut.getPosition().getOut()
+		out.Println("public static void " + initPackageName + "() {")
+		out.Println("}")
+		out.Println("")
+		out.Println("static {")
+		blockOut := out.AddTab()
+		for _, importName := range pkgInit.imports {
+			if hasInit(importName, map[string]bool{}) {
+				blockOut.Println(getClassPart(importName) + "." + initPackageName + "();")
+			}
+		}
+		for _, varInit := range pkgInit.sortedVars() {
+			blockOut.getPosition().insertOut = varInit.out
+		}
+		for _, name := range pkgInit.inits {
+			blockOut.Println(name + "();")
+		}
+		out.Println("}")
+		out.Println("")
+	}
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// the package variables are initialized in declaration order when their functions only use locals of the same names
+func TestInitOrderIgnoresLocals(t *testing.T) {
+	tr := translate(t, map[string]string{"app/main.go": `package main
+
+import "fmt"
+
+var total = sum(1)
+
+var n = count()
+
+func sum(k int) int {
+	n := 3
+	return n * k
+}
+
+func count() int {
+	return 4
+}
+
+func main() {
+	fmt.Println(total, n)
+}
+`})
+	source := tr.java(t, "app/Main.java")
+	expectContains(t, source, "total = sum(1);", "n = count();")
+	if strings.Index(source, "total = sum(1);") > strings.Index(source, "n = count();") {
+		t.Errorf("expected total initialized before n in\n%s", source)
+	}
+}
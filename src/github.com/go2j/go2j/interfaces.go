@@ -0,0 +1,204 @@
+package main
+
+import (
+	"go/ast"
+	"go/types"
+	"sort"
+	"strings"
+)
+
+// interfaces are satisfied implicitly in go, the implements clauses of the
+// java classes are computed from the method sets of the converted types
+
+func typeString(expr ast.Expr) string {
+	switch tp := expr.(type) {
+	case *ast.Ident:
+		return tp.Name
+	case *ast.StarExpr:
+		return "*" + typeString(tp.X)
+	case *ast.SelectorExpr:
+		// package qualifiers differ between the declaring packages
+		return tp.Sel.Name
+	case *ast.ArrayType:
+		if tp.Len == nil {
+			return "[]" + typeString(tp.Elt)
+		}
+		return "[" + types.ExprString(tp.Len) + "]" + typeString(tp.Elt)
+	case *ast.Ellipsis:
+		return "..." + typeString(tp.Elt)
+	case *ast.MapType:
+		return "map[" + typeString(tp.Key) + "]" + typeString(tp.Value)
+	case *ast.ChanType:
+		return "chan " + typeString(tp.Value)
+	case *ast.FuncType:
+		return "func" + signatureOf(tp)
+	}
+	return types.ExprString(expr)
+}
+
+func fieldListString(fieldList *ast.FieldList) string {
+	if fieldList == nil {
+		return ""
+	}
+	typeNames := []string{}
+	for _, field := range fieldList.List {
+		count := len(field.Names)
+		if count == 0 {
+			count = 1
+		}
+		for i := 0; i < count; i++ {
+			typeNames = append(typeNames, typeString(field.Type))
+		}
+	}
+	return strings.Join(typeNames, ", ")
+}
+
+func signatureOf(funcType *ast.FuncType) string {
+	return "(" + fieldListString(funcType.Params) + ") (" + fieldListString(funcType.Results) + ")"
+}
+
+// methodSet returns the signatures of the declared and promoted methods of a type
+func (outTypes *OutTypes) methodSet(key string) map[string]string {
+	methods := map[string]string{}
+	outType := lookupType(key)
+	if outType == nil {
+		return methods
+	}
+	for _, member := range outType.members() {
+		if member.funcType != nil {
+			methods[member.name] = signatureOf(member.funcType)
+		}
+	}
+	for _, member := range outTypes.promotedMembers(key) {
+		if member.funcType != nil {
+			methods[member.name] = signatureOf(member.funcType)
+		}
+	}
+	return methods
+}
+
+func (outTypes *OutTypes) satisfies(key, interfaceKey string) bool {
+	outInterface := lookupType(interfaceKey)
+	if outInterface == nil || outInterface.interfaceType == nil {
+		return false
+	}
+	interfaceMethods := outInterface.members()
+	if len(interfaceMethods) == 0 {
+		// every type satisfies the empty interface, it is translated to Object
+		return false
+	}
+	methods := outTypes.methodSet(key)
+	for _, member := range interfaceMethods {
+		if signature, has := methods[member.name]; !has || signature != signatureOf(member.funcType) {
+			return false
+		}
+	}
+	return true
+}
+
+// addConversion notes a conversion by the java class names of the types
+func (outTypes *OutTypes) addConversion(typeName, interfaceName string) {
+	typeName, interfaceName = getClassPart(typeName), getClassPart(interfaceName)
+	if outTypes.conversions[typeName] == nil {
+		outTypes.conversions[typeName] = map[string]bool{}
+	}
+	outTypes.conversions[typeName][interfaceName] = true
+}
+
+// recordConversion notes that the value of expr is used as typeExpr
+func recordConversion(expr ast.Expr, typeExpr ast.Expr, out *Output) {
+	if expr == nil || typeExpr == nil || !isInterfaceType(typeExpr) {
+		return
+	}
+	recordInterfaceValue(expr, out)
+	nrto := newResolveTypeOpts()
+	nrto.DirectEval = true
+	newOut := out.NewIndependentOutput()
+	resolveType(expr, newOut, nrto)
+	valueTypeName := newOut.out.buf.String()
+	newOut = out.NewIndependentOutput()
+	convertType(typeExpr, newOut, nrto)
+	typeName := newOut.out.buf.String()
+	if valueTypeName != typeName {
+		oTypes.addConversion(valueTypeName, typeName)
+	}
+}
+
+func (outTypes *OutTypes) localKeys() []string {
+	keys := []string{}
+	for key, outType := range outTypes.set {
+		if !outType.system && (outType.structType != nil || outType.interfaceType != nil || hasValueClass(key)) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// resolveImplementations declares the implemented interfaces of every converted struct and named type
+func resolveImplementations() {
+	keys := oTypes.localKeys()
+	for _, key := range keys {
+		outType := oTypes.get(key)
+		if outType.structType == nil && !hasValueClass(key) || outType.implementsIP == nil {
+			continue
+		}
+		for _, interfaceKey := range keys {
+			if *implUsedOnly && !oTypes.conversions[typeKeyName(key)][typeKeyName(interfaceKey)] {
+				continue
+			}
+			if oTypes.satisfies(key, interfaceKey) {
+				oTypes.declareImplements(key, interfaceKey)
+			}
+		}
+		declareErrorInterfaces(key)
+		declareSortInterface(key)
+		declareValueInterface(key)
+	}
+}
+
+// overrides tells whether a method of a type implements a method of one of its interfaces
+func (outTypes *OutTypes) overrides(key, methodName string) bool {
+	outType := lookupType(key)
+	if outType == nil {
+		return false
+	}
+	for interfaceName := range outType.implements {
+		if isErrorInterfaceMethod(interfaceName, methodName) || isSortInterfaceMethod(interfaceName, methodName) {
+			return true
+		}
+		outInterface := lookupType(interfaceName)
+		if outInterface == nil || outInterface.interfaceType == nil {
+			continue
+		}
+		for _, member := range outInterface.members() {
+			if member.name == methodName {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func convertMethodModifiers(key string, name *ast.Ident, out *Output) {
+	if oTypes.overrides(key, name.Name) {
+		out.Println("@Override")
+		// interface methods are public in java
+		out.Print("public ")
+	} else {
+		convertExport(name, out)
+	}
+}
+
+// convertMethodModifiersPostEval prints the annotations and the access modifier of a method
+// after the implemented interfaces are known
+func convertMethodModifiersPostEval(key string, name *ast.Ident, out *Output) {
+	tabs := out.tabs
+	out.Print("")
+	pos := out.getPosition()
+	pos.postEvalFn = func(postOut *Output) {
+		postOut.tabs = tabs
+		postOut.needTabs = false
+		convertMethodModifiers(key, name, postOut)
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import "testing"
+
+// with -implused the types implement the interfaces they are converted to at any place of a value
+func TestImplementsUsedConversions(t *testing.T) {
+	tr := translate(t, map[string]string{"app/main.go": `package main
+
+import "fmt"
+
+type Shape interface {
+	Area() int
+}
+
+type Sq struct{ s int }
+type Rect struct{ w, h int }
+type Tri struct{ b, h int }
+type Hex struct{ s int }
+type Oct struct{ s int }
+type Pent struct{ s int }
+type Unused struct{ s int }
+
+func (q Sq) Area() int     { return q.s * q.s }
+func (r Rect) Area() int   { return r.w * r.h }
+func (t Tri) Area() int    { return t.b * t.h / 2 }
+func (h Hex) Area() int    { return h.s * 6 }
+func (o Oct) Area() int    { return o.s * 8 }
+func (p Pent) Area() int   { return p.s * 5 }
+func (u Unused) Area() int { return u.s }
+
+type Holder struct {
+	shape Shape
+}
+
+func draw(s Shape) {
+	fmt.Println(s.Area())
+}
+
+func drawAll(shapes ...Shape) {
+	for _, s := range shapes {
+		draw(s)
+	}
+}
+
+func main() {
+	var s Shape
+	s = Sq{2}
+	draw(s)
+	draw(Rect{2, 3})
+	drawAll(Tri{2, 3})
+	shapes := []Shape{Hex{1}}
+	h := Holder{shape: Oct{1}}
+	m := map[string]Shape{"p": Pent{1}}
+	fmt.Println(len(shapes), h.shape.Area(), len(m), Unused{1}.Area())
+}
+`}, "-implused")
+	for _, name := range []string{"Sq", "Rect", "Tri", "Hex", "Oct", "Pent"} {
+		expectContains(t, tr.java(t, "app/"+name+".java"), "public class "+name+" implements Shape, GoObjects.Value {")
+	}
+	expectContains(t, tr.java(t, "app/Unused.java"), "public class Unused implements GoObjects.Value {")
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// go literals are decoded and encoded again as java literals, the go syntax is not valid java in general
+
+func convertBasicLit(basicLit *ast.BasicLit, out *Output) {
+	switch basicLit.Kind {
+	case token.INT:
+		out.Print(javaIntLit(basicLit.Value))
+	case token.FLOAT:
+		// java has hexadecimal floats, but no digit separators before java 7
+		out.Print(strings.ReplaceAll(basicLit.Value, "_", ""))
+	case token.IMAG:
+		out.Error(basicLit, "complex numbers are not supported: "+basicLit.Value)
+		out.Print(basicLit.Value)
+	case token.CHAR:
+		out.Print(javaRuneLit(basicLit.Value))
+	case token.STRING:
+		value, err := strconv.Unquote(basicLit.Value)
+		if err != nil {
+			out.Print(basicLit.Value)
+			return
+		}
+		if strings.HasPrefix(basicLit.Value, "`") && strings.Contains(value, "\n") {
+			convertMultilineStringLit(value, out)
+		} else {
+			out.Print(javaQuote(value))
+		}
+	}
+}
+
+// javaIntLit keeps decimal and hexadecimal literals, octal and binary literals are written in java syntax
+func javaIntLit(literal string) string {
+	value := constant.MakeFromLiteral(literal, token.INT, 0)
+	if value.Kind() != constant.Int {
+		return literal
+	}
+	if !fitsInt(value) {
+		return javaConstLiteral(value, "long")
+	}
+	literal = strings.ReplaceAll(literal, "_", "")
+	lower := strings.ToLower(literal)
+	switch {
+	case strings.HasPrefix(lower, "0x"):
+		return literal
+	case strings.HasPrefix(lower, "0o"):
+		return "0" + literal[2:]
+	case strings.HasPrefix(lower, "0b"):
+		return value.ExactString()
+	}
+	return literal
+}
+
+// javaRuneLit writes runes of the basic multilingual plane as char literals, others as their code point
+func javaRuneLit(literal string) string {
+	value := constant.MakeFromLiteral(literal, token.CHAR, 0)
+	r, exact := constant.Int64Val(value)
+	if !exact {
+		return literal
+	}
+	if r > 0xffff || (r >= 0xd800 && r <= 0xdfff) {
+		return strconv.FormatInt(r, 10)
+	}
+	return "'" + javaEscapeRune(rune(r), '\'') + "'"
+}
+
+// convertMultilineStringLit writes multiline raw strings line by line or as a text block
+func convertMultilineStringLit(value string, out *Output) {
+	if *textBlocksFlag {
+		out.Print(javaTextBlock(value))
+		return
+	}
+	lines := strings.SplitAfter(value, "\n")
+	for idx, line := range lines {
+		if idx > 0 {
+			out.Print(" +\n")
+		}
+		if line != "" || idx == 0 {
+			out.Print(javaQuote(line))
+		} else {
+			out.Print(`""`)
+		}
+	}
+}
+
+// javaTextBlock encodes a string as a java 15 text block, the content starts at the first column so no indentation is stripped
+func javaTextBlock(value string) string {
+	var sb strings.Builder
+	sb.WriteString("\"\"\"\n")
+	lines := strings.Split(value, "\n")
+	for idx, line := range lines {
+		if idx > 0 {
+			sb.WriteString("\n")
+		}
+		// trailing white space is stripped from the lines of text blocks
+		trimmed := strings.TrimRight(line, " \t")
+		trailing := line[len(trimmed):]
+		for len(trimmed) > 0 {
+			r, size := utf8.DecodeRuneInString(trimmed)
+			switch {
+			case r == utf8.RuneError && size == 1:
+				sb.WriteString(javaUnicodeEscape(0xdc00 + rune(trimmed[0])))
+			case r == '"' && strings.HasPrefix(trimmed, `"""`):
+				sb.WriteString(`\"`)
+			case r == '"':
+				sb.WriteString(`"`)
+			default:
+				sb.WriteString(javaEscapeRune(r, '"'))
+			}
+			trimmed = trimmed[size:]
+		}
+		if trailing != "" {
+			sb.WriteString(trailing[:len(trailing)-1])
+			if trailing[len(trailing)-1] == ' ' {
+				sb.WriteString(`\s`)
+			} else {
+				sb.WriteString(`\t`)
+			}
+		}
+	}
+	// the escaped line end leaves out the last new line
+	sb.WriteString("\\\n\"\"\"")
+	return sb.String()
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// complex numbers fail the translation at the position of the literal
+func TestComplexLiteralError(t *testing.T) {
+	tr := translate(t, map[string]string{"app/main.go": `package main
+
+import "fmt"
+
+func main() {
+	c := 1 + 2i
+	fmt.Println(c)
+}
+`})
+	if !tr.failed || !strings.Contains(tr.stderr, "main.go:6:11: error: complex numbers are not supported: 2i") {
+		t.Errorf("expected an error of the literal, got %v\n%s", tr.failed, tr.stderr)
+	}
+	if _, has := tr.files["app/Main.java"]; has {
+		t.Errorf("java source written despite the error")
+	}
+}
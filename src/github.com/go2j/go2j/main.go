@@ -21,20 +21,26 @@ var versionFlag *bool = flag.Bool("v", false, "Print the version number.")
 var printSource *bool = flag.Bool("psrc", false, "Print generated sources.")
 var goSrcDir *string = flag.String("gs", "", "Go absolute source path. Required.")
 var javaSrcDir *string = flag.String("js", "", "Java absolute source path, full. Required.")
+var implUsedOnly *bool = flag.Bool("implused", false, "Declare only the implemented interfaces the types are converted to.")
+var enumsFlag *bool = flag.Bool("enums", false, "Convert typed iota constant groups with a String method to java enums, if the values of the type are only its constants.")
+var textBlocksFlag *bool = flag.Bool("textblocks", false, "Convert multiline raw strings to java text blocks (java 15).")
+var exceptionsFlag *bool = flag.Bool("exceptions", false, "Add a method throwing GoException on a non-nil error to the exported functions returning an error.")
+var mapFlag *string = flag.String("map", "", "Comma separated list of api and type mapping files, applied after the go2j.json of the project.")
 var goRoot = ""
 
-var oFileSet = &OutFileSet{map[string]*OutSource{}, "", map[string]*OutSource{}, map[string]*Package{}, map[string]bool{}, map[string]string{}, map[string]string{}, false}
-var oTypes = &OutTypes{map[string]*OutType{}}
+var oFileSet = &OutFileSet{map[string]*OutSource{}, "", "", map[string]*OutSource{}, map[string]*Package{}, map[string]bool{}, map[string]string{}, map[string]string{}, false}
+var oTypes = &OutTypes{map[string]*OutType{}, map[string][]string{}, map[string]map[string]bool{}}
 
 type OutFileSet struct {
-	set            map[string]*OutSource
-	currentPackage string
-	classNameSet   map[string]*OutSource
-	packageSet     map[string]*Package
-	sysPkgs        map[string]bool
-	sysImportNames map[string]string
-	typeAliases    map[string]string
-	postEvalPhase  bool
+	set                map[string]*OutSource
+	currentPackage     string
+	currentPackageName string
+	classNameSet       map[string]*OutSource
+	packageSet         map[string]*Package
+	sysPkgs            map[string]bool
+	sysImportNames     map[string]string
+	typeAliases        map[string]string
+	postEvalPhase      bool
 }
 
 type OutSource struct {
@@ -48,21 +54,40 @@ type OutSource struct {
 	system           bool
 }
 
+type SourceFile struct {
+	absPath string
+	fset    *token.FileSet
+	file    *ast.File
+}
+
 type Package struct {
 	name            string
 	VariableTypes   map[string]ast.Expr
 	FuncReturnTypes map[string]ast.Expr
 }
 
+// OutTypes keys the types by their package and name, see typeKey
 type OutTypes struct {
-	set map[string]*OutType
+	set         map[string]*OutType
+	names       map[string][]string
+	conversions map[string]map[string]bool
 }
 
 type OutType struct {
-	functionsIP   *InsertPoint
-	implementsIP  *InsertPoint
-	anyImplements bool
-	implements    map[string]bool
+	functionsIP    *InsertPoint
+	implementsIP   *InsertPoint
+	anyImplements  bool
+	implements     map[string]bool
+	pkgName        string
+	system         bool
+	typeSpec       *ast.TypeSpec
+	structType     *ast.StructType
+	interfaceType  *ast.InterfaceType
+	valueType      ast.Expr // the underlying type of the other named types
+	methods        map[string]*ast.FuncDecl
+	// the receiver kinds of the methods, the class has the methods of both kinds
+	pointerMethods map[string]bool
+	pendingMethods []*PendingMethod
 }
 
 type ResolveTypeOpts struct {
@@ -71,12 +96,13 @@ type ResolveTypeOpts struct {
 	PrimitiveAsObject   bool
 	DirectEval          bool
 	FunctionAsReference bool
+	ValueClass          bool
 	structuralInfo      *StructuralInfo
 }
 
 func (outTypes *OutTypes) ensure(typeName string) {
 	if outTypes.set[typeName] == nil {
-		outTypes.set[typeName] = &OutType{nil, nil, false, map[string]bool{}}
+		outTypes.set[typeName] = &OutType{nil, nil, false, map[string]bool{}, "", false, nil, nil, nil, nil, map[string]*ast.FuncDecl{}, map[string]bool{}, nil}
 	}
 }
 
@@ -123,6 +149,72 @@ func (outTypes *OutTypes) hasImpemented(typeName, implementedTypeName string) bo
 	return outTypes.set[typeName].implements[implementedTypeName]
 }
 
+func (outTypes *OutTypes) declareImplements(typeName, implementedTypeName string) {
+	implementsIP := outTypes.getImplementsPos(typeName)
+	if implementsIP == nil || outTypes.hasImpemented(typeName, implementedTypeName) {
+		return
+	}
+	outImplements := implementsIP.getOut()
+	outImplements.needTabs = false
+	if !outTypes.getAnyImplements(typeName) {
+		outImplements.Print(" implements ")
+	} else {
+		outImplements.Print(", ")
+	}
+	if implemented := outTypes.get(implementedTypeName); implemented != nil && !implemented.system {
+		// interfaces of the project are keyed by their package
+		name := typeKeyName(implementedTypeName)
+		outSource := implementsIP.origOut.outSource
+		if isAmbiguousClass(name) {
+			outImplements.Print(convertPath(implemented.pkgName), ".", name)
+		} else {
+			if implemented.pkgName != outSource.path {
+				outSource.addImportedClass(implemented.pkgName + "/" + name)
+			}
+			outImplements.Print(name)
+		}
+	} else {
+		outImplements.Print(implementedTypeName)
+	}
+	outTypes.addImplements(typeName, implementedTypeName)
+}
+
+func (outTypes *OutTypes) setTypeSpec(typeName, pkgName string, system bool, typeSpec *ast.TypeSpec) {
+	outTypes.ensure(typeName)
+	outType := outTypes.set[typeName]
+	if !system && outType.typeSpec == nil {
+		name := strings.Title(typeSpec.Name.Name)
+		outTypes.names[name] = append(outTypes.names[name], typeName)
+	}
+	outType.typeSpec = typeSpec
+	outType.pkgName = pkgName
+	outType.system = system
+	switch tp := typeSpec.Type.(type) {
+	case *ast.StructType:
+		outType.structType = tp
+	case *ast.InterfaceType:
+		outType.interfaceType = tp
+	default:
+		outType.valueType = tp
+	}
+}
+
+func (outTypes *OutTypes) addMethod(typeName string, funcDecl *ast.FuncDecl) {
+	outTypes.ensure(typeName)
+	outTypes.set[typeName].methods[funcDecl.Name.Name] = funcDecl
+	outTypes.set[typeName].pointerMethods[funcDecl.Name.Name] = isPointerReceiver(funcDecl.Recv)
+}
+
+func (outTypes *OutTypes) get(typeName string) *OutType {
+	return outTypes.set[typeName]
+}
+
+// typeKey returns the oTypes key of a type declared in the package being converted.
+// System package types are qualified by their package name, the types of the project by their package path.
+func typeKey(name string, out *Output) string {
+	return qualifyTypeKey(currentPkgName(out), name)
+}
+
 func pathOf(path string) string {
 	pathTags := strings.Split(path, "/")
 	return strings.Join(pathTags[:len(pathTags)-1], "/")
@@ -176,13 +268,39 @@ func (outSource *OutSource) addSysImportName(name, path string) {
 	oFileSet.sysImportNames[name] = path
 }
 
-func (outSource *OutSource) addImportedClass(name string) {
-	outSource.importedClasses[name] = true
-	if oFileSet.classNameSet[name] != nil {
-		outSource.addImportName(name, oFileSet.classNameSet[name].getFullPackageName())
+// addImportedClass imports a class of the project by the path of its package and its name
+func (outSource *OutSource) addImportedClass(key string) {
+	outSource.importedClasses[key] = true
+	if classSource := classOf(key); classSource != nil {
+		outSource.addImportName(classSource.name, classSource.getFullPackageName())
 	}
 }
 
+// classOf returns the source of a class of the project, the types of the declarations of an other
+// package are found by name if no other package has a class of the name
+func classOf(key string) *OutSource {
+	if classSource := oFileSet.classNameSet[key]; classSource != nil {
+		return classSource
+	}
+	var found *OutSource
+	for otherKey, classSource := range oFileSet.classNameSet {
+		if fileNameOf(otherKey) != fileNameOf(key) {
+			continue
+		}
+		if found != nil {
+			return nil
+		}
+		found = classSource
+	}
+	return found
+}
+
+// isAmbiguousClass tells whether more packages of the project declare a type of a name, its
+// classes are referred to by qualified names
+func isAmbiguousClass(name string) bool {
+	return len(oTypes.names[strings.Title(name)]) > 1
+}
+
 func (outSource *OutSource) writeFile() {
 	outBytes := []byte(outSource.out.buf.String())
 	ioutil.WriteFile(outSource.getFullFileName(), outBytes, 0644)
@@ -238,6 +356,12 @@ func (insertPoint *InsertPoint) postEvaluate(outSource *OutSource) {
 	//			out.AddVar(insertPoint.postEvalIdent.Name, typeExpr)
 	//		}
 	//	}
+	// the package of the insert point resolves the names of its imports
+	currentPackage := oFileSet.currentPackage
+	oFileSet.currentPackage = insertPoint.pkgPath
+	defer func() {
+		oFileSet.currentPackage = currentPackage
+	}()
 	if insertPoint.postEvalStmtFn != nil {
 		out := insertPoint.getOut()
 		insertPoint.postEvalStmtFn(insertPoint.postEvalStmt, out)
@@ -275,6 +399,10 @@ func main() {
 		return
 	}
 	srcDirTags := strings.Split(srcDir, "/src")
+	if err := loadMappingFiles(mappingFiles(srcDirTags[0], *mapFlag)); err != nil {
+		fmt.Println("ERROR: mapping file", err)
+		return
+	}
 	addPrefix := ""
 	if len(srcDirTags) > 1 {
 		lastTag := srcDirTags[len(srcDirTags)-1]
@@ -284,19 +412,31 @@ func main() {
 	}
 
 	fileList := FileList(srcDir, "", "")
+	sourceFiles := []*SourceFile{}
 	for _, path := range fileList {
-		convertSourceFile(path, strings.TrimSuffix(srcDir+"/", addPrefix))
+		sourceFiles = append(sourceFiles, parseSourceFile(path))
+	}
+	trimPrefix := strings.TrimSuffix(srcDir+"/", addPrefix)
+	collectDecls(sourceFiles, trimPrefix)
+	if *enumsFlag {
+		collectEnums(sourceFiles, trimPrefix)
 	}
+	for _, sourceFile := range sourceFiles {
+		convertSourceFile(sourceFile, trimPrefix)
+	}
+	convertPackageInits()
 
 	// set referenced classes to imported classes
 	for _, outSource := range oFileSet.set {
 		for importClass, _ := range outSource.importedClasses {
-			if oFileSet.classNameSet[importClass] != nil {
-				outSource.addImportName(importClass, oFileSet.classNameSet[importClass].getFullPackageName())
+			if classSource := classOf(importClass); classSource != nil {
+				outSource.addImportName(classSource.name, classSource.getFullPackageName())
 			}
 		}
 	}
 
+	resolveImplementations()
+
 	oFileSet.postEvalPhase = true
 	// resolve variable types
 	for _, outSource := range oFileSet.set {
@@ -309,9 +449,15 @@ func main() {
 	// add import declarations
 	for _, outSource := range oFileSet.set {
 		importsOut := outSource.importsIP.getOut()
+		// a class may be referred to from its package and from the package of a declaration using it
+		imported := map[*OutSource]bool{}
 		for importClass, _ := range outSource.importedClasses {
-			if oFileSet.classNameSet[importClass] != nil {
-				importsOut.Print("import ", oFileSet.classNameSet[importClass].getFullyQualifiedName())
+			if classSource := classOf(importClass); classSource != nil {
+				if imported[classSource] {
+					continue
+				}
+				imported[classSource] = true
+				importsOut.Print("import ", classSource.getFullyQualifiedName())
 				importsOut.Println(";")
 			} else if oFileSet.sysImportNames[importClass] != "" {
 				importsOut.Print("import ", oFileSet.sysImportNames[importClass])
@@ -325,6 +471,10 @@ func main() {
 		outSource.closeFile()
 
 	}
+	if len(oErrors) > 0 {
+		fmt.Fprintln(os.Stderr, "ERROR:", len(oErrors), "errors, no java sources written")
+		os.Exit(1)
+	}
 
 	if targetDir != "" {
 		os.MkdirAll(targetDir, 0755)
@@ -379,6 +529,11 @@ func parseSystemImport(name string) {
 		return
 	}
 	oFileSet.sysPkgs[name] = true
+	// the importing package is converted further
+	currentPackage, currentPackageName := oFileSet.currentPackage, oFileSet.currentPackageName
+	defer func() {
+		oFileSet.currentPackage, oFileSet.currentPackageName = currentPackage, currentPackageName
+	}()
 	sysSrcDir := goRoot + "/src/" + name
 	fileList := FileList(sysSrcDir, "", "")
 	for _, path := range fileList {
@@ -443,6 +598,7 @@ func parseSystemSourceFile(absPath, trimPrefix string) {
 	outSource, isNewPackage := oFileSet.newOutFile(packagePath, true, true)
 	out := newOutput(fset, outSource)
 	oFileSet.currentPackage = getPackagePath(packagePath)
+	oFileSet.currentPackageName = file.Name.Name
 	if isNewPackage {
 
 		for _, importSpec := range file.Imports {
@@ -464,14 +620,19 @@ func parseSystemSourceFile(absPath, trimPrefix string) {
 
 }
 
-func convertSourceFile(absPath, trimPrefix string) {
-	path := strings.TrimPrefix(absPath, trimPrefix)
+func parseSourceFile(absPath string) *SourceFile {
 	fset := token.NewFileSet()
 	file, err := parser.ParseFile(fset, absPath, nil, parser.ParseComments)
 	if err != nil {
 		log.Fatal(err)
-		return
 	}
+	return &SourceFile{absPath, fset, file}
+}
+
+func convertSourceFile(sourceFile *SourceFile, trimPrefix string) {
+	path := strings.TrimPrefix(sourceFile.absPath, trimPrefix)
+	fset := sourceFile.fset
+	file := sourceFile.file
 
 	packagePath := convertPackageFileName(path, file.Name.Name)
 	//fmt.Println("pkg path:", packagePath)
@@ -480,6 +641,7 @@ func convertSourceFile(absPath, trimPrefix string) {
 	outSource, isNewPackage := oFileSet.newOutFile(packagePath, true, false)
 	out := newOutput(fset, outSource)
 	oFileSet.currentPackage = getPackagePath(packagePath)
+	oFileSet.currentPackageName = file.Name.Name
 	if isNewPackage {
 		convertPackageHeader(packagePath, out)
 
@@ -490,9 +652,11 @@ func convertSourceFile(absPath, trimPrefix string) {
 		out.Println()
 
 		convertClassHeader(packagePath, out)
+		oAnonStructs.setPackagePos(out.AddTab().getPosition())
 		oFileSet.set[packagePath] = outSource
-		pkg := newPackage(strings.Title(file.Name.Name))
-		oFileSet.packageSet[outSource.getFullPackageName()] = pkg
+		if outSource.getPackage() == nil {
+			oFileSet.packageSet[outSource.getFullPackageName()] = newPackage(strings.Title(file.Name.Name))
+		}
 		//fmt.Println("ofspkg:", outSource.getFullFileName(), strings.Title(file.Name.Name))
 	}
 
@@ -501,6 +665,7 @@ func convertSourceFile(absPath, trimPrefix string) {
 	for _, decl := range file.Decls {
 		convertDecl(decl, out)
 	}
+	setPackageEnd(out)
 
 }
 
@@ -510,7 +675,7 @@ func convertImportSpec(importSpec *ast.ImportSpec, out *Output) {
 	if ownPackage {
 		// own package
 		importSpecPath += "/" + strings.Title(fileNameOf(importSpecPath))
-	} else {
+	} else if !isApiPackage(importSpecPath) {
 		parseSystemImport(fileNameOf(importSpecPath))
 	}
 	importPath := convertClassName(importSpecPath)
@@ -523,6 +688,7 @@ func convertImportSpec(importSpec *ast.ImportSpec, out *Output) {
 		out.Print("import ")
 		out.Print(importPath)
 		out.Println(";")
+		addPackageImport(importPath, out)
 		out.outSource.addImportName(importName, importSpecPath)
 	} else {
 		out.outSource.addImportName(importName, "/"+strings.Title(importSpecPath))
@@ -539,10 +705,23 @@ func convertPackageHeader(packagePath string, out *Output) {
 }
 
 func convertTypeSpec(typeSpec *ast.TypeSpec, out *Output) {
+	if enumData := oEnums.set[typeKey(typeSpec.Name.Name, out)]; enumData != nil && !out.outSource.system {
+		convertEnum(typeSpec, enumData, out)
+		return
+	}
 
+	key := typeKey(typeSpec.Name.Name, out)
 	switch tp := typeSpec.Type.(type) {
 	case *ast.Ident:
 		oFileSet.typeAliases[typeSpec.Name.Name] = tp.Name
+		if !hasValueClass(key) {
+			return
+		}
+	default:
+		oTypes.setTypeSpec(key, currentPkgName(out), out.outSource.system, typeSpec)
+	}
+	if outType := oTypes.get(key); outType.valueType != nil && !outType.system && !hasValueClass(key) {
+		// named types without methods are their underlying types
 		return
 	}
 	// TODO: convert Capital letter type to external file
@@ -550,6 +729,10 @@ func convertTypeSpec(typeSpec *ast.TypeSpec, out *Output) {
 	if typeSpec.Name.IsExported() {
 		out = toNewFile(typeSpec.Name.Name, out)
 	}
+	if hasValueClass(key) {
+		convertValueClass(typeSpec, out)
+		return
+	}
 	convertNamedExpr(typeSpec.Type, typeSpec.Name, out)
 }
 
@@ -560,35 +743,69 @@ func convertRecv(fieldList *ast.FieldList, out *Output) *Output {
 	}
 	field := fieldList.List[0]
 
-	if len(field.Names) == 0 {
-		return out
-	}
-	typeName := resolveTypeName(field.Type, true)
+	typeName := receiverTypeName(fieldList, true)
 	if typeName != "" {
-		outPos := oTypes.getFunctionsPos(typeName)
+		outPos := oTypes.getFunctionsPos(typeKey(typeName, out))
 		if outPos != nil {
 			out = outPos.getOut()
-			out.SetReceiverTypeName(field.Names[0].Name)
+			// unnamed and blank receivers are not used by the body
+			if len(field.Names) > 0 && field.Names[0].Name != "_" {
+				recvType := field.Type
+				if starExpr, ok := recvType.(*ast.StarExpr); ok && hasValueClass(typeKey(typeName, out)) {
+					// pointer receivers update the value of the class
+					recvType = starExpr.X
+				}
+				out.SetReceiverTypeName(field.Names[0].Name)
+				out.AddVar(field.Names[0].Name, recvType)
+			}
 			return out
 		}
 	}
 	return out
 }
 
+func receiverKey(fieldList *ast.FieldList, out *Output) string {
+	typeName := receiverTypeName(fieldList, false)
+	if typeName == "" {
+		return ""
+	}
+	return typeKey(typeName, out)
+}
+
 func convertFuncDecl(funcDecl *ast.FuncDecl, out *Output) {
 	//func rcvr name params ret
+	if recvKey := receiverKey(funcDecl.Recv, out); recvKey != "" {
+		oTypes.addMethod(recvKey, funcDecl)
+		if oTypes.deferMethod(recvKey, funcDecl, out) {
+			return
+		}
+	}
+	registerPackageFunc(funcDecl, out)
 	out = convertRecv(funcDecl.Recv, out)
-	convertExport(funcDecl.Name, out)
+	funcName := funcDecl.Name.Name
+	if recvKey := receiverKey(funcDecl.Recv, out); recvKey != "" && oTypes.getFunctionsPos(recvKey) != nil {
+		convertMethodModifiersPostEval(recvKey, funcDecl.Name, out)
+	} else if funcDecl.Recv == nil && funcName == "init" {
+		// called by the static initializer only
+		funcName = initFuncName(out)
+		out.Print("private ")
+	} else {
+		convertExport(funcDecl.Name, out)
+	}
 	if funcDecl.Recv == nil {
 		out.Print("static ")
 	}
-	convertFuncType(funcDecl.Type, funcDecl.Name.Name, funcDecl.Name.IsExported(), out, newResolveTypeOpts())
+	convertFuncType(funcDecl.Type, funcName, funcDecl.Name.IsExported(), out, newResolveTypeOpts())
 	//out.Println(" {")
 	if funcDecl.Body != nil {
+		collectAddressTaken(funcDecl.Body)
 		out.SetCurrentFunctionName(funcDecl.Name.Name)
-		convertBlockStmt(funcDecl.Body, out, nil)
+		out.SetCurrentFuncType(funcDecl.Type)
+		out.SetCurrentFuncDecl(funcDecl)
+		convertBlockStmt(funcDecl.Body, out, joinDefsFns(convertVariadicParams(funcDecl.Type), convertNamedResults(funcDecl.Type)))
 		out.Println("")
 	}
+	convertOrThrow(funcDecl, funcName, out)
 	//out.Println("}")
 }
 
@@ -645,13 +862,20 @@ func convertStmt(stmt ast.Stmt, out *Output) {
 		convertReturnStmt(tp, out)
 	case *ast.RangeStmt:
 		convertRangeStmt(tp, out)
+	case *ast.SendStmt:
+		convertSendStmt(tp, out)
 	}
 }
 
 func convertCaseClause(caseClause *ast.CaseClause, out *Output) {
 	for _, expr := range caseClause.List {
 		out.Print("case ")
-		convertExpr(expr, out)
+		if ident, ok := expr.(*ast.Ident); ok && oEnums.constEnum(ident.Name, out) != nil {
+			// enum switch labels are unqualified
+			out.Print(ident.Name)
+		} else {
+			convertExpr(expr, out)
+		}
 		out.Println(":")
 	}
 	for _, stmt := range caseClause.Body {
@@ -671,13 +895,19 @@ func convertSwitchStmt(switchStmt *ast.SwitchStmt, out *Output) {
 }
 
 func convertIncDecStmt(incDecStmt *ast.IncDecStmt, out *Output) {
+	if convertSliceUpdate(incDecStmt.X, incDecStmt.Tok, nil, out) || convertMapAssign(incDecStmt.X, incDecStmt.Tok, nil, out) ||
+		convertCellAssign(incDecStmt.X, incDecStmt.Tok, nil, out) || convertEnumUpdate(incDecStmt.X, incDecStmt.Tok, nil, out) {
+		return
+	}
 	convertExpr(incDecStmt.X, out)
 	convertOp(incDecStmt.Tok, out)
 	convertStmtEnd(out)
 }
 
 func convertDeclStmt(declStmt *ast.DeclStmt, out *Output) {
+	out.structuralInfo.LocalDecl = true
 	convertDecl(declStmt.Decl, out)
+	out.structuralInfo.LocalDecl = false
 	convertStmtEnd(out)
 }
 
@@ -691,13 +921,11 @@ func convertDecl(decl ast.Decl, out *Output) {
 	}
 }
 
-type IotaData struct {
-	idx      int
-	active   bool
-	dataType ast.Expr
-}
-
 func convertGenDecl(decl *ast.GenDecl, out *Output) {
+	if oEnums.decls[decl] != nil {
+		// converted with its type
+		return
+	}
 	isConst := isConst(decl.Tok)
 	isImport := decl.Tok == token.IMPORT
 	needNewLineAtTheEnd := decl.Tok == token.VAR || decl.Tok == token.CONST
@@ -708,12 +936,13 @@ func convertGenDecl(decl *ast.GenDecl, out *Output) {
 		out.Print("(")
 	}
 
-	iotaData := &IotaData{0, false, nil}
+	constData := &ConstData{}
 	for idx, spec := range decl.Specs {
 		if needComma && idx > 0 {
 			out.Print(", ")
 		}
-		convertSpec(spec, isConst, out, iotaData)
+		constData.iota = int64(idx)
+		convertSpec(spec, isConst, out, constData)
 		//if needNewLine {
 		//	out.Println("")
 		//}
@@ -730,71 +959,76 @@ func isConst(tok token.Token) bool {
 	return tok.String() == "const"
 }
 
-func convertSpec(spec ast.Spec, isConst bool, out *Output, iotaData *IotaData) {
+func convertSpec(spec ast.Spec, isConst bool, out *Output, constData *ConstData) {
 	switch tp := spec.(type) {
 	case *ast.TypeSpec:
 		convertTypeSpec(tp, out)
 	case *ast.ValueSpec:
-		convertValueSpec(tp, isConst, out, iotaData)
+		convertValueSpec(tp, isConst, out, constData)
 		// handle import before anything else
 		//case *ast.ImportSpec:
 		//	convertImportSpec(tp, out)
 	}
 }
 
-func convertValueSpec(valueSpec *ast.ValueSpec, isConst bool, out *Output, iotaData *IotaData) {
+func convertValueSpec(valueSpec *ast.ValueSpec, isConst bool, out *Output, constData *ConstData) {
 	//printer.Fprint(os.Stdout, out.fset, valueSpec)
+	if isConst {
+		convertConstSpec(valueSpec, out, constData)
+		return
+	}
 	for idx, name := range valueSpec.Names {
-		convertExport(name, out)
-		out.Print("static ")
-		convertConst(isConst, out)
+		if isLocalDecl(out) {
+			var value ast.Expr
+			if idx < len(valueSpec.Values) {
+				value = valueSpec.Values[idx]
+			}
+			typeExpr := valueSpec.Type
+			if typeExpr == nil && value != nil {
+				typeExpr = typeOf(value, out)
+			}
+			if convertCellDecl(name, typeExpr, value, out) {
+				continue
+			}
+		}
+		out.Print("")
+		if !isLocalDecl(out) {
+			convertExport(name, out)
+			out.Print("static ")
+		}
 		nrto := newResolveTypeOpts()
 		nrto.FunctionAsReference = true
-		var dataType ast.Expr = nil
 
 		if valueSpec.Type != nil {
-			dataType = valueSpec.Type
 			convertType(valueSpec.Type, out, nrto)
 			out.outSource.getPackage().AddVarType(name.Name, valueSpec.Type)
+			if idx < len(valueSpec.Values) {
+				recordConversion(valueSpec.Values[idx], valueSpec.Type, out)
+			}
 			out.Print(" ")
 		} else {
-			if iotaData.active {
-				convertType(iotaData.dataType, out, nrto)
-			} else if idx < len(valueSpec.Values) {
-				dataType = resolveType(valueSpec.Values[idx], out, nrto)
+			if idx < len(valueSpec.Values) {
+				if valueType := typeOf(valueSpec.Values[idx], out); valueType != nil {
+					convertType(valueType, out, nrto)
+					out.outSource.getPackage().AddVarType(name.Name, valueType)
+				} else {
+					resolveType(valueSpec.Values[idx], out, nrto)
+				}
 			}
 			out.Print(" ")
 		}
 		convertIdent(name, out)
-		if valueSpec.Values != nil {
+		if idx < len(valueSpec.Values) && !isLocalDecl(out) && needsStaticInit(valueSpec.Values[idx], out) {
+			// assigned in the static initializer
+			addVarInit(name, valueSpec.Values[idx], out)
+		} else if valueSpec.Values != nil {
 			out.Print(" = ")
-			if idx < len(valueSpec.Values) {
-				switch tp := valueSpec.Values[idx].(type) {
-				case *ast.Ident:
-					if tp.Name == "iota" {
-						iotaData.active = true
-						iotaData.dataType = dataType
-					}
-				}
-				if iotaData.active {
-					out.Print(iotaData.idx)
-					iotaData.idx++
-				} else {
-					convertExpr(valueSpec.Values[idx], out)
-				}
+			if idx < len(valueSpec.Values) && (valueSpec.Type == nil && !convertValueCopy(nil, valueSpec.Values[idx], out) ||
+				valueSpec.Type != nil && !convertAssignedValue(valueSpec.Type, valueSpec.Values[idx], false, out)) {
+				convertExpr(valueSpec.Values[idx], out)
 			}
 		} else if valueSpec.Type != nil {
-			switch valueSpec.Type.(type) {
-			// automatic initialization of arrays
-			case *ast.ArrayType:
-				out.Print(" = new ")
-				convertType(valueSpec.Type, out, nrto)
-				out.Print("{}")
-			}
-		} else if iotaData.active {
-			out.Print(" = ")
-			out.Print(iotaData.idx)
-			iotaData.idx++
+			convertZeroInit(valueSpec.Type, out)
 		}
 		convertStmtEnd(out)
 	}
@@ -858,13 +1092,21 @@ func resolveType(expr ast.Expr, out *Output, opts *ResolveTypeOpts) ast.Expr {
 			}
 		}
 	case *ast.Ident:
+		if enumData := oEnums.constEnum(tp.Name, out); enumData != nil {
+			out.Print(enumData.name)
+			return ast.NewIdent(enumData.name)
+		}
 		identExpr := out.GetVarType(tp.Name)
 		if identExpr == nil {
 			out.Print("Object")
 		} else {
 			switch tp := identExpr.(type) {
 			case *ast.Ident:
-				convertIdent(tp, out)
+				if isTypeName(tp.Name) {
+					convertTypeIdent(tp, out, opts)
+				} else {
+					convertIdent(tp, out)
+				}
 				return tp
 			case *ast.MapType:
 				// apply dereferences, if any
@@ -901,8 +1143,7 @@ func resolveType(expr ast.Expr, out *Output, opts *ResolveTypeOpts) ast.Expr {
 					if rtExpr != nil {
 						qualifiedTypeName := funNamePrefix + "." + resolveTypeName(rtExpr, false)
 						if conv, has := typeConvs[qualifiedTypeName]; has {
-							out.Print(conv.typeName)
-							out.outSource.addSysImportName(conv.imports.typeName, conv.imports.qualifiedName)
+							convertTypeConv(conv, nil, out)
 						} else {
 							convertType(rtExpr, out, opts)
 						}
@@ -930,7 +1171,12 @@ func resolveType(expr ast.Expr, out *Output, opts *ResolveTypeOpts) ast.Expr {
 		selName := resolveTypeName(tp.X, false)
 		// remove receiver type name from method expressions
 		if out.GetReceiverTypeName() == "" || selName != out.GetReceiverTypeName() {
-			// TODO
+			// fields and methods of values, also of types of other packages
+			if typeExpr := typeOf(tp, out); typeExpr != nil {
+				convertType(typeExpr, out, opts)
+				return typeExpr
+			}
+			out.Print("Object")
 		} else {
 			// convert rcvr type field
 			pkg := out.outSource.getPackage()
@@ -949,9 +1195,9 @@ func resolveTypeBasicLit(basicLit *ast.BasicLit, out *Output) {
 	case token.INT:
 		out.Print("int")
 	case token.FLOAT:
-		out.Print("float")
+		out.Print("double")
 	case token.CHAR:
-		out.Print("char")
+		out.Print("int")
 	case token.STRING:
 		out.Print("String")
 	}
@@ -966,54 +1212,20 @@ func convertConst(isConst bool, out *Output) {
 func convertRangeStmtKeyValue(rangeStmtExpr ast.Stmt, out *Output) {
 	rangeStmt := rangeStmtExpr.(*ast.RangeStmt)
 
-	newOut := out.NewIndependentOutput()
-	typeExpr := resolveType(rangeStmt.X, newOut, newResolveTypeOpts())
-	switch typeExpr.(type) {
-	case *ast.MapType:
-	default:
-		out.needTabs = false
-		if rangeStmt.Key != nil {
-			out.Print("/* ")
-			convertExpr(rangeStmt.Key, out)
-			out.Print(" */ ")
-		}
-		convertExpr(rangeStmt.Value, out)
-	}
-}
-
-func convertRangeStmtIterable(rangeStmtExpr ast.Stmt, out *Output) {
-	rangeStmt := rangeStmtExpr.(*ast.RangeStmt)
-
-	newOut := out.NewIndependentOutput()
-	typeExpr := resolveType(rangeStmt.X, newOut, newResolveTypeOpts())
-	switch typeExpr.(type) {
-	case *ast.MapType:
-		out.needTabs = false
-		out.Print(".entrySet()")
-	}
-}
-
-func convertRangeStmtExtraDefs(rangeStmtExpr ast.Stmt, out *Output) {
-	rangeStmt := rangeStmtExpr.(*ast.RangeStmt)
-
-	newOut := out.NewIndependentOutput()
-	typeExpr := resolveType(rangeStmt.X, newOut, newResolveTypeOpts())
-	switch tp := typeExpr.(type) {
-	case *ast.MapType:
-		if rangeStmt.Key != nil {
-			convertType(tp.Key, out, newResolveTypeOpts())
-			out.Print(" ")
-			convertExpr(rangeStmt.Key, out)
-			out.Println(" = entry.getKey();")
-		}
-		convertType(tp.Value, out, newResolveTypeOpts())
-		out.Print(" ")
-		convertExpr(rangeStmt.Value, out)
-		out.Println(" = entry.getValue();")
+	out.needTabs = false
+	if rangeStmt.Key != nil {
+		out.Print("/* ")
+		convertExpr(rangeStmt.Key, out)
+		out.Print(" */ ")
 	}
+	convertExpr(rangeStmt.Value, out)
 }
 
 func convertRangeStmt(rangeStmt *ast.RangeStmt, out *Output) {
+	if convertStringRangeStmt(rangeStmt, out) || convertSliceRangeStmt(rangeStmt, out) || convertMapRangeStmt(rangeStmt, out) ||
+		convertChanRangeStmt(rangeStmt, out) {
+		return
+	}
 	out.Print("for (")
 	//out.Print("Object ")
 
@@ -1025,7 +1237,6 @@ func convertRangeStmt(rangeStmt *ast.RangeStmt, out *Output) {
 		pos.postEvalFn = func(postOut *Output) {
 			resolveOpts := newResolveTypeOpts()
 			resolveOpts.ElementOnly = true
-			resolveOpts.structuralInfo.RangeStmtVars = true
 			typeExpr := resolveType(rangeStmt.X, postOut, resolveOpts)
 			if typeExpr != nil {
 				postOut.AddVar(rangeStmt.Value.(*ast.Ident).Name, typeExpr)
@@ -1035,7 +1246,6 @@ func convertRangeStmt(rangeStmt *ast.RangeStmt, out *Output) {
 		//pos.postEvalExpr = rangeStmt.X
 		//pos.postEvalIdent = rangeStmt.Value.(*ast.Ident)
 		//pos.resolveOpts.ElementOnly = true
-		//pos.resolveOpts.structuralInfo.RangeStmtVars = true
 		out.Print(" ")
 	}
 
@@ -1045,21 +1255,17 @@ func convertRangeStmt(rangeStmt *ast.RangeStmt, out *Output) {
 
 	out.Print(" : ")
 	convertExpr(rangeStmt.X, out)
-	pos = out.getPosition()
-	pos.postEvalStmt = rangeStmt
-	pos.postEvalStmtFn = convertRangeStmtIterable
-
 	out.Print(") ")
-	convertBlockStmt(rangeStmt.Body, out, func(out *Output) {
-		pos := out.getPosition()
-		pos.postEvalStmt = rangeStmt
-		pos.postEvalStmtFn = convertRangeStmtExtraDefs
-	})
+	convertBlockStmt(rangeStmt.Body, out, nil)
 }
 
 func convertForStmt(forStmt *ast.ForStmt, out *Output) {
 	out.Print("for (")
-	if forStmt.Init != nil {
+	counter, enumData := enumCounter(forStmt, out)
+	if counter != nil {
+		convertEnumCounterInit(forStmt, counter, enumData, out)
+		defer delete(out.blockInfo.EnumCounters, counter.Name)
+	} else if forStmt.Init != nil {
 		convertStmt(forStmt.Init, out.BanStmtEnd())
 	}
 	out.Print("; ")
@@ -1075,9 +1281,19 @@ func convertForStmt(forStmt *ast.ForStmt, out *Output) {
 }
 
 func convertIfStmt(ifStmt *ast.IfStmt, out *Output) {
+	if ifStmt.Init != nil {
+		// variables of the init statement are scoped to the if statement
+		out.Println("{")
+		convertIfStmtTail(ifStmt, out.AddTab())
+		out.Println("}")
+		return
+	}
+	convertIfStmtTail(ifStmt, out)
+}
+
+func convertIfStmtTail(ifStmt *ast.IfStmt, out *Output) {
 	if ifStmt.Init != nil {
 		convertStmt(ifStmt.Init, out)
-		convertStmtEnd(out)
 	}
 	out.Print("if (")
 	convertExpr(ifStmt.Cond, out)
@@ -1095,43 +1311,22 @@ func convertExprStmt(exprStmt *ast.ExprStmt, out *Output) {
 }
 
 func convertReturnStmt(returnStmt *ast.ReturnStmt, out *Output) {
+	if convertResultsReturn(returnStmt, out) {
+		return
+	}
 	out.Print("return ")
 	for idx, expr := range returnStmt.Results {
 		if idx > 0 {
 			out.Print(" /* ")
 		}
-		convertExpr(expr, out)
+		if idx > 0 || !convertAssignedValue(resultTypeAt(out.GetCurrentFuncType(), 0), expr, false, out) {
+			convertExpr(expr, out)
+		}
 		if idx > 0 {
 			out.Print(" */")
 		}
 		if idx == 0 {
-			newOut := out.NewIndependentOutput()
-			nrto := newResolveTypeOpts()
-			nrto.DirectEval = true
-			resolveType(expr, newOut, nrto)
-			retTypeName := newOut.out.buf.String()
-			funName := out.GetCurrentFunctionName()
-			funType := out.GetFuncType(funName)
-			newOut = out.NewIndependentOutput()
-			convertType(funType, newOut, nrto)
-			funTypeName := newOut.out.buf.String()
-			if funTypeName != retTypeName {
-				implementsIP := oTypes.getImplementsPos(retTypeName)
-				if implementsIP != nil {
-					outImplements := implementsIP.getOut()
-					outImplements.needTabs = false
-					if !oTypes.hasImpemented(retTypeName, funTypeName) {
-						if !oTypes.getAnyImplements(retTypeName) {
-							outImplements.Print(" implements ")
-							oTypes.addImplements(retTypeName, funTypeName)
-						} else {
-							outImplements.Print(", ")
-						}
-						outImplements.Print(funTypeName)
-					}
-				}
-			}
-
+			recordConversion(expr, out.GetFuncType(out.GetCurrentFunctionName()), out)
 		}
 	}
 	convertStmtEnd(out)
@@ -1147,13 +1342,33 @@ func convertAssignToken(token token.Token, out *Output) {
 }
 
 func convertAssignStmt(assignStmt *ast.AssignStmt, out *Output) {
-	assignmentToken := assignStmt.Tok.String()
-	isDef := assignmentToken == ":="
+	if assignStmt.Tok == token.ASSIGN && len(assignStmt.Lhs) == len(assignStmt.Rhs) {
+		for idx, lhs := range assignStmt.Lhs {
+			if !isBlank(lhs) {
+				recordConversion(assignStmt.Rhs[idx], typeOf(lhs, out), out)
+			}
+		}
+	}
+	if convertMapLookupOk(assignStmt, out) || convertResultsAssign(assignStmt, out) || convertTupleAssign(assignStmt, out) || convertBlankAssign(assignStmt, out) {
+		return
+	}
+	// a := statement with several names may redeclare the first one
+	isDef := assignStmt.Tok == token.DEFINE && (len(assignStmt.Lhs) == 1 || isNewDef(assignStmt.Lhs[0], assignStmt))
+	if ident, isIdent := assignStmt.Lhs[0].(*ast.Ident); isDef && isIdent && convertCellDecl(ident, typeOf(assignStmt.Rhs[0], out), assignStmt.Rhs[0], out) {
+		return
+	}
 	if isDef {
 		out.Print("")
 		//postpone resolving type of assignStmt.Rhs[0]
+		rhsType := typeOf(assignStmt.Rhs[0], out)
 		pos := out.getPosition()
 		pos.postEvalFn = func(postOut *Output) {
+			if rhsType != nil {
+				nrto := newResolveTypeOpts()
+				nrto.FunctionAsReference = true
+				convertType(rhsType, postOut, nrto)
+				return
+			}
 			typeExpr := resolveType(assignStmt.Rhs[0], postOut, newResolveTypeOpts())
 			if typeExpr != nil {
 				postOut.AddVar(assignStmt.Lhs[0].(*ast.Ident).Name, typeExpr)
@@ -1164,15 +1379,15 @@ func convertAssignStmt(assignStmt *ast.AssignStmt, out *Output) {
 		//pos.postEvalIdent = assignStmt.Lhs[0].(*ast.Ident)
 		out.Print(" ")
 	}
-	if len(assignStmt.Lhs) > 1 && len(assignStmt.Rhs) > 1 {
-		for idx, expr := range assignStmt.Lhs {
-			if idx > 0 {
-				out.Print(", ")
-			}
-			convertExpr(expr, out)
-			out.Print(" = ")
-			convertExpr(assignStmt.Rhs[idx], out)
-		}
+	if len(assignStmt.Lhs) == 1 && !isDef && assignStmt.Tok != token.ASSIGN &&
+		convertSliceUpdate(assignStmt.Lhs[0], assignStmt.Tok, assignStmt.Rhs[0], out) {
+		return
+	} else if len(assignStmt.Lhs) == 1 && !isDef && convertMapAssign(assignStmt.Lhs[0], assignStmt.Tok, assignStmt.Rhs[0], out) {
+		return
+	} else if len(assignStmt.Lhs) == 1 && !isDef && convertCellAssign(assignStmt.Lhs[0], assignStmt.Tok, assignStmt.Rhs[0], out) {
+		return
+	} else if len(assignStmt.Lhs) == 1 && convertOpAssign(assignStmt.Lhs[0], assignStmt.Tok, assignStmt.Rhs[0], out) {
+		return
 	} else {
 		for idx, expr := range assignStmt.Lhs {
 			if idx > 0 {
@@ -1186,18 +1401,27 @@ func convertAssignStmt(assignStmt *ast.AssignStmt, out *Output) {
 			}
 		}
 		if isDef {
-			out.AddVar(assignStmt.Lhs[0].(*ast.Ident).Name, findType(assignStmt.Rhs[0], out))
+			typeExpr := typeOf(assignStmt.Rhs[0], out)
+			if typeExpr == nil {
+				typeExpr = findType(assignStmt.Rhs[0], out)
+			}
+			out.AddVar(assignStmt.Lhs[0].(*ast.Ident).Name, typeExpr)
 		}
 		if out.structuralInfo.MapAssignment {
 			out.structuralInfo.MapAssignment = false
 			out.Print(", ")
-			convertExpr(assignStmt.Rhs[0], out)
+			if !convertValueCopy(nil, assignStmt.Rhs[0], out) {
+				convertExpr(assignStmt.Rhs[0], out)
+			}
 			out.Print(")")
 		} else {
 			out.Print(" ")
 			convertAssignToken(assignStmt.Tok, out)
 			out.Print(" ")
-			convertExpr(assignStmt.Rhs[0], out)
+			if isDef && !convertValueCopy(nil, assignStmt.Rhs[0], out) ||
+				!isDef && !convertAssignedValue(typeOf(assignStmt.Lhs[0], out), assignStmt.Rhs[0], false, out) {
+				convertExpr(assignStmt.Rhs[0], out)
+			}
 		}
 	}
 	convertStmtEnd(out)
@@ -1226,10 +1450,6 @@ func convertExprSkipFirstSel(expr ast.Expr, out *Output) {
 	}
 }
 
-var typeConversion = map[string]string{
-	"String->byte[]": ".getBytes()",
-}
-
 func convertNamedExpr(expr ast.Expr, ident *ast.Ident, out *Output) {
 	//out.Println("expr:", reflect.TypeOf(expr))
 	//printer.Fprint(out.out.buf, out.fset, expr)
@@ -1238,91 +1458,46 @@ func convertNamedExpr(expr ast.Expr, ident *ast.Ident, out *Output) {
 		convertInterface(tp, ident, out)
 		//printer.Fprint(os.Stdout, fset, tp)
 	case *ast.StructType:
+		if ident == nil {
+			// anonymous structs have no class, like type dequeueNil *struct{}
+			return
+		}
 		convertStruct(tp, ident, out)
 		//printer.Fprint(os.Stdout, out.fset, tp)
 	case *ast.Ident:
 		convertIdent(tp, out)
 	case *ast.BinaryExpr:
-		convertExpr(tp.X, out)
-		out.Print(" ")
-		convertOp(tp.Op, out)
-		out.Print(" ")
-		convertExpr(tp.Y, out)
+		convertBinaryExpr(tp, out)
 	case *ast.BasicLit:
 		convertBasicLit(tp, out)
 	case *ast.CallExpr:
-		funName := resolveTypeName(tp.Fun, false)
-		if len(tp.Args) == 1 {
-			iout := out.NewIndependentOutput()
-			nrto := newResolveTypeOpts()
-			nrto.DirectEval = true
-			convertType(tp.Fun, iout, nrto)
-			funConv := iout.out.buf.String()
-			iout = out.NewIndependentOutput()
-			resolveType(tp.Args[0], iout, newResolveTypeOpts())
-			arg0Type := iout.out.buf.String()
-			typeConvJava := typeConversion[arg0Type+"->"+funConv]
-			if typeConvJava != "" {
-				convertExpr(tp.Args[0], out)
-				out.Print(typeConvJava)
-				return
-			}
-		}
-
-		conv := apiConvs[funName]
-		if conv != nil {
-			out.Print(conv.method)
-			if conv.imports != nil {
-				out.outSource.addSysImportName(conv.imports.typeName, conv.imports.qualifiedName)
-			}
-		} else {
-			convertExpr(tp.Fun, out)
-		}
-		out.Print("(")
-		for idx, arg := range tp.Args {
-			if idx > 0 {
-				if conv != nil && conv.argSeparator != nil {
-					out.Print(conv.argSeparator.sep)
-				} else {
-					out.Print(", ")
-				}
-			}
-			convertExpr(arg, out)
+		if convertBuiltinCall(tp, out) || convertConversion(tp, out) || convertErrorsAs(tp, out) || convertMapsKeys(tp, out) || convertApiCall(tp, out) {
+			return
 		}
-		out.Print(")")
-	case *ast.CompositeLit:
-		out.Print("new ")
-		resolveOpts := newResolveTypeOpts()
-		resolveOpts.ImplementationClass = true
-		convertType(tp.Type, out, resolveOpts)
+		convertExpr(tp.Fun, out)
+		funcType := calleeFuncType(tp, out)
 		out.Print("(")
-		for idx, elt := range tp.Elts {
+		for idx := range tp.Args {
 			if idx > 0 {
 				out.Print(", ")
 			}
-			convertExpr(elt, out)
+			convertCallArg(tp, idx, funcType, out)
 		}
 		out.Print(")")
+	case *ast.CompositeLit:
+		convertCompositeLit(tp, tp.Type, out)
 	case *ast.IndexExpr:
+		tp = enumIndexed(tp, out)
+		if convertStringIndex(tp, out) || convertSliceIndex(tp, out) || convertMapIndex(tp, out) {
+			return
+		}
 		convertExpr(tp.X, out)
-
-		newOut := out.NewIndependentOutput()
-		iterType := resolveType(tp.X, newOut, newResolveTypeOpts())
-		switch iterType.(type) {
-		case *ast.MapType:
-			if out.structuralInfo.AssignmentLeft {
-				out.structuralInfo.MapAssignment = true
-				out.Print(".put(")
-				convertExpr(tp.Index, out)
-			} else {
-				out.Print(".get(")
-				convertExpr(tp.Index, out)
-				out.Print(")")
-			}
-		default:
-			out.Print("[")
-			convertExpr(tp.Index, out)
-			out.Print("]")
+		out.Print("[")
+		convertExpr(tp.Index, out)
+		out.Print("]")
+	case *ast.SliceExpr:
+		if !convertStringSlice(tp, out) {
+			convertSliceExpr(tp, out)
 		}
 	case *ast.KeyValueExpr:
 		convertExpr(tp.Value, out)
@@ -1345,50 +1520,57 @@ func convertNamedExpr(expr ast.Expr, ident *ast.Ident, out *Output) {
 			out.Print(tp.Sel.Name)
 			return
 		} else {*/
+		if convertPackageConst(tp, out) {
+			return
+		}
+		if conv := apiConvs[resolveTypeName(tp, false)]; conv != nil {
+			conv.addImports(out)
+			out.Print(conv.method)
+			return
+		}
+		if isEnumValueExpr(tp, out) {
+			convertEnumValue(tp.X, out)
+			return
+		}
 		selName := resolveTypeName(tp.X, false)
+		if (out.GetReceiverTypeName() == "" || selName != out.GetReceiverTypeName()) && convertValueMethod(tp, out) {
+			return
+		}
 		// remove receiver type name from method expressions
 		if out.GetReceiverTypeName() == "" || selName != out.GetReceiverTypeName() {
+			// only the selected field is assigned
+			isLeft := out.structuralInfo.AssignmentLeft
+			out.structuralInfo.AssignmentLeft = false
 			convertExpr(tp.X, out)
+			out.structuralInfo.AssignmentLeft = isLeft
 		} else {
 			out.Print("this")
 		}
 		//}
+		convertPromotedPath(tp, out)
 		out.Print(".")
 		out.Print(tp.Sel.Name)
 	case *ast.StarExpr:
-		convertExpr(tp.X, out)
+		if !convertCellRead(tp, out) {
+			convertExpr(tp.X, out)
+		}
 	case *ast.UnaryExpr:
-		convertUnOp(tp.Op, out)
-		convertExpr(tp.X, out)
+		convertUnaryExpr(tp, out)
+	case *ast.FuncLit:
+		convertFuncLit(tp, out)
 	}
 }
 
 var go2jUnOp = map[string]string{
 	"&": "",
 	"*": "",
+	"^": "~",
 }
 
 var go2jIdent = map[string]string{
 	"nil": "null",
 }
 
-func convertMultilineStringLit(value string, out *Output) {
-	value = strings.TrimPrefix(value, "`")
-	value = strings.TrimSuffix(value, "`")
-	value = strings.ReplaceAll(value, "\"", "\\\"")
-	value = strings.ReplaceAll(value, "\n", "\\n\"+\n\"")
-	value = "\"" + value + "\""
-	out.Print(value)
-}
-
-func convertBasicLit(basicLit *ast.BasicLit, out *Output) {
-	if strings.HasPrefix(basicLit.Value, "`") {
-		convertMultilineStringLit(basicLit.Value, out)
-	} else {
-		out.Print(basicLit.Value)
-	}
-}
-
 func convertUnOp(op token.Token, out *Output) {
 	name := op.String()
 	if convName, has := go2jUnOp[name]; has {
@@ -1402,15 +1584,34 @@ func convertOp(op token.Token, out *Output) {
 }
 
 func convertIdent(tp *ast.Ident, out *Output) {
+	if convertEnumConstIdent(tp, out) || convertEnumCounterIdent(tp, out) {
+		return
+	}
 	name := tp.Name
+	if name != "" && name == out.GetReceiverTypeName() {
+		out.Print("this")
+		if valueClassOf(tp, out) != nil {
+			out.Print(".value")
+		}
+		return
+	}
+	if javaName, has := out.blockInfo.Renames[name]; has {
+		name = javaName
+	}
 	if convName, has := go2jIdent[name]; has {
 		name = convName
 	}
 
 	if out.GetVarType(name) == nil && out.outSource.importedPackages[strings.Title(name)] != "" {
 		name = strings.Title(name)
+	} else if isPackageMember(tp, out) {
+		// the package members are static members of the package class
+		out.Print(strings.Title(oFileSet.currentPackageName), ".")
 	}
 	out.Print(name)
+	if isCellVar(tp) && !out.structuralInfo.CellRef {
+		out.Print(".get()")
+	}
 }
 
 func convertStructConstructor(tp *ast.StructType, fieldList []*ast.Field, ident *ast.Ident, out *Output) {
@@ -1418,22 +1619,26 @@ func convertStructConstructor(tp *ast.StructType, fieldList []*ast.Field, ident
 	name := strings.Title(ident.Name)
 	out.Print(name)
 	out.Print("(")
-	for idx, field := range fieldList {
-		if idx > 0 {
+	paramNames := []string{}
+	for _, field := range fieldList {
+		names := fieldNames(field)
+		if len(names) == 0 {
+			continue
+		}
+		if len(paramNames) > 0 {
 			out.Print(", ")
 		}
 		convertField(field, out.BanStmtEnd(), true)
+		paramNames = append(paramNames, names...)
 	}
 	out.Println(") {")
-	for _, field := range fieldList {
-		if len(field.Names) > 0 {
-			outTab := out.AddTab()
-			outTab.Print("this.")
-			outTab.Print(field.Names[0])
-			outTab.Print(" = ")
-			outTab.Print(field.Names[0])
-			convertStmtEnd(outTab)
-		}
+	for _, fieldName := range paramNames {
+		outTab := out.AddTab()
+		outTab.Print("this.")
+		outTab.Print(fieldName)
+		outTab.Print(" = ")
+		outTab.Print(fieldName)
+		convertStmtEnd(outTab)
 	}
 	out.Println("}")
 	out.Println("")
@@ -1449,35 +1654,8 @@ func convertStruct(tp *ast.StructType, ident *ast.Ident, out *Output) {
 	name := strings.Title(ident.Name)
 	out.Print(name)
 
-	firstIdent := true
-	secondIdent := false
-	//anyImplements := false
-	for _, field := range tp.Fields.List {
-		switch tp := field.Type.(type) {
-		case *ast.Ident:
-			if len(field.Names) == 0 {
-				if firstIdent {
-					out.Print(" extends ")
-					firstIdent = false
-					secondIdent = true
-				} else if secondIdent {
-					out.Print(" implements ")
-					secondIdent = false
-					//anyImplements = true
-					oTypes.addImplements(name, strings.Title(tp.Name))
-				} else {
-					out.Print(", ")
-					oTypes.addImplements(name, strings.Title(tp.Name))
-				}
-				convertType(tp, out, newResolveTypeOpts())
-			} else {
-				break
-			}
-		}
-	}
-
-	oTypes.setImplementsPos(name, out.getPosition())
-	//oTypes.setAnyImplements(name, anyImplements)
+	key := typeKey(ident.Name, out)
+	oTypes.setImplementsPos(key, out.getPosition())
 
 	out.Println(" {")
 	for _, field := range tp.Fields.List {
@@ -1490,29 +1668,68 @@ func convertStruct(tp *ast.StructType, ident *ast.Ident, out *Output) {
 		convertStructConstructor(tp, tp.Fields.List, ident, out.AddTab())
 	}
 
-	oTypes.setFunctionsPos(name, out.AddTab().getPosition())
+	membersOut := out.AddTab()
+	equalityIP := membersOut.getPosition()
+	equalityIP.postEvalFn = func(postOut *Output) {
+		postOut.tabs = membersOut.tabs
+		postOut.needTabs = true
+		convertStructEquality(tp, name, postOut)
+		convertStructCopy(tp, name, postOut)
+		convertStructString(postOut)
+	}
+	promotedIP := membersOut.getPosition()
+	promotedIP.postEvalFn = func(postOut *Output) {
+		postOut.tabs = membersOut.tabs
+		postOut.needTabs = true
+		convertPromotedMembers(key, postOut)
+	}
+	oTypes.setFunctionsPos(key, membersOut.getPosition())
+	convertPendingMethods(key)
 
 	out.Println("}")
 }
 
+// fieldNames returns the names of the java fields of a struct field, blank fields are left out
+func fieldNames(field *ast.Field) []string {
+	if len(field.Names) == 0 {
+		if name := embeddedFieldName(field.Type); name != "" {
+			return []string{name}
+		}
+		return nil
+	}
+	names := []string{}
+	for _, name := range field.Names {
+		if name.Name != "_" {
+			names = append(names, name.Name)
+		}
+	}
+	return names
+}
+
 func convertField(field *ast.Field, out *Output, asParameter bool) {
-	if len(field.Names) > 0 {
+	if len(field.Names) == 0 {
+		convertEmbeddedField(field, out, asParameter)
+		return
+	}
+	for idx, name := range field.Names {
+		if name.Name == "_" {
+			continue
+		}
+		if asParameter && idx > 0 {
+			out.Print(", ")
+		}
 		if !asParameter {
-			convertExport(field.Names[0], out)
+			convertGoTypeAnnotation(field.Type, out)
+			convertExport(name, out)
 		}
 		nrto := newResolveTypeOpts()
 		nrto.FunctionAsReference = true
 		convertType(field.Type, out, nrto)
-		out.outSource.getPackage().AddVarType(field.Names[0].Name, field.Type)
+		out.outSource.getPackage().AddVarType(name.Name, field.Type)
 		out.Print(" ")
-		out.Print(field.Names[0].Name)
+		out.Print(name.Name)
 		if !asParameter && field.Type != nil {
-			switch field.Type.(type) {
-			case *ast.ArrayType:
-				out.Print(" = new ")
-				convertType(field.Type, out, newResolveTypeOpts())
-				out.Print("{}")
-			}
+			convertZeroInit(field.Type, out)
 		}
 		convertStmtEnd(out)
 	}
@@ -1534,7 +1751,7 @@ func toNewFile(name string, origOut *Output) *Output {
 	out := newOutput(origOut.getFset(), outSource)
 	if isNew {
 		convertPackageHeader(oFileSet.currentPackage, out)
-		oFileSet.classNameSet[name] = outSource
+		oFileSet.classNameSet[path] = outSource
 		outSource.importsIP = out.getPosition()
 		pkg := newPackage(name)
 		oFileSet.packageSet[outSource.getFullPackageName()] = pkg
@@ -1582,10 +1799,12 @@ func convertInterface(tp *ast.InterfaceType, ident *ast.Ident, out *Output) {
 //Function<Event, Void> eh;
 func convertFuncTypeRef(tp *ast.FuncType, funcName string, isExported bool, out *Output) {
 	out.Print("Function<")
-	if tp.Results == nil {
+	if results := resultTypes(tp); len(results) == 0 {
 		out.Print("Void")
+	} else if len(results) > 1 {
+		convertTupleType(results, out)
 	} else {
-		convertType(tp.Results.List[0].Type, out, newResolveTypeOpts())
+		convertType(results[0], out, newResolveTypeOpts())
 	}
 	out.Print(",")
 	if len(tp.Params.List) == 0 {
@@ -1604,23 +1823,23 @@ func convertFuncType(tp *ast.FuncType, funcName string, isExported bool, out *Ou
 		return
 	}
 
-	if tp.Results == nil {
+	if results := resultTypes(tp); len(results) == 0 {
 		out.Print("void ")
 	} else {
-		for idx, field := range tp.Results.List {
-			out.Print("")
-			convertType(field.Type, out, newResolveTypeOpts())
-			out.Print(" ")
-			if idx == 0 {
-				if isExported {
-					//out.Println("expfn pgknm:", out.outSource.getPackage().name)
-					out.outSource.getPackage().AddFunc(funcName, field.Type)
-					//out.Println("expfn pgknmlen:", len(out.outSource.getPackage().FuncReturnTypes))
-					//out.Println("expfn pgknmlen2:", len(oFileSet.packageSet[out.outSource.getFullFileName()].FuncReturnTypes))
-				}
-				out.AddFunc(funcName, field.Type)
-			}
+		out.Print("")
+		if len(results) > 1 {
+			convertTupleType(results, out)
+		} else {
+			convertType(results[0], out, newResolveTypeOpts())
+		}
+		out.Print(" ")
+		if isExported {
+			//out.Println("expfn pgknm:", out.outSource.getPackage().name)
+			out.outSource.getPackage().AddFunc(funcName, results[0])
+			//out.Println("expfn pgknmlen:", len(out.outSource.getPackage().FuncReturnTypes))
+			//out.Println("expfn pgknmlen2:", len(oFileSet.packageSet[out.outSource.getFullFileName()].FuncReturnTypes))
 		}
+		out.AddFunc(funcName, results[0])
 	}
 	out.Print(funcName)
 	out.Print("(")
@@ -1636,7 +1855,11 @@ func convertFuncType(tp *ast.FuncType, funcName string, isExported bool, out *Ou
 			nrto.FunctionAsReference = true
 			convertType(field.Type, out, nrto)
 			out.Print(" ")
-			out.Print(name.Name)
+			if _, isVariadic := field.Type.(*ast.Ellipsis); isVariadic {
+				out.Print(varargsName(name.Name))
+			} else {
+				out.Print(name.Name)
+			}
 			idx++
 		}
 	}
@@ -1681,14 +1904,16 @@ func convertType(fieldType ast.Expr, out *Output, opts *ResolveTypeOpts) {
 		if opts.ElementOnly {
 			opts.ElementOnly = false
 			convertType(tp.Elt, out, opts)
+		} else if tp.Len == nil {
+			convertSliceType(tp, out, opts)
 		} else {
 			convertType(tp.Elt, out, opts)
 			out.Print("[]")
 		}
+	case *ast.ChanType:
+		convertChanType(tp, out)
 	case *ast.MapType:
-		if opts.structuralInfo.RangeStmtVars {
-			out.Print("Map.Entry<")
-		} else if opts.ImplementationClass {
+		if opts.ImplementationClass {
 			out.outSource.addSysImportName("HashMap", "java.util.HashMap")
 			out.Print("HashMap<")
 		} else {
@@ -1700,40 +1925,54 @@ func convertType(fieldType ast.Expr, out *Output, opts *ResolveTypeOpts) {
 		} else {
 			opts.PrimitiveAsObject = true
 		}
-		convertType(tp.Key, out, opts)
+		convertMapKeyType(tp, out, opts)
 		out.Print(",")
 		convertType(tp.Value, out, opts)
 		out.Print(">")
-		if opts.structuralInfo.RangeStmtVars {
-			out.Print(" entry")
-		}
 	case *ast.StarExpr:
+		if isCellElem(tp.X) {
+			convertCellType(tp.X, out)
+			return
+		}
 		convertType(tp.X, out, opts)
 	case *ast.Ellipsis:
 		convertType(tp.Elt, out, opts)
 		out.Print("...")
 	case *ast.SelectorExpr:
+		if conv, has := typeConvs[resolveTypeName(tp, false)]; has {
+			convertTypeConv(conv, nil, out)
+			return
+		}
+		if key := namedValueKey(tp); key != "" && !opts.ValueClass {
+			convertType(oTypes.get(key).valueType, out, opts)
+			return
+		}
 		firstSelector := strings.Title(firstSelectorName(tp))
 		//out.Println("fsn:", firstSelector)
-		if out.outSource.importedPackages[firstSelector] != "" {
+		if out.outSource.importedPackages[firstSelector] != "" || isOwnPackageName(tp.X, out) {
+			pkgPath := ownPackagePath(firstSelectorName(tp), currentPkgName(out))
+			if isAmbiguousClass(tp.Sel.Name) {
+				out.Print(convertPath(pkgPath), ".", tp.Sel.Name)
+				return
+			}
 			convertExprSkipFirstSel(tp.X, out)
 			out.Print(tp.Sel.Name)
-			out.outSource.addImportedClass(tp.Sel.Name)
+			out.outSource.addImportedClass(pkgPath + "/" + tp.Sel.Name)
 			//out.outSource.importedClasses[tp.Sel.Name] = true
 			return
 		} else {
-			qualifiedTypeName := resolveTypeName(tp, false)
-			if conv, has := typeConvs[qualifiedTypeName]; has {
-				out.Print(conv.typeName)
-				out.outSource.addSysImportName(conv.imports.typeName, conv.imports.qualifiedName)
-			} else {
-				convertType(tp.X, out, opts)
-				out.Print(".")
-				out.Print(tp.Sel.Name)
-			}
+			convertType(tp.X, out, opts)
+			out.Print(".")
+			out.Print(tp.Sel.Name)
 		}
+	case *ast.IndexExpr:
+		convertGenericType(tp.X, []ast.Expr{tp.Index}, out, opts)
+	case *ast.IndexListExpr:
+		convertGenericType(tp.X, tp.Indices, out, opts)
 	case *ast.InterfaceType:
 		out.Print("Object")
+	case *ast.StructType:
+		out.Print(strings.Title(anonStructName(tp, out)))
 	}
 }
 
@@ -1741,8 +1980,18 @@ var go2jType = map[string]string{
 	"string":  "String",
 	"bool":    "boolean",
 	"byte":    "byte",
+	"rune":    "int",
 	"int":     "int",
+	"int8":    "byte",
+	"int16":   "short",
+	"int32":   "int",
 	"int64":   "long",
+	"uint":    "int",
+	"uint8":   "byte",
+	"uint16":  "short",
+	"uint32":  "int",
+	"uint64":  "long",
+	"uintptr": "long",
 	"float32": "float",
 	"float64": "double",
 }
@@ -1751,8 +2000,18 @@ var go2jTypeObj = map[string]string{
 	"string":  "String",
 	"bool":    "Boolean",
 	"byte":    "Byte",
+	"rune":    "Integer",
 	"int":     "Integer",
+	"int8":    "Byte",
+	"int16":   "Short",
+	"int32":   "Integer",
 	"int64":   "Long",
+	"uint":    "Integer",
+	"uint8":   "Byte",
+	"uint16":  "Short",
+	"uint32":  "Integer",
+	"uint64":  "Long",
+	"uintptr": "Long",
 	"float32": "Float",
 	"float64": "Double",
 }
@@ -1761,11 +2020,25 @@ func convertTypeIdent(tp *ast.Ident, out *Output, opts *ResolveTypeOpts) {
 	name := tp.Name
 	titleName := strings.Title(name)
 
-	if aliasName, has := oFileSet.typeAliases[titleName]; has {
+	if key := namedValueKey(tp); key != "" {
+		if !opts.ValueClass {
+			// the class of a named type only holds its methods
+			convertType(oTypes.get(key).valueType, out, opts)
+			return
+		}
+	} else if aliasName, has := oFileSet.typeAliases[name]; has {
+		name = aliasName
+		titleName = strings.Title(name)
+	} else if aliasName, has := oFileSet.typeAliases[titleName]; has {
 		name = aliasName
 		titleName = strings.Title(name)
 	}
 
+	if name == "error" {
+		useGoError(out.outSource)
+		out.Print(JI_GO_ERROR.typeName)
+		return
+	}
 	typeNameMap := go2jType
 	if opts.PrimitiveAsObject {
 		typeNameMap = go2jTypeObj
@@ -1773,7 +2046,7 @@ func convertTypeIdent(tp *ast.Ident, out *Output, opts *ResolveTypeOpts) {
 	if convName, has := typeNameMap[name]; has {
 		titleName = convName
 	} else {
-		out.outSource.addImportedClass(titleName)
+		out.outSource.addImportedClass(out.outSource.path + "/" + titleName)
 	}
 	out.Print(titleName)
 }
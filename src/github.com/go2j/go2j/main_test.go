@@ -0,0 +1,99 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// the tests translate go sources with the test binary running main in a new process,
+// the translator keeps its state in globals
+
+func TestMain(m *testing.M) {
+	if os.Getenv("GO2J_TEST_MAIN") != "" {
+		main()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+type translation struct {
+	// the java sources by their path in the source directory
+	files  map[string]string
+	stderr string
+	failed bool
+}
+
+// translate writes the go sources, keyed by their path below src, and translates the app directory
+func translate(t *testing.T, sources map[string]string, flags ...string) *translation {
+	t.Helper()
+	dir := t.TempDir()
+	for path, source := range sources {
+		fileName := filepath.Join(dir, "src", path)
+		if err := os.MkdirAll(filepath.Dir(fileName), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(fileName, []byte(source), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	javaDir := filepath.Join(dir, "java")
+	cmd := exec.Command(os.Args[0], append(flags, "-gs", filepath.Join(dir, "src", "app"), "-js", javaDir)...)
+	cmd.Env = append(os.Environ(), "GO2J_TEST_MAIN=1")
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	if _, isExit := err.(*exec.ExitError); err != nil && !isExit {
+		t.Fatal(err)
+	}
+	result := &translation{map[string]string{}, stderr.String(), err != nil}
+	srcDir := filepath.Join(javaDir, "src")
+	filepath.Walk(srcDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || !strings.HasSuffix(path, ".java") {
+			return err
+		}
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		relPath, _ := filepath.Rel(srcDir, path)
+		result.files[filepath.ToSlash(relPath)] = string(content)
+		return nil
+	})
+	return result
+}
+
+// java returns a generated source, the helper classes of org/go2j included
+func (tr *translation) java(t *testing.T, path string) string {
+	t.Helper()
+	source, has := tr.files[path]
+	if !has {
+		paths := []string{}
+		for path := range tr.files {
+			paths = append(paths, path)
+		}
+		t.Fatalf("no %s in %v\n%s", path, paths, tr.stderr)
+	}
+	return source
+}
+
+func expectContains(t *testing.T, source string, parts ...string) {
+	t.Helper()
+	for _, part := range parts {
+		if !strings.Contains(source, part) {
+			t.Errorf("missing %q in\n%s", part, source)
+		}
+	}
+}
+
+func expectNotContains(t *testing.T, source string, parts ...string) {
+	t.Helper()
+	for _, part := range parts {
+		if strings.Contains(source, part) {
+			t.Errorf("unexpected %q in\n%s", part, source)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/parser"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// mapping files extend and override the built-in api and type conversions, so the packages of a
+// project can be converted to existing java libraries. they are loaded from go2j.json in the
+// project directory, the parent of the src directory of -gs, and from the files of the -map flag
+
+const projectMappingFile = "go2j.json"
+
+type MappingFile struct {
+	Funcs       map[string]*ApiMapping       `json:"funcs"`
+	Values      map[string]*ApiMapping       `json:"values"`
+	Methods     map[string]*ApiMapping       `json:"methods"`
+	Types       map[string]*TypeMapping      `json:"types"`
+	BasicTypes  map[string]*BasicTypeMapping `json:"basicTypes"`
+	Conversions map[string]string            `json:"conversions"`
+}
+
+// ApiMapping converts a function, a value or a method. java is a method name or a template with
+// {0}, {1}.., {args} and {recv} placeholders, args orders the arguments of a method name
+type ApiMapping struct {
+	Java      string   `json:"java"`
+	Args      []int    `json:"args"`
+	Imports   []string `json:"imports"`
+	Results   []string `json:"results"`
+	PrintArgs *int     `json:"printArgs"`
+}
+
+// TypeMapping converts a type, {0}, {1}.. in java are the generic parameters. zero is the
+// java zero value of the type, null by default
+type TypeMapping struct {
+	Java    string   `json:"java"`
+	Imports []string `json:"imports"`
+	Zero    string   `json:"zero"`
+}
+
+type BasicTypeMapping struct {
+	Java   string `json:"java"`
+	Object string `json:"object"`
+}
+
+// mappingFiles returns the project mapping file when it exists and the files of the -map flag
+func mappingFiles(projectDir, mapFiles string) []string {
+	paths := []string{}
+	projectPath := filepath.Join(projectDir, projectMappingFile)
+	if _, err := os.Stat(projectPath); err == nil {
+		paths = append(paths, projectPath)
+	}
+	for _, path := range strings.Split(mapFiles, ",") {
+		if path = strings.TrimSpace(path); path != "" {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// loadMappingFiles loads the mapping files in order, the later ones override the earlier ones
+func loadMappingFiles(paths []string) error {
+	for _, path := range paths {
+		if err := loadMappingFile(path); err != nil {
+			return fmt.Errorf("%s: %v", path, err)
+		}
+	}
+	return nil
+}
+
+func loadMappingFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	decoder := json.NewDecoder(file)
+	decoder.DisallowUnknownFields()
+	mapping := &MappingFile{}
+	if err := decoder.Decode(mapping); err != nil {
+		return err
+	}
+	return mapping.register()
+}
+
+func (mapping *MappingFile) register() error {
+	for name, api := range mapping.Funcs {
+		conv, err := api.apiConv(name)
+		if err != nil {
+			return err
+		}
+		apiConvs[name] = conv
+	}
+	for name, api := range mapping.Values {
+		conv, err := api.apiConv(name)
+		if err != nil {
+			return err
+		}
+		// values are printed as they are
+		conv.method, conv.template = api.Java, ""
+		apiConvs[name] = conv
+	}
+	for name, api := range mapping.Methods {
+		if strings.Count(name, ".") != 2 {
+			return fmt.Errorf("method %s is not like pkg.Type.Method", name)
+		}
+		conv, err := api.apiConv(name)
+		if err != nil {
+			return err
+		}
+		methodConvs[name] = conv
+	}
+	for name, tp := range mapping.Types {
+		if tp.Java == "" {
+			return fmt.Errorf("type %s has no java type", name)
+		}
+		typeConvs[name] = &JavaTypeConv{typeName: tp.Java, extraImports: javaImports(tp.Imports), zero: tp.Zero}
+	}
+	for name, tp := range mapping.BasicTypes {
+		if tp.Java == "" || tp.Object == "" {
+			return fmt.Errorf("basic type %s needs a java and an object type", name)
+		}
+		go2jType[name] = tp.Java
+		go2jTypeObj[name] = tp.Object
+	}
+	for conversion, method := range mapping.Conversions {
+		typeConversion[conversion] = method
+	}
+	return nil
+}
+
+func (api *ApiMapping) apiConv(name string) (*JavaApiConv, error) {
+	if api.Java == "" {
+		return nil, fmt.Errorf("%s has no java method or template", name)
+	}
+	for _, result := range api.Results {
+		typeName := expandResult(result, func(int, string) string { return "T" })
+		if _, err := parser.ParseExpr(typeName); err != nil {
+			return nil, fmt.Errorf("%s: invalid result type %s", name, result)
+		}
+	}
+	for _, idx := range api.Args {
+		if idx < 0 {
+			return nil, fmt.Errorf("%s: invalid argument index %d", name, idx)
+		}
+	}
+	if api.PrintArgs != nil && *api.PrintArgs < 0 {
+		return nil, fmt.Errorf("%s: invalid print argument index %d", name, *api.PrintArgs)
+	}
+	conv := &JavaApiConv{args: api.Args, extraImports: javaImports(api.Imports), results: api.Results}
+	if strings.Contains(api.Java, "{") {
+		conv.template = api.Java
+	} else {
+		conv.method = api.Java
+	}
+	if api.PrintArgs != nil {
+		conv.printArgs = &PrintArgs{from: *api.PrintArgs}
+	}
+	return conv, nil
+}
+
+// javaImports returns the imports of qualified class names
+func javaImports(qualifiedNames []string) []*JavaImport {
+	imports := []*JavaImport{}
+	for _, qualifiedName := range qualifiedNames {
+		typeName := qualifiedName[strings.LastIndex(qualifiedName, ".")+1:]
+		imports = append(imports, &JavaImport{typeName, qualifiedName})
+	}
+	return imports
+}
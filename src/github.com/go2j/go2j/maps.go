@@ -0,0 +1,409 @@
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+)
+
+// map operations are translated to GoMap calls, they handle nil maps and missing keys like go
+
+func useGoMap(out *Output) {
+	out.outSource.addSysImportName(JI_GO_MAP.typeName, JI_GO_MAP.qualifiedName)
+}
+
+func mapTypeOf(expr ast.Expr, out *Output) *ast.MapType {
+	mapType, _ := underlyingType(typeOf(expr, out)).(*ast.MapType)
+	return mapType
+}
+
+func isBlank(expr ast.Expr) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && ident.Name == "_"
+}
+
+// javaPrimitiveOf returns the java primitive type of basic go types
+func javaPrimitiveOf(typeExpr ast.Expr) string {
+	if ident, ok := underlyingType(typeExpr).(*ast.Ident); ok && ident.Name != "string" {
+		return go2jType[ident.Name]
+	}
+	return ""
+}
+
+// convertZeroValue prints the zero value of a go type
+func convertZeroValue(typeExpr ast.Expr, out *Output) {
+	if conv := zeroTypeConv(typeExpr); conv != nil {
+		conv.addImports(out)
+		out.Print(conv.zero)
+		return
+	}
+	switch tp := underlyingType(typeExpr).(type) {
+	case *ast.Ident:
+		if tp.Name == "string" {
+			out.Print(`""`)
+			return
+		}
+		if enumData := oEnums.set[typeKey(tp.Name, out)]; enumData != nil {
+			out.Print(enumData.name, ".valueOf(0)")
+			return
+		}
+		switch javaPrimitiveOf(tp) {
+		case "boolean":
+			out.Print("false")
+		case "long":
+			out.Print("0L")
+		case "float":
+			out.Print("0.0f")
+		case "double":
+			out.Print("0.0")
+		case "byte", "short":
+			out.Print("(", javaPrimitiveOf(tp), ") 0")
+		case "int":
+			out.Print("0")
+		default:
+			out.Print("null")
+		}
+	case *ast.StructType:
+		opts := newResolveTypeOpts()
+		opts.ImplementationClass = true
+		out.Print("new ")
+		convertType(typeExpr, out, opts)
+		out.Print("()")
+	case *ast.ArrayType:
+		if tp.Len != nil {
+			convertArrayAlloc(tp, out)
+		} else {
+			out.Print("null")
+		}
+	default:
+		out.Print("null")
+	}
+}
+
+// convertBoxedValue converts a map key or value, java boxes int constants only to Integer
+func convertBoxedValue(typeExpr ast.Expr, expr ast.Expr, out *Output) {
+	if convertValueCopy(typeExpr, expr, out) {
+		return
+	}
+	if compositeLit, ok := expr.(*ast.CompositeLit); ok && compositeLit.Type == nil {
+		convertCompositeLit(compositeLit, typeExpr, out)
+		return
+	}
+	switch javaType := javaPrimitiveOf(typeExpr); javaType {
+	case "int":
+		if basicLit, ok := expr.(*ast.BasicLit); ok && basicLit.Kind == token.CHAR {
+			// char literals would be boxed to Character
+			out.Print("(int) ")
+		}
+	case "long", "byte", "short", "float", "double":
+		out.Print("(", javaType, ") ")
+		if _, isBinary := expr.(*ast.BinaryExpr); isBinary {
+			out.Print("(")
+			convertExpr(expr, out)
+			out.Print(")")
+			return
+		}
+	}
+	convertExpr(expr, out)
+}
+
+// updateExpr builds the value of x op= y and x++ assignments
+func updateExpr(x ast.Expr, tok token.Token, y ast.Expr) ast.Expr {
+	var op token.Token
+	switch tok {
+	case token.INC:
+		op = token.ADD
+	case token.DEC:
+		op = token.SUB
+	default:
+		// the assignment operators follow the binary operators in the same order
+		op = tok - token.ADD_ASSIGN + token.ADD
+	}
+	if y == nil {
+		y = &ast.BasicLit{Kind: token.INT, Value: "1"}
+	}
+	if _, isBinary := y.(*ast.BinaryExpr); isBinary {
+		y = &ast.ParenExpr{X: y}
+	}
+	return &ast.BinaryExpr{X: x, Op: op, Y: y}
+}
+
+func convertMapIndex(indexExpr *ast.IndexExpr, out *Output) bool {
+	mapType := mapTypeOf(indexExpr.X, out)
+	if mapType == nil {
+		return false
+	}
+	useGoMap(out)
+	isLeft := out.structuralInfo.AssignmentLeft
+	out.structuralInfo.AssignmentLeft = false
+	if isLeft {
+		out.Print("GoMap.put(")
+	} else {
+		out.Print("GoMap.get(")
+	}
+	convertExpr(indexExpr.X, out)
+	out.Print(", ")
+	convertMapKey(mapType, indexExpr.Index, out)
+	if isLeft {
+		// the assignment prints the value and closes the call
+		out.structuralInfo.MapAssignment = true
+		return true
+	}
+	out.Print(", ")
+	convertZeroValue(mapType.Value, out)
+	out.Print(")")
+	return true
+}
+
+// convertMapAssign translates m[k] = v, m[k] op= v and m[k]++
+func convertMapAssign(lhs ast.Expr, tok token.Token, rhs ast.Expr, out *Output) bool {
+	indexExpr, ok := lhs.(*ast.IndexExpr)
+	if !ok {
+		return false
+	}
+	mapType := mapTypeOf(indexExpr.X, out)
+	if mapType == nil {
+		return false
+	}
+	if tok != token.ASSIGN {
+		indexExpr = evalOnce(indexExpr, out)
+	}
+	useGoMap(out)
+	out.Print("GoMap.put(")
+	convertExpr(indexExpr.X, out)
+	out.Print(", ")
+	convertMapKey(mapType, indexExpr.Index, out)
+	out.Print(", ")
+	if tok == token.ASSIGN {
+		convertBoxedValue(mapType.Value, rhs, out)
+	} else {
+		convertBoxedValue(mapType.Value, updateExpr(indexExpr, tok, rhs), out)
+	}
+	out.Print(")")
+	convertStmtEnd(out)
+	return true
+}
+
+// convertMapLookupOk translates v, ok := m[k]
+func convertMapLookupOk(assignStmt *ast.AssignStmt, out *Output) bool {
+	if len(assignStmt.Lhs) != 2 || len(assignStmt.Rhs) != 1 {
+		return false
+	}
+	indexExpr, ok := assignStmt.Rhs[0].(*ast.IndexExpr)
+	if !ok {
+		return false
+	}
+	mapType := mapTypeOf(indexExpr.X, out)
+	if mapType == nil {
+		return false
+	}
+	valueExpr, okExpr := assignStmt.Lhs[0], assignStmt.Lhs[1]
+	if !isBlank(valueExpr) && !isBlank(okExpr) {
+		indexExpr = evalOnce(indexExpr, out)
+	}
+	convertValue := func() {
+		if isBlank(valueExpr) {
+			return
+		}
+		if _, isIdent := valueExpr.(*ast.Ident); !isIdent {
+			// slice elements and map entries have their own assignments
+			convertAssignStmt(&ast.AssignStmt{Lhs: []ast.Expr{valueExpr}, TokPos: assignStmt.TokPos, Tok: token.ASSIGN, Rhs: []ast.Expr{indexExpr}}, out)
+			return
+		}
+		if isNewDef(valueExpr, assignStmt) {
+			out.Print("")
+			nrto := newResolveTypeOpts()
+			nrto.FunctionAsReference = true
+			convertType(mapType.Value, out, nrto)
+			out.Print(" ")
+			out.AddVar(valueExpr.(*ast.Ident).Name, mapType.Value)
+		}
+		convertExpr(valueExpr, out)
+		out.Print(" = ")
+		convertMapIndex(indexExpr, out)
+		convertStmtEnd(out)
+	}
+	// the lookup must not see the assigned value
+	valueFirst := isBlank(valueExpr) || !refersTo(indexExpr, map[string]bool{rootName(valueExpr): true})
+	if valueFirst {
+		convertValue()
+	}
+	if !isBlank(okExpr) {
+		if isNewDef(okExpr, assignStmt) {
+			out.Print("boolean ")
+			out.AddVar(okExpr.(*ast.Ident).Name, ast.NewIdent("bool"))
+		}
+		convertExpr(okExpr, out)
+		out.Print(" = GoMap.has(")
+		convertExpr(indexExpr.X, out)
+		out.Print(", ")
+		convertMapKey(mapType, indexExpr.Index, out)
+		out.Print(")")
+		convertStmtEnd(out)
+	}
+	if !valueFirst {
+		convertValue()
+	}
+	return true
+}
+
+// convertMapCall translates len, delete and clear of maps
+func convertMapCall(callExpr *ast.CallExpr, name string, out *Output) bool {
+	mapType := mapTypeOf(callExpr.Args[0], out)
+	if mapType == nil {
+		return false
+	}
+	useGoMap(out)
+	out.Print("GoMap.", name, "(")
+	convertExpr(callExpr.Args[0], out)
+	if name == "delete" && len(callExpr.Args) == 2 {
+		out.Print(", ")
+		convertMapKey(mapType, callExpr.Args[1], out)
+	}
+	out.Print(")")
+	return true
+}
+
+// isArrayKey tells whether the keys of a map are arrays, java arrays are equal by identity only
+func isArrayKey(mapType *ast.MapType) bool {
+	arrayType, ok := underlyingType(mapType.Key).(*ast.ArrayType)
+	return ok && arrayType.Len != nil
+}
+
+// convertMapKeyType prints the java type of the keys of a map, array keys are wrapped in GoMap.Key
+func convertMapKeyType(mapType *ast.MapType, out *Output, opts *ResolveTypeOpts) {
+	if !isArrayKey(mapType) {
+		convertType(mapType.Key, out, opts)
+		return
+	}
+	useGoMap(out)
+	out.Print("GoMap.Key<")
+	nrto := newResolveTypeOpts()
+	nrto.FunctionAsReference = true
+	convertType(mapType.Key, out, nrto)
+	out.Print(">")
+}
+
+// convertMapKey prints a key of a map
+func convertMapKey(mapType *ast.MapType, expr ast.Expr, out *Output) {
+	if !isArrayKey(mapType) {
+		convertBoxedValue(mapType.Key, expr, out)
+		return
+	}
+	useGoMap(out)
+	out.Print("GoMap.key(")
+	convertBoxedValue(mapType.Key, expr, out)
+	out.Print(")")
+}
+
+func convertMapTypeArgs(mapType *ast.MapType, out *Output) {
+	nrto := newResolveTypeOpts()
+	nrto.PrimitiveAsObject = true
+	nrto.FunctionAsReference = true
+	out.Print("<")
+	convertMapKeyType(mapType, out, nrto)
+	out.Print(", ")
+	convertType(mapType.Value, out, nrto)
+	out.Print(">")
+}
+
+// convertMapLit builds the maps of map literals with GoMap.Builder
+func convertMapLit(compositeLit *ast.CompositeLit, mapType *ast.MapType, out *Output) {
+	if len(compositeLit.Elts) == 0 {
+		out.outSource.addSysImportName("HashMap", "java.util.HashMap")
+		out.Print("new HashMap")
+		convertMapTypeArgs(mapType, out)
+		out.Print("()")
+		return
+	}
+	useGoMap(out)
+	out.Print("new GoMap.Builder")
+	convertMapTypeArgs(mapType, out)
+	out.Print("()")
+	for _, elt := range compositeLit.Elts {
+		keyValue, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		recordConversion(keyValue.Key, mapType.Key, out)
+		recordConversion(keyValue.Value, mapType.Value, out)
+		out.Print(".put(")
+		convertMapKey(mapType, keyValue.Key, out)
+		out.Print(", ")
+		convertBoxedValue(mapType.Value, keyValue.Value, out)
+		out.Print(")")
+	}
+	out.Print(".build()")
+}
+
+// convertMapRangeStmt iterates a map with GoMap.Range
+func convertMapRangeStmt(rangeStmt *ast.RangeStmt, out *Output) bool {
+	mapType := mapTypeOf(rangeStmt.X, out)
+	if mapType == nil {
+		return false
+	}
+	useGoMap(out)
+	// nested loops need distinct names
+	rangeName := "range" + strconv.Itoa(out.tabs)
+	out.Print("for (GoMap.Range")
+	convertMapTypeArgs(mapType, out)
+	out.Print(" ", rangeName, " = GoMap.range(")
+	convertExpr(rangeStmt.X, out)
+	out.Print("); ", rangeName, ".next();)")
+	isDef := rangeStmt.Tok == token.DEFINE
+	convertBlockStmt(rangeStmt.Body, out, func(out *Output) {
+		vars := []struct {
+			expr     ast.Expr
+			typeExpr ast.Expr
+			field    string
+		}{{rangeStmt.Key, mapType.Key, "key"}, {rangeStmt.Value, mapType.Value, "value"}}
+		for _, v := range vars {
+			name := rangeVarName(v.expr)
+			if name == "" {
+				continue
+			}
+			if isDef {
+				out.Print("")
+				nrto := newResolveTypeOpts()
+				nrto.FunctionAsReference = true
+				convertType(v.typeExpr, out, nrto)
+				out.Print(" ")
+				out.AddVar(name, v.typeExpr)
+			}
+			if v.field == "key" && isArrayKey(mapType) {
+				out.Println(name, "=", rangeName+".key.array;")
+				continue
+			}
+			out.Println(name, "=", rangeName+"."+v.field+";")
+		}
+	})
+	return true
+}
+
+// convertMapsKeys translates maps.Keys and maps.Values of primitive keys or values, they are
+// unboxed to primitive slices. the others are GoMaps calls returning a Slice
+func convertMapsKeys(callExpr *ast.CallExpr, out *Output) bool {
+	name := resolveTypeName(callExpr.Fun, false)
+	if (name != "maps.Keys" && name != "maps.Values") || len(callExpr.Args) != 1 {
+		return false
+	}
+	mapType := mapTypeOf(callExpr.Args[0], out)
+	if mapType == nil {
+		return false
+	}
+	elemType, method := mapType.Key, "keyArray"
+	if name == "maps.Values" {
+		elemType, method = mapType.Value, "valueArray"
+	}
+	className := sliceClass(elemType)
+	if className == "Slice" {
+		return false
+	}
+	javaType := javaPrimitiveOf(elemType)
+	useSliceClass(className, out)
+	out.outSource.addSysImportName(JI_GO_MAPS.typeName, JI_GO_MAPS.qualifiedName)
+	out.Print(className, ".wrap((", javaType, "[]) GoMaps.", method, "(")
+	convertExpr(callExpr.Args[0], out)
+	out.Print(", ", javaType, ".class))")
+	return true
+}
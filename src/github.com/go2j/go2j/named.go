@@ -0,0 +1,156 @@
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+)
+
+// named slices, maps, functions and basic types are their underlying java types. the types with methods
+// get a class too, its value field holds the value. methods are called on an instance wrapping the value
+// and the values converted to interfaces are wrapped, so named slices implement GoSort.Interface
+
+// namedValueKey returns the key of a named type of the project that is not a struct or an interface
+func namedValueKey(typeExpr ast.Expr) string {
+	key := ""
+	switch tp := typeExpr.(type) {
+	case *ast.Ident:
+		key = resolveKey(strings.Title(tp.Name))
+	case *ast.SelectorExpr:
+		if ident, ok := tp.X.(*ast.Ident); !ok || ownPackagePath(ident.Name, "") == "" {
+			return ""
+		}
+		key = resolveKey(typeKeyIn(tp, ""))
+	case *ast.ParenExpr:
+		return namedValueKey(tp.X)
+	default:
+		return ""
+	}
+	outType := oTypes.get(key)
+	if outType == nil || outType.valueType == nil || outType.system || oEnums.set[key] != nil {
+		return ""
+	}
+	return key
+}
+
+// hasValueClass tells whether a named type has a class for its methods
+func hasValueClass(key string) bool {
+	outType := oTypes.get(key)
+	return outType != nil && outType.valueType != nil && !outType.system && oEnums.set[key] == nil && len(outType.methods) > 0
+}
+
+// valueClassOf returns the named type of a value if it has a class
+func valueClassOf(expr ast.Expr, out *Output) ast.Expr {
+	typeExpr := typeOf(expr, out)
+	if starExpr, ok := typeExpr.(*ast.StarExpr); ok {
+		typeExpr = starExpr.X
+	}
+	if key := namedValueKey(typeExpr); key == "" || !hasValueClass(key) {
+		return nil
+	}
+	return typeExpr
+}
+
+func convertValueClass(typeSpec *ast.TypeSpec, out *Output) {
+	ident := typeSpec.Name
+	out.Print("public ")
+	if !ident.IsExported() {
+		// inner class in package
+		out.Print("static ")
+	}
+	name := strings.Title(ident.Name)
+	out.Print("class ", name)
+
+	key := typeKey(ident.Name, out)
+	oTypes.setImplementsPos(key, out.getPosition())
+
+	out.Println(" {")
+	nrto := newResolveTypeOpts()
+	nrto.FunctionAsReference = true
+	outTab := out.AddTab()
+	outTab.Print("public ")
+	convertType(typeSpec.Type, outTab, nrto)
+	outTab.Print(" value")
+	convertStmtEnd(outTab)
+	outTab.Println("")
+
+	outTab.Print("public ", name, "(")
+	convertType(typeSpec.Type, outTab, nrto)
+	outTab.Println(" value) {")
+	outBody := outTab.AddTab()
+	outBody.Print("this.value = value")
+	convertStmtEnd(outBody)
+	outTab.Println("}")
+	outTab.Println("")
+
+	oTypes.setFunctionsPos(key, outTab.getPosition())
+	convertPendingMethods(key)
+
+	out.Println("}")
+}
+
+// convertNewValue wraps a value in the class of its named type
+func convertNewValue(classType ast.Expr, expr ast.Expr, out *Output) {
+	nrto := newResolveTypeOpts()
+	nrto.ValueClass = true
+	out.Print("new ")
+	convertType(classType, out, nrto)
+	out.Print("(")
+	convertExpr(expr, out)
+	out.Print(")")
+}
+
+// convertInterfaceValue wraps a value of a named type with methods converted to an interface.
+// fmt only calls the String and Error methods of the values of empty interfaces
+func convertInterfaceValue(typeExpr ast.Expr, expr ast.Expr, out *Output) bool {
+	if typeExpr == nil || !isInterfaceType(typeExpr) && !isSortInterfaceType(typeExpr) {
+		return false
+	}
+	classType := valueClassOf(expr, out)
+	if classType == nil || isEmptyInterface(typeExpr) && !isStringer(namedValueKey(classType)) {
+		return false
+	}
+	convertNewValue(classType, expr, out)
+	return true
+}
+
+func isEmptyInterface(typeExpr ast.Expr) bool {
+	switch tp := underlyingType(typeExpr).(type) {
+	case *ast.Ident:
+		return tp.Name == "any"
+	case *ast.InterfaceType:
+		return len(tp.Methods.List) == 0
+	}
+	return false
+}
+
+// isStringer tells whether fmt prints the values of a type by their String or Error method
+func isStringer(key string) bool {
+	methods := oTypes.methodSet(key)
+	return methods["String"] == "() (string)" || methods["Error"] == "() (string)"
+}
+
+// convertValueMethod prints the receiver of a method of a named type with a class
+func convertValueMethod(selectorExpr *ast.SelectorExpr, out *Output) bool {
+	classType := valueClassOf(selectorExpr.X, out)
+	if classType == nil {
+		return false
+	}
+	outType := oTypes.get(namedValueKey(classType))
+	if _, isMethod := outType.methods[selectorExpr.Sel.Name]; !isMethod {
+		return false
+	}
+	if outType.pointerMethods[selectorExpr.Sel.Name] {
+		out.Warn(selectorExpr, "the method "+selectorExpr.Sel.Name+" updates a copy of the receiver")
+	}
+	receiver := selectorExpr.X
+	if parenExpr, ok := receiver.(*ast.ParenExpr); ok {
+		receiver = parenExpr.X
+	}
+	if unaryExpr, ok := receiver.(*ast.UnaryExpr); ok && unaryExpr.Op == token.AND {
+		receiver = unaryExpr.X
+	}
+	convertNewValue(classType, receiver, out)
+	out.Print(".", selectorExpr.Sel.Name)
+	return true
+}
@@ -0,0 +1,388 @@
+package main
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+)
+
+// binary expressions are printed with the java precedence of their operators, operators java does not have are lowered.
+// java calculates with int at least, byte and short results are narrowed to wrap around like in go
+
+// javaPrecedence of the binary operators, higher binds tighter
+var javaPrecedence = map[token.Token]int{
+	token.MUL: 12, token.QUO: 12, token.REM: 12,
+	token.ADD: 11, token.SUB: 11,
+	token.SHL: 10, token.SHR: 10,
+	token.LSS: 9, token.LEQ: 9, token.GTR: 9, token.GEQ: 9,
+	token.EQL: 8, token.NEQ: 8,
+	token.AND: 7, token.AND_NOT: 7,
+	token.XOR:  6,
+	token.OR:   5,
+	token.LAND: 4,
+	token.LOR:  3,
+}
+
+// casts and method calls bind tighter than any binary operator
+const callPrecedence = 15
+
+func isComparison(op token.Token) bool {
+	switch op {
+	case token.EQL, token.NEQ, token.LSS, token.LEQ, token.GTR, token.GEQ:
+		return true
+	}
+	return false
+}
+
+func isOrdering(op token.Token) bool {
+	return isComparison(op) && op != token.EQL && op != token.NEQ
+}
+
+// operandType returns the type of the operands, untyped constants take the type of the other operand
+func operandType(binaryExpr *ast.BinaryExpr, out *Output) ast.Expr {
+	xConst := isConstExpr(binaryExpr.X)
+	if binaryExpr.Op == token.SHL || binaryExpr.Op == token.SHR {
+		// the count does not give its type to the shifted operand
+		_, isLit := binaryExpr.X.(*ast.BasicLit)
+		xConst = isLit || isPackageConst(binaryExpr.X)
+	}
+	if !xConst || isConstExpr(binaryExpr.Y) {
+		if xType := typeOf(binaryExpr.X, out); xType != nil {
+			return xType
+		}
+	}
+	return typeOf(binaryExpr.Y, out)
+}
+
+func isNarrowType(javaType string) bool {
+	return javaType == "byte" || javaType == "short"
+}
+
+// isNarrowed tells whether the result of an arithmetic expression is cast back to byte or short
+func isNarrowed(binaryExpr *ast.BinaryExpr, out *Output) bool {
+	if isComparison(binaryExpr.Op) || binaryExpr.Op == token.LAND || binaryExpr.Op == token.LOR {
+		return false
+	}
+	return isNarrowType(javaPrimitiveOf(typeOf(binaryExpr, out)))
+}
+
+// javaPrecedenceOf returns the precedence of the java expression a binary expression is translated to
+func javaPrecedenceOf(binaryExpr *ast.BinaryExpr, out *Output) int {
+	op := binaryExpr.Op
+	switch {
+	case op == token.EQL || op == token.NEQ:
+		if !isNilIdent(binaryExpr.X) && !isNilIdent(binaryExpr.Y) &&
+			equalityFunc(typeOf(binaryExpr.X, out), typeOf(binaryExpr.Y, out), out) != "" {
+			return callPrecedence
+		}
+	case isNarrowed(binaryExpr, out) || enumArithmetic(binaryExpr, out) != nil:
+		return callPrecedence
+	case op == token.QUO || op == token.REM:
+		if conversionKind(operandType(binaryExpr, out)) == "uint" {
+			return callPrecedence
+		}
+	case op == token.SHL || op == token.SHR:
+		// variable shifts are lowered to parenthesized conditionals, signed right shifts keep the operator
+		xType := operandType(binaryExpr, out)
+		if (op == token.SHL || conversionKind(xType) == "uint") && shiftWidth(javaPrimitiveOf(xType)) > 0 && !isConstExpr(binaryExpr.Y) {
+			return callPrecedence
+		}
+	}
+	return javaPrecedence[op]
+}
+
+// convertBinaryOperand parenthesizes an operand java would group differently
+func convertBinaryOperand(expr ast.Expr, precedence int, isRight bool, out *Output) {
+	if binaryExpr, ok := expr.(*ast.BinaryExpr); ok {
+		operandPrecedence := javaPrecedenceOf(binaryExpr, out)
+		if operandPrecedence < precedence || isRight && operandPrecedence == precedence {
+			out.Print("(")
+			convertBinaryExpr(binaryExpr, out)
+			out.Print(")")
+			return
+		}
+	}
+	convertExpr(expr, out)
+}
+
+// convertTypedOperand prints constant operands as literals of the java type of the other operand
+func convertTypedOperand(expr ast.Expr, javaType string, precedence int, isRight bool, out *Output) {
+	if value := evalConst(expr, 0, lookupConst(oFileSet.currentPackage)); value.Kind() == constant.Int {
+		out.Print(javaConstLiteral(value, javaType))
+		return
+	}
+	if isBoxedRead(expr, out) {
+		convertUnboxed(expr, out)
+		return
+	}
+	convertBinaryOperand(expr, precedence, isRight, out)
+}
+
+// convertUnsignedOperand widens byte and short operands without their sign
+func convertUnsignedOperand(expr ast.Expr, javaType string, out *Output) {
+	if value := evalConst(expr, 0, lookupConst(oFileSet.currentPackage)); value.Kind() == constant.Int {
+		out.Print(value.ExactString())
+		return
+	}
+	out.Print("(")
+	if isBoxedRead(expr, out) {
+		convertUnboxed(expr, out)
+	} else {
+		convertBinaryOperand(expr, javaPrecedence[token.AND], false, out)
+	}
+	out.Print(" & ", javaIntMasks[javaType], ")")
+}
+
+func convertBinaryExpr(binaryExpr *ast.BinaryExpr, out *Output) {
+	if value := evalConst(binaryExpr, 0, lookupConst(oFileSet.currentPackage)); value.Kind() == constant.Float {
+		// java has no exact constant arithmetic, the folded value may fit where the operands do not
+		out.Print(javaConstLiteral(value, javaPrimitiveOf(typeOf(binaryExpr, out))))
+		return
+	}
+	if convertEqualityExpr(binaryExpr, out) || convertEnumBinaryExpr(binaryExpr, out) {
+		return
+	}
+	op := binaryExpr.Op
+	xType := operandType(binaryExpr, out)
+	if isOrdering(op) && isStringType(xType) {
+		// strings are ordered by their bytes
+		printGoStringCall("compare", out, binaryExpr.X, binaryExpr.Y)
+		out.Print(" ", op, " 0")
+		return
+	}
+	javaType := javaPrimitiveOf(xType)
+	unsigned := conversionKind(xType) == "uint"
+	if isNarrowed(binaryExpr, out) {
+		out.Print("(", javaPrimitiveOf(typeOf(binaryExpr, out)), ") (")
+		defer out.Print(")")
+	}
+	switch {
+	case (op == token.SHL || op == token.SHR) && shiftWidth(javaType) > 0 && !isConstExpr(binaryExpr.Y):
+		convertVariableShift(binaryExpr, javaType, unsigned, out)
+		return
+	case unsigned && isNarrowType(javaType) && (isComparison(op) || op == token.QUO || op == token.REM || op == token.SHR):
+		convertUnsignedOperand(binaryExpr.X, javaType, out)
+		out.Print(" ", op, " ")
+		if op == token.SHR {
+			convertBinaryOperand(binaryExpr.Y, javaPrecedence[op], true, out)
+		} else {
+			convertUnsignedOperand(binaryExpr.Y, javaType, out)
+		}
+		return
+	case unsigned && (isOrdering(op) || op == token.QUO || op == token.REM):
+		className := "Integer"
+		if javaType == "long" {
+			className = "Long"
+		}
+		switch op {
+		case token.QUO:
+			out.Print(className, ".divideUnsigned(")
+		case token.REM:
+			out.Print(className, ".remainderUnsigned(")
+		default:
+			out.Print(className, ".compareUnsigned(")
+		}
+		convertTypedOperand(binaryExpr.X, javaType, 0, false, out)
+		out.Print(", ")
+		convertTypedOperand(binaryExpr.Y, javaType, 0, false, out)
+		out.Print(")")
+		if isOrdering(op) {
+			out.Print(" ", op, " 0")
+		}
+		return
+	case unsigned && (op == token.EQL || op == token.NEQ):
+		convertTypedOperand(binaryExpr.X, javaType, javaPrecedence[op], false, out)
+		out.Print(" ", op, " ")
+		convertTypedOperand(binaryExpr.Y, javaType, javaPrecedence[op], true, out)
+		return
+	case op == token.SHL || op == token.SHR:
+		// java takes the shift count modulo the size of int and long, go shifts all bits out
+		if count := evalConst(binaryExpr.Y, 0, lookupConst(oFileSet.currentPackage)); count.Kind() == constant.Int && shiftWidth(javaType) > 0 {
+			bits := shiftWidth(javaType)
+			if n, exact := constant.Int64Val(count); !exact || n >= bits {
+				if op == token.SHL || unsigned {
+					convertShiftedOut(javaType, out)
+					return
+				}
+				binaryExpr = &ast.BinaryExpr{X: binaryExpr.X, Op: op, Y: &ast.BasicLit{Kind: token.INT, Value: constant.MakeInt64(bits - 1).ExactString()}}
+			}
+		}
+	}
+	precedence := javaPrecedence[op]
+	// the operands of constant expressions keep the names of their constants
+	typed := !isConstExpr(binaryExpr)
+	convertOperandOf(binaryExpr.X, javaType, typed, precedence, false, out)
+	switch {
+	case op == token.AND_NOT:
+		out.Print(" & ~")
+		convertOperandOf(binaryExpr.Y, javaType, typed, callPrecedence, true, out)
+		return
+	case op == token.SHL || op == token.SHR:
+		if op == token.SHR && unsigned {
+			out.Print(" >>> ")
+		} else {
+			out.Print(" ", op, " ")
+		}
+		// the count keeps its type
+		convertOperandOf(binaryExpr.Y, "", false, precedence, true, out)
+		return
+	default:
+		out.Print(" ", op, " ")
+	}
+	convertOperandOf(binaryExpr.Y, javaType, typed, precedence, true, out)
+}
+
+// convertOperandOf prints the constant operands of a typed operand and the constants of other packages as literals
+// of the java type of the other operand, java does not convert long and double constants to int and float
+func convertOperandOf(expr ast.Expr, javaType string, typed bool, precedence int, isRight bool, out *Output) {
+	value := evalConst(expr, 0, lookupConst(oFileSet.currentPackage))
+	if javaType == "" || javaType == "boolean" || value.Kind() != constant.Int && value.Kind() != constant.Float ||
+		!typed && !isPackageConst(expr) {
+		convertBinaryOperand(expr, precedence, isRight, out)
+		return
+	}
+	if _, isIdent := expr.(*ast.Ident); isIdent && javaPrimitiveOf(typeOf(expr, out)) == javaType {
+		// the constant has the java type
+		convertBinaryOperand(expr, precedence, isRight, out)
+		return
+	}
+	if isNarrowType(javaType) {
+		// bytes and shorts are calculated as int
+		javaType = "int"
+	}
+	out.Print(javaConstLiteral(value, javaType))
+}
+
+// shiftWidth returns the bits java shifts an integer type in, bytes and shorts are shifted as int
+func shiftWidth(javaType string) int64 {
+	switch javaType {
+	case "byte", "short", "int":
+		return 32
+	case "long":
+		return 64
+	}
+	return 0
+}
+
+// isShiftedOut tells whether a shift assignment may shift more bits than java does
+func isShiftedOut(lhs ast.Expr, tok token.Token, count ast.Expr, out *Output) bool {
+	bits := shiftWidth(javaPrimitiveOf(typeOf(lhs, out)))
+	if tok != token.SHL_ASSIGN && tok != token.SHR_ASSIGN || bits == 0 {
+		return false
+	}
+	value := evalConst(count, 0, lookupConst(oFileSet.currentPackage))
+	if value.Kind() != constant.Int {
+		return true
+	}
+	n, exact := constant.Int64Val(value)
+	return !exact || n >= bits
+}
+
+func convertShiftedOut(javaType string, out *Output) {
+	if javaType == "long" {
+		out.Print("0L")
+	} else {
+		out.Print("0")
+	}
+}
+
+// convertShiftCountTest prints whether a shift count shifts all bits out
+func convertShiftCountTest(count ast.Expr, bits int64, out *Output) {
+	countType := typeOf(count, out)
+	countJava := javaPrimitiveOf(countType)
+	switch {
+	case conversionKind(countType) == "uint" && isNarrowType(countJava):
+		convertUnsignedOperand(count, countJava, out)
+	case conversionKind(countType) == "uint":
+		className := "Integer"
+		if countJava == "long" {
+			className = "Long"
+		}
+		out.Print(className, ".compareUnsigned(")
+		convertExpr(count, out)
+		out.Print(", ", bits, ") >= 0")
+		return
+	default:
+		convertBinaryOperand(count, javaPrecedence[token.GEQ], false, out)
+	}
+	out.Print(" >= ", bits)
+}
+
+// convertVariableShift lowers a shift by a variable count, counts of the size of the operand or more shift all bits out
+func convertVariableShift(binaryExpr *ast.BinaryExpr, javaType string, unsigned bool, out *Output) {
+	bits := shiftWidth(javaType)
+	precedence := javaPrecedence[binaryExpr.Op]
+	if binaryExpr.Op == token.SHR && !unsigned {
+		// the sign fills all bits
+		convertBinaryOperand(binaryExpr.X, precedence, false, out)
+		out.Print(" >> (")
+		convertShiftCountTest(binaryExpr.Y, bits, out)
+		out.Print(" ? ", bits-1, " : ")
+		convertExpr(binaryExpr.Y, out)
+		out.Print(")")
+		return
+	}
+	if !isNarrowed(binaryExpr, out) {
+		out.Print("(")
+		defer out.Print(")")
+	}
+	convertShiftCountTest(binaryExpr.Y, bits, out)
+	out.Print(" ? ")
+	convertShiftedOut(javaType, out)
+	out.Print(" : ")
+	switch {
+	case binaryExpr.Op == token.SHL:
+		convertBinaryOperand(binaryExpr.X, precedence, false, out)
+		out.Print(" << ")
+	case isNarrowType(javaType):
+		convertUnsignedOperand(binaryExpr.X, javaType, out)
+		out.Print(" >> ")
+	default:
+		convertBinaryOperand(binaryExpr.X, precedence, false, out)
+		out.Print(" >>> ")
+	}
+	convertBinaryOperand(binaryExpr.Y, precedence, true, out)
+}
+
+// convertUnaryExpr translates the bitwise complement ^x to ~x, negation of bytes and shorts is narrowed
+func convertUnaryExpr(unaryExpr *ast.UnaryExpr, out *Output) {
+	if convertReceive(unaryExpr, out) || convertAddressOf(unaryExpr, out) {
+		return
+	}
+	if unaryExpr.Op == token.SUB || unaryExpr.Op == token.XOR {
+		if javaType := javaPrimitiveOf(typeOf(unaryExpr.X, out)); isNarrowType(javaType) {
+			out.Print("(", javaType, ") ")
+		}
+	}
+	convertUnOp(unaryExpr.Op, out)
+	if _, isBinary := unaryExpr.X.(*ast.BinaryExpr); isBinary {
+		out.Print("(")
+		convertExpr(unaryExpr.X, out)
+		out.Print(")")
+		return
+	}
+	convertExpr(unaryExpr.X, out)
+}
+
+// convertOpAssign translates the op= assignments java has no operator for
+func convertOpAssign(lhs ast.Expr, tok token.Token, rhs ast.Expr, out *Output) bool {
+	switch tok {
+	case token.ASSIGN, token.DEFINE:
+		return false
+	case token.AND_NOT_ASSIGN:
+	case token.QUO_ASSIGN, token.REM_ASSIGN, token.SHL_ASSIGN, token.SHR_ASSIGN:
+		if !isShiftedOut(lhs, tok, rhs, out) && (tok == token.SHL_ASSIGN || conversionKind(typeOf(lhs, out)) != "uint") {
+			return false
+		}
+	default:
+		return convertEnumUpdate(lhs, tok, rhs, out)
+	}
+	if indexExpr, ok := lhs.(*ast.IndexExpr); ok {
+		lhs = evalOnce(indexExpr, out)
+	}
+	convertExpr(lhs, out)
+	out.Print(" = ")
+	convertExpr(updateExpr(lhs, tok, rhs), out)
+	convertStmtEnd(out)
+	return true
+}
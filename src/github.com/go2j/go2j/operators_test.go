@@ -0,0 +1,37 @@
+package main
+
+import "testing"
+
+// constant operands are literals of the java type of the other operand
+func TestTypedConstOperands(t *testing.T) {
+	tr := translate(t, map[string]string{"app/main.go": `package main
+
+import "fmt"
+
+const big = 4000000000
+
+func main() {
+	var u uint32 = 5
+	v := u & 0xFFFFFFFF
+	w := u + 4000000000
+	x := big + u
+	var f float32 = 1.5
+	g := f * 0.1
+	var b byte = 3
+	c := b + 200
+	var s uint64 = 9
+	e := s &^ 0xFFFFFFFFFFFFFFF0
+	q := u >> 31
+	fmt.Println(v, w, x, g, c, e, q)
+}
+`})
+	expectContains(t, tr.java(t, "app/Main.java"),
+		"int v = u & -1;",
+		"int w = u + -294967296;",
+		"int x = -294967296 + u;",
+		"float g = f * 0.1f;",
+		"byte c = (byte) (b + 200);",
+		"long e = s & ~-16L;",
+		"int q = u >>> 31;",
+	)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"go/ast"
 	"go/token"
+	"os"
 	//"go/printer"
 	"strings"
 )
@@ -31,6 +32,7 @@ type InsertPoint struct {
 	postEvalStmt  ast.Stmt
 	postEvalStmtFn func(ast.Stmt, *Output)
 	postEvalFn func(*Output)
+	pkgPath    string
 }
 
 type BlockInfo struct {
@@ -38,12 +40,18 @@ type BlockInfo struct {
 	FuncReturnTypes  map[string]ast.Expr
 	ReceiverTypeName string
 	CurrentFunctionName string
+	CurrentFuncType *ast.FuncType
+	CurrentFuncDecl *ast.FuncDecl
+	Renames map[string]string
+	EnumCounters map[string]*EnumData
 }
 
 type StructuralInfo struct {
 	AssignmentLeft bool
 	MapAssignment bool
-	RangeStmtVars bool
+	LocalDecl bool
+	// the variables declared as cells print the cell, not its value
+	CellRef bool
 }
 
 func newInsertableOut() *InsertableOut {
@@ -59,7 +67,7 @@ func newResolveTypeOpts() *ResolveTypeOpts {
 }
 
 func (out *Output) getPosition() *InsertPoint {
-	insertPoint := &InsertPoint{out, newInsertableOut(), len([]byte(out.out.buf.String())), nil, nil, nil}
+	insertPoint := &InsertPoint{out, newInsertableOut(), len([]byte(out.out.buf.String())), nil, nil, nil, oFileSet.currentPackage}
 	out.out.insertPoints = append(out.out.insertPoints, insertPoint)
 	return insertPoint
 }
@@ -140,6 +148,31 @@ func (out *Output) GetCurrentFunctionName() string {
 	return out.blockInfo.CurrentFunctionName
 }
 
+func (out *Output) SetCurrentFuncType(funcType *ast.FuncType) {
+	out.blockInfo.CurrentFuncType = funcType
+}
+
+func (out *Output) GetCurrentFuncType() *ast.FuncType {
+	return out.blockInfo.CurrentFuncType
+}
+
+func (out *Output) SetCurrentFuncDecl(funcDecl *ast.FuncDecl) {
+	out.blockInfo.CurrentFuncDecl = funcDecl
+}
+
+func (out *Output) GetCurrentFuncDecl() *ast.FuncDecl {
+	return out.blockInfo.CurrentFuncDecl
+}
+
+// Rename prints a variable with an other name, an empty name removes the renaming
+func (out *Output) Rename(name, javaName string) {
+	if javaName == "" {
+		delete(out.blockInfo.Renames, name)
+		return
+	}
+	out.blockInfo.Renames[name] = javaName
+}
+
 func (out *Output) GetFuncType(name string) ast.Expr {
 	//out.Println("GET:", name, "->", out.blockInfo.VariableTypes[name])
 	return out.blockInfo.FuncReturnTypes[name]
@@ -162,5 +195,23 @@ func (out *Output) BanStmtEnd() *Output {
 }
 
 func newOutput(fset *token.FileSet, outSource *OutSource) *Output {
-	return &Output{outSource.out, 0, false, fset, false, BlockInfo{map[string]ast.Expr{}, map[string]ast.Expr{}, "", ""}, outSource, &StructuralInfo{}}
+	return &Output{outSource.out, 0, false, fset, false, BlockInfo{map[string]ast.Expr{}, map[string]ast.Expr{}, "", "", nil, nil, map[string]string{}, map[string]*EnumData{}}, outSource, &StructuralInfo{}}
+}
+
+// Warn reports a construct the java code does not keep the semantics of
+func (out *Output) Warn(node ast.Node, message string) {
+	fmt.Fprintln(os.Stderr, out.getFset().Position(node.Pos()).String()+": warning:", message)
+}
+
+// oErrors holds the reported constructs that are not translated, no java code is written then.
+// types may be converted more than once
+var oErrors = map[string]bool{}
+
+// Error reports a construct the java code cannot express
+func (out *Output) Error(node ast.Node, message string) {
+	report := out.getFset().Position(node.Pos()).String() + ": error: " + message
+	if !oErrors[report] {
+		oErrors[report] = true
+		fmt.Fprintln(os.Stderr, report)
+	}
 }
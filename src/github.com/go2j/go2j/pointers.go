@@ -0,0 +1,163 @@
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+// pointers to structs and arrays are java references. java copies the other values,
+// the values they point to are kept in GoPtr cells. the local variables whose address
+// is taken are declared as cells, the variable and its pointers share the value.
+// the address of other variables, parameters and fields of such values cannot be taken
+
+// oAddressTaken holds the local variables of the converted functions used with &
+var oAddressTaken = map[*ast.Object]bool{}
+
+// oCells holds the variables declared as cells
+var oCells = map[*ast.Object]bool{}
+
+// collectAddressTaken finds the local variables of a function used with &
+func collectAddressTaken(body *ast.BlockStmt) {
+	ast.Inspect(body, func(node ast.Node) bool {
+		if unaryExpr, ok := node.(*ast.UnaryExpr); ok && unaryExpr.Op == token.AND {
+			if ident, ok := unaryExpr.X.(*ast.Ident); ok && ident.Obj != nil && ident.Obj.Kind == ast.Var {
+				oAddressTaken[ident.Obj] = true
+			}
+		}
+		return true
+	})
+}
+
+func isCellVar(ident *ast.Ident) bool {
+	return ident.Obj != nil && oCells[ident.Obj]
+}
+
+func useGoPtr(out *Output) {
+	out.outSource.addSysImportName(JI_GO_PTR.typeName, JI_GO_PTR.qualifiedName)
+}
+
+// isCellElem tells whether the values pointers point to of a type are kept in cells
+func isCellElem(elemType ast.Expr) bool {
+	switch tp := underlyingType(elemType).(type) {
+	case *ast.Ident:
+		_, basic := go2jType[underlyingTypeName(tp.Name)]
+		return basic
+	case *ast.ArrayType:
+		return tp.Len == nil
+	case *ast.MapType:
+		return true
+	}
+	return false
+}
+
+func isCellPointer(typeExpr ast.Expr) bool {
+	starExpr, ok := typeExpr.(*ast.StarExpr)
+	return ok && isCellElem(starExpr.X)
+}
+
+// isCellRead tells whether an expression reads the value of a cell, the java value is boxed
+func isCellRead(expr ast.Expr, out *Output) bool {
+	switch tp := expr.(type) {
+	case *ast.StarExpr:
+		return isCellPointer(typeOf(tp.X, out))
+	case *ast.Ident:
+		return isCellVar(tp)
+	}
+	return false
+}
+
+func convertCellType(elemType ast.Expr, out *Output) {
+	useGoPtr(out)
+	out.Print("GoPtr")
+	convertSliceTypeArg(elemType, out)
+}
+
+// convertNewCell translates new(T) and &x to a cell holding a zero value or x
+func convertNewCell(elemType ast.Expr, value ast.Expr, out *Output) {
+	out.Print("new ")
+	convertCellType(elemType, out)
+	out.Print("(")
+	if value == nil {
+		convertZeroValue(elemType, out)
+	} else {
+		convertBoxedValue(elemType, value, out)
+	}
+	out.Print(")")
+}
+
+// convertCellDecl declares a local variable whose address is taken as a cell, the value is nil for the zero value
+func convertCellDecl(ident *ast.Ident, typeExpr ast.Expr, value ast.Expr, out *Output) bool {
+	if ident.Obj == nil || !oAddressTaken[ident.Obj] || typeExpr == nil || !isCellElem(typeExpr) {
+		return false
+	}
+	oCells[ident.Obj] = true
+	out.Print("")
+	convertCellType(typeExpr, out)
+	out.Print(" ", ident.Name, " = ")
+	convertNewCell(typeExpr, value, out)
+	convertStmtEnd(out)
+	out.AddVar(ident.Name, typeExpr)
+	return true
+}
+
+// convertCell prints the cell of a pointer or a variable declared as a cell
+func convertCell(expr ast.Expr, out *Output) {
+	if starExpr, ok := expr.(*ast.StarExpr); ok {
+		convertOperand(starExpr.X, out)
+		return
+	}
+	out.structuralInfo.CellRef = true
+	convertExpr(expr, out)
+	out.structuralInfo.CellRef = false
+}
+
+func convertCellRead(starExpr *ast.StarExpr, out *Output) bool {
+	if !isCellPointer(typeOf(starExpr.X, out)) {
+		return false
+	}
+	convertOperand(starExpr.X, out)
+	out.Print(".get()")
+	return true
+}
+
+// convertCellAssign translates *p = v, *p op= v and *p++ and the assignments of variables declared as cells
+func convertCellAssign(lhs ast.Expr, tok token.Token, rhs ast.Expr, out *Output) bool {
+	if !isCellRead(lhs, out) {
+		return false
+	}
+	value := rhs
+	if tok != token.ASSIGN {
+		value = updateExpr(lhs, tok, rhs)
+	}
+	convertCell(lhs, out)
+	out.Print(".set(")
+	convertBoxedValue(typeOf(lhs, out), value, out)
+	out.Print(")")
+	convertStmtEnd(out)
+	return true
+}
+
+// convertAddressOf translates &x of values kept in cells, a variable declared as a cell is its pointer
+func convertAddressOf(unaryExpr *ast.UnaryExpr, out *Output) bool {
+	if unaryExpr.Op != token.AND {
+		return false
+	}
+	elemType := typeOf(unaryExpr.X, out)
+	if elemType == nil || !isCellElem(elemType) {
+		return false
+	}
+	switch tp := unaryExpr.X.(type) {
+	case *ast.CompositeLit:
+		convertNewCell(elemType, tp, out)
+	case *ast.Ident:
+		if !isCellVar(tp) {
+			out.Error(unaryExpr, "the address of "+tp.Name+" cannot be taken, only local variables are declared as cells")
+		}
+		convertCell(tp, out)
+	default:
+		out.Error(unaryExpr, "the address of "+types.ExprString(tp)+" cannot be taken, only local variables are declared as cells")
+		convertExpr(tp, out)
+	}
+	return true
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// the local variables whose address is taken are cells, the pointers update the variable
+func TestAddressTakenLocals(t *testing.T) {
+	tr := translate(t, map[string]string{"app/main.go": `package main
+
+import "fmt"
+
+func inc(p *int) {
+	*p++
+}
+
+func pair() (int, error) { return 4, nil }
+
+func main() {
+	x := 1
+	inc(&x)
+	x += 10
+	var n int64 = 5
+	pn := &n
+	*pn = 7
+	y, err := pair()
+	inc(&y)
+	if x == y {
+		fmt.Println("eq")
+	}
+	fmt.Println(x, n, y, err)
+}
+`})
+	expectContains(t, tr.java(t, "app/Main.java"),
+		"GoPtr<Integer> x = new GoPtr<Integer>(1);",
+		"inc(x);",
+		"x.set(x.get() + 10);",
+		"GoPtr<Long> n = new GoPtr<Long>((long) 5);",
+		"GoPtr<Long> pn = n;",
+		"GoPtr<Integer> y = new GoPtr<Integer>(tmp1.r0);",
+		"if ((int) x.get() == (int) y.get())",
+		"Fmt.Println(x.get(), n.get(), y.get(), err);",
+	)
+}
+
+// the address of parameters, package variables, fields and range variables cannot be taken
+func TestAddressOfOtherVariables(t *testing.T) {
+	tr := translate(t, map[string]string{"app/main.go": `package main
+
+import "fmt"
+
+var count int
+
+type P struct{ n int }
+
+func inc(p *int) { *p++ }
+
+func bump(v int) { inc(&v) }
+
+func main() {
+	inc(&count)
+	p := P{}
+	inc(&p.n)
+	for _, v := range []int{1} {
+		inc(&v)
+	}
+	fmt.Println(count, p)
+}
+`})
+	if !tr.failed {
+		t.Errorf("expected the translation to fail")
+	}
+	for _, message := range []string{
+		"main.go:11:24: error: the address of v cannot be taken",
+		"main.go:14:6: error: the address of count cannot be taken",
+		"main.go:16:6: error: the address of p.n cannot be taken",
+		"main.go:18:7: error: the address of v cannot be taken",
+	} {
+		if !strings.Contains(tr.stderr, message) {
+			t.Errorf("missing %q in\n%s", message, tr.stderr)
+		}
+	}
+}
+
+// channels are not translated
+func TestChannelsError(t *testing.T) {
+	tr := translate(t, map[string]string{"app/main.go": `package main
+
+func main() {
+	c := make(chan int, 1)
+	c <- 1
+	close(c)
+}
+`})
+	if !tr.failed || !strings.Contains(tr.stderr, "main.go:4:12: error: channels are not supported") ||
+		!strings.Contains(tr.stderr, "main.go:6:2: error: close of channels is not supported") {
+		t.Errorf("expected channel errors, got\n%s", tr.stderr)
+	}
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+)
+
+// functions with several results return a tuple class holding them. return statements create
+// the tuple, a, b := f() keeps the tuple in a temporary and assigns its fields
+
+// resultTypes returns the type of every result of a function, a field with several names gives several results
+func resultTypes(funcType *ast.FuncType) []ast.Expr {
+	if funcType == nil || funcType.Results == nil {
+		return nil
+	}
+	results := []ast.Expr{}
+	for _, field := range funcType.Results.List {
+		count := len(field.Names)
+		if count == 0 {
+			count = 1
+		}
+		for i := 0; i < count; i++ {
+			results = append(results, field.Type)
+		}
+	}
+	return results
+}
+
+// resultNames returns the names of named results
+func resultNames(funcType *ast.FuncType) []*ast.Ident {
+	if funcType == nil || funcType.Results == nil {
+		return nil
+	}
+	names := []*ast.Ident{}
+	for _, field := range funcType.Results.List {
+		names = append(names, field.Names...)
+	}
+	return names
+}
+
+func useTupleClass(size int, out *Output) {
+	if className := "org/go2j/util/" + tupleClass(size) + ".java"; helperClasses[className] == "" {
+		// the larger tuples are generated when they are used
+		helperClasses[className] = tupleSource(size)
+	}
+	out.outSource.addSysImportName(tupleClass(size), "org.go2j.util."+tupleClass(size))
+}
+
+// tupleField names the field of a tuple holding a result
+func tupleField(idx int) string {
+	return "r" + strconv.Itoa(idx)
+}
+
+// convertTupleType prints the tuple class of the results, java type arguments are objects
+func convertTupleType(results []ast.Expr, out *Output) {
+	useTupleClass(len(results), out)
+	out.Print(tupleClass(len(results)), "<")
+	for idx, resultType := range results {
+		if idx > 0 {
+			out.Print(", ")
+		}
+		nrto := newResolveTypeOpts()
+		nrto.PrimitiveAsObject = true
+		convertType(resultType, out, nrto)
+	}
+	out.Print(">")
+}
+
+// callResults returns the result types of a called function when it is known
+func callResults(callExpr *ast.CallExpr, out *Output) []ast.Expr {
+	if conv, _ := apiConvOf(callExpr, out); conv != nil {
+		return conv.resultTypes(callExpr, out)
+	}
+	return resultTypes(calleeFuncType(callExpr, out))
+}
+
+// convertNamedResults declares the named results at the start of the body with their zero values
+func convertNamedResults(funcType *ast.FuncType) func(out *Output) {
+	if funcType.Results == nil {
+		return nil
+	}
+	return func(out *Output) {
+		for _, field := range funcType.Results.List {
+			for _, name := range field.Names {
+				if name.Name == "_" {
+					continue
+				}
+				nrto := newResolveTypeOpts()
+				nrto.FunctionAsReference = true
+				out.Print("")
+				convertType(field.Type, out, nrto)
+				out.Print(" ", name.Name, " = ")
+				convertZeroValue(field.Type, out)
+				convertStmtEnd(out)
+				out.AddVar(name.Name, field.Type)
+			}
+		}
+	}
+}
+
+// joinDefsFns runs the functions declaring variables at the start of a body in order
+func joinDefsFns(defsFns ...func(out *Output)) func(out *Output) {
+	return func(out *Output) {
+		for _, defsFn := range defsFns {
+			if defsFn != nil {
+				defsFn(out)
+			}
+		}
+	}
+}
+
+// convertResultsReturn returns the tuple of the results, or the named results of a bare return
+func convertResultsReturn(returnStmt *ast.ReturnStmt, out *Output) bool {
+	funcType := out.GetCurrentFuncType()
+	results := resultTypes(funcType)
+	values := returnStmt.Results
+	if len(values) == 0 {
+		for _, name := range resultNames(funcType) {
+			values = append(values, name)
+		}
+	}
+	if len(results) < 2 {
+		if len(returnStmt.Results) == 0 && len(values) == 1 {
+			out.Print("return ")
+			convertExpr(values[0], out)
+			convertStmtEnd(out)
+			return true
+		}
+		return false
+	}
+	out.Print("return ")
+	if len(values) == 1 {
+		// the results of a call with the same result types
+		convertExpr(values[0], out)
+		convertStmtEnd(out)
+		return true
+	}
+	useTupleClass(len(results), out)
+	out.Print("new ", tupleClass(len(results)), "<>(")
+	for idx, value := range values {
+		if idx > 0 {
+			out.Print(", ")
+		}
+		convertLitValue(results[idx], value, out)
+	}
+	out.Print(")")
+	convertStmtEnd(out)
+	return true
+}
+
+// convertResultsAssign translates a, b := f() to the assignments of the fields of the returned tuple
+func convertResultsAssign(assignStmt *ast.AssignStmt, out *Output) bool {
+	if len(assignStmt.Lhs) < 2 || len(assignStmt.Rhs) != 1 {
+		return false
+	}
+	callExpr, ok := assignStmt.Rhs[0].(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	results := callResults(callExpr, out)
+	if len(results) != len(assignStmt.Lhs) {
+		return false
+	}
+	if out.banStmtEnd {
+		out.Warn(assignStmt, "the results of a call cannot be assigned in a for statement: "+exprListString(assignStmt.Lhs))
+		return false
+	}
+	tmpName := newTmpVarName(out)
+	out.Print("")
+	convertTupleType(results, out)
+	out.Print(" ", tmpName, " = ")
+	convertExpr(callExpr, out)
+	convertStmtEnd(out)
+	for idx, target := range assignStmt.Lhs {
+		if isBlank(target) {
+			continue
+		}
+		value := &ast.SelectorExpr{X: ast.NewIdent(tmpName), Sel: ast.NewIdent(tupleField(idx))}
+		if isNewDef(target, assignStmt) && convertCellDecl(target.(*ast.Ident), results[idx], value, out) {
+			continue
+		}
+		if isNewDef(target, assignStmt) {
+			name := target.(*ast.Ident).Name
+			nrto := newResolveTypeOpts()
+			nrto.FunctionAsReference = true
+			out.Print("")
+			convertType(results[idx], out, nrto)
+			out.Print(" ", name, " = ")
+			convertExpr(value, out)
+			convertStmtEnd(out)
+			out.AddVar(name, results[idx])
+			continue
+		}
+		convertAssignStmt(&ast.AssignStmt{Lhs: []ast.Expr{target}, TokPos: assignStmt.TokPos, Tok: token.ASSIGN, Rhs: []ast.Expr{value}}, out)
+	}
+	return true
+}
@@ -0,0 +1,298 @@
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+)
+
+// slices are translated to the slice classes of org.go2j.util, arrays stay java arrays
+
+func isSliceType(typeExpr ast.Expr) bool {
+	arrayType, ok := underlyingType(typeExpr).(*ast.ArrayType)
+	return ok && arrayType.Len == nil
+}
+
+// sliceClass returns the runtime class of slices with the given element type
+func sliceClass(elemType ast.Expr) string {
+	if ident, ok := underlyingType(elemType).(*ast.Ident); ok {
+		if className, has := primitiveSlices[go2jType[ident.Name]]; has {
+			return className
+		}
+	}
+	return "Slice"
+}
+
+func useSliceClass(className string, out *Output) {
+	out.outSource.addSysImportName(className, "org.go2j.util."+className)
+}
+
+// sliceClassOf returns the slice class of an expression, or "" if it is not a slice
+func sliceClassOf(expr ast.Expr, out *Output) string {
+	exprType := typeOf(expr, out)
+	if !isSliceType(exprType) {
+		return ""
+	}
+	return sliceClass(sliceElemType(exprType))
+}
+
+func convertSliceType(arrayType *ast.ArrayType, out *Output, opts *ResolveTypeOpts) {
+	if !oFileSet.postEvalPhase && !opts.DirectEval {
+		// element types may be named types declared later
+		pos := out.getPosition()
+		pos.postEvalFn = func(postOut *Output) {
+			convertSliceType(arrayType, postOut, opts)
+		}
+		return
+	}
+	className := sliceClass(arrayType.Elt)
+	useSliceClass(className, out)
+	out.Print(className)
+	if className == "Slice" {
+		convertSliceTypeArg(arrayType.Elt, out)
+	}
+}
+
+func convertSliceTypeArg(elemType ast.Expr, out *Output) {
+	elemOpts := newResolveTypeOpts()
+	elemOpts.PrimitiveAsObject = true
+	elemOpts.FunctionAsReference = true
+	out.Print("<")
+	convertType(elemType, out, elemOpts)
+	out.Print(">")
+}
+
+// convertArrayAlloc allocates a zeroed java array of a fixed size array type
+func convertArrayAlloc(arrayType *ast.ArrayType, out *Output) {
+	lens := []ast.Expr{arrayType.Len}
+	elemType := arrayType.Elt
+	for {
+		innerType, ok := elemType.(*ast.ArrayType)
+		if !ok || innerType.Len == nil {
+			break
+		}
+		lens = append(lens, innerType.Len)
+		elemType = innerType.Elt
+	}
+	out.Print("new ")
+	if isSliceType(elemType) {
+		// generic array creation is not allowed in java
+		className := sliceClass(sliceElemType(elemType))
+		useSliceClass(className, out)
+		out.Print(className)
+	} else {
+		convertType(elemType, out, newResolveTypeOpts())
+	}
+	for _, lenExpr := range lens {
+		out.Print("[")
+		convertExpr(lenExpr, out)
+		out.Print("]")
+	}
+}
+
+// convertZeroInit initializes arrays, strings and struct values, slices are null like nil slices in go
+func convertZeroInit(typeExpr ast.Expr, out *Output) {
+	if isLocalDecl(out) {
+		// java requires local variables to be assigned before use
+		out.Print(" = ")
+		convertZeroValue(typeExpr, out)
+		return
+	}
+	if arrayType, ok := typeExpr.(*ast.ArrayType); ok && arrayType.Len != nil {
+		out.Print(" = ")
+		convertArrayAlloc(arrayType, out)
+		return
+	}
+	// struct types may be declared later
+	pos := out.getPosition()
+	pos.postEvalFn = func(postOut *Output) {
+		if hasObjectZero(typeExpr, postOut) {
+			postOut.Print(" = ")
+			convertZeroValue(typeExpr, postOut)
+		}
+	}
+}
+
+// convertSliceIndex translates indexing of slices to get and set calls
+func convertSliceIndex(indexExpr *ast.IndexExpr, out *Output) bool {
+	if !isSliceType(typeOf(indexExpr.X, out)) {
+		return false
+	}
+	isLeft := out.structuralInfo.AssignmentLeft
+	out.structuralInfo.AssignmentLeft = false
+	convertExpr(indexExpr.X, out)
+	if isLeft {
+		// the assignment prints the value and closes the call
+		out.Print(".set(")
+		convertExpr(indexExpr.Index, out)
+		out.structuralInfo.MapAssignment = true
+		return true
+	}
+	out.Print(".get(")
+	convertExpr(indexExpr.Index, out)
+	out.Print(")")
+	return true
+}
+
+// convertSliceUpdate translates a[i] op= v and a[i]++ to a.set(i, a.get(i) op v)
+func convertSliceUpdate(lhs ast.Expr, tok token.Token, rhs ast.Expr, out *Output) bool {
+	indexExpr, ok := lhs.(*ast.IndexExpr)
+	if !ok {
+		return false
+	}
+	indexExpr = enumIndexed(indexExpr, out)
+	className := sliceClassOf(indexExpr.X, out)
+	if className == "" {
+		return false
+	}
+	indexExpr = evalOnce(indexExpr, out)
+	convertExpr(indexExpr.X, out)
+	out.Print(".set(")
+	convertExpr(indexExpr.Index, out)
+	out.Print(", ")
+	convertSliceElem(className, updateExpr(indexExpr, tok, rhs), out)
+	out.Print(")")
+	convertStmtEnd(out)
+	return true
+}
+
+// convertSliceExpr translates a[low:high:max], slicing an array shares the array
+func convertSliceExpr(sliceExpr *ast.SliceExpr, out *Output) {
+	xType := typeOf(sliceExpr.X, out)
+	if starType, ok := xType.(*ast.StarExpr); ok {
+		xType = starType.X
+	}
+	if arrayType, ok := underlyingType(xType).(*ast.ArrayType); ok && arrayType.Len != nil {
+		className := sliceClass(arrayType.Elt)
+		useSliceClass(className, out)
+		out.Print(className, ".wrap(")
+		convertExpr(sliceExpr.X, out)
+		out.Print(")")
+	} else {
+		convertExpr(sliceExpr.X, out)
+	}
+	out.Print(".slice(")
+	if sliceExpr.Low != nil {
+		convertExpr(sliceExpr.Low, out)
+	} else {
+		out.Print("0")
+	}
+	if sliceExpr.High != nil {
+		out.Print(", ")
+		convertExpr(sliceExpr.High, out)
+	}
+	if sliceExpr.Max != nil {
+		out.Print(", ")
+		convertExpr(sliceExpr.Max, out)
+	}
+	out.Print(")")
+}
+
+// convertSliceElem converts a value stored in a slice, byte and short elements need a cast in java
+func convertSliceElem(className string, arg ast.Expr, out *Output) {
+	castType := ""
+	switch className {
+	case "ByteSlice":
+		castType = "byte"
+	case "ShortSlice":
+		castType = "short"
+	}
+	if castType == "" || javaPrimitiveOf(typeOf(arg, out)) == castType {
+		convertExpr(arg, out)
+		return
+	}
+	out.Print("(", castType, ") ")
+	if _, isBinary := arg.(*ast.BinaryExpr); isBinary {
+		out.Print("(")
+		convertExpr(arg, out)
+		out.Print(")")
+	} else {
+		convertExpr(arg, out)
+	}
+}
+
+// convertSliceCall translates len, cap, append, copy and clear of slices
+func convertSliceCall(callExpr *ast.CallExpr, name string, out *Output) bool {
+	args := callExpr.Args
+	className := sliceClassOf(args[0], out)
+	if className == "" {
+		if name != "append" {
+			return false
+		}
+		// the element type is unknown
+		className = "Slice"
+	}
+	useSliceClass(className, out)
+	out.Print(className, ".")
+	switch {
+	case name == "append" && callExpr.Ellipsis.IsValid() && len(args) == 2:
+		if className == "ByteSlice" && isStringType(typeOf(args[1], out)) {
+			out.Print("appendString(")
+		} else {
+			out.Print("appendSlice(")
+		}
+		convertExpr(args[0], out)
+		out.Print(", ")
+		convertExpr(args[1], out)
+		out.Print(")")
+		return true
+	case name == "copy" && len(args) == 2 && className == "ByteSlice" && isStringType(typeOf(args[1], out)):
+		out.Print("copyString(")
+	default:
+		out.Print(name, "(")
+	}
+	for idx, arg := range args {
+		if idx > 0 {
+			out.Print(", ")
+		}
+		if name == "append" && idx > 0 {
+			convertSliceElem(className, arg, out)
+		} else {
+			convertExpr(arg, out)
+		}
+	}
+	out.Print(")")
+	return true
+}
+
+// convertSliceRangeStmt iterates a slice with the Range class of its slice class
+func convertSliceRangeStmt(rangeStmt *ast.RangeStmt, out *Output) bool {
+	xType := typeOf(rangeStmt.X, out)
+	if !isSliceType(xType) {
+		return false
+	}
+	elemType := sliceElemType(xType)
+	className := sliceClass(elemType)
+	useSliceClass(className, out)
+	// nested loops need distinct names
+	rangeName := "range" + strconv.Itoa(out.tabs)
+	out.Print("for (", className, ".Range")
+	if className == "Slice" {
+		convertSliceTypeArg(elemType, out)
+	}
+	out.Print(" ", rangeName, " = ", className, ".range(")
+	convertExpr(rangeStmt.X, out)
+	out.Print("); ", rangeName, ".next();)")
+	isDef := rangeStmt.Tok == token.DEFINE
+	convertBlockStmt(rangeStmt.Body, out, func(out *Output) {
+		if name := rangeVarName(rangeStmt.Key); name != "" {
+			if isDef {
+				out.Print("int ")
+				out.AddVar(name, ast.NewIdent("int"))
+			}
+			out.Println(name, "=", rangeName+".index;")
+		}
+		if name := rangeVarName(rangeStmt.Value); name != "" {
+			if isDef {
+				out.Print("")
+				nrto := newResolveTypeOpts()
+				nrto.FunctionAsReference = true
+				convertType(elemType, out, nrto)
+				out.Print(" ")
+				out.AddVar(name, elemType)
+			}
+			out.Println(name, "=", rangeName+".value;")
+		}
+	})
+	return true
+}
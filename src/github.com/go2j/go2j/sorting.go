@@ -0,0 +1,56 @@
+package main
+
+import "go/ast"
+
+// sort.Interface is the Interface of the GoSort runtime, types with its Len, Less and Swap methods
+// implement it. sort, slices, maps and cmp are translated to GoSort, GoSlices and GoMaps calls, the
+// less and compare functions are lambdas
+
+const sortInterfaceName = "GoSort.Interface"
+
+// the go signatures of the methods of sort.Interface
+var sortInterfaceMethods = map[string]string{
+	"Len":  "() (int)",
+	"Less": "(int, int) (bool)",
+	"Swap": "(int, int) ()",
+}
+
+// declareSortInterface declares GoSort.Interface for the types with the methods of sort.Interface
+func declareSortInterface(key string) {
+	methods := oTypes.methodSet(key)
+	for name, signature := range sortInterfaceMethods {
+		if methods[name] != signature {
+			return
+		}
+	}
+	oTypes.declareImplements(key, sortInterfaceName)
+	outSource := oTypes.getImplementsPos(key).origOut.outSource
+	outSource.addSysImportName(JI_GO_SORT.typeName, JI_GO_SORT.qualifiedName)
+}
+
+func isSortInterfaceMethod(interfaceName, methodName string) bool {
+	_, has := sortInterfaceMethods[methodName]
+	return has && interfaceName == sortInterfaceName
+}
+
+func isSortInterfaceType(typeExpr ast.Expr) bool {
+	_, isSelector := typeExpr.(*ast.SelectorExpr)
+	return isSelector && resolveTypeName(typeExpr, false) == "sort.Interface"
+}
+
+// the sort functions taking a sort.Interface, values of named types are passed in their class
+var sortInterfaceFuncs = map[string]bool{
+	"sort.Sort": true, "sort.Stable": true, "sort.IsSorted": true, "sort.Reverse": true,
+}
+
+func convertSortInterfaceArg(callExpr *ast.CallExpr, idx int, out *Output) bool {
+	if !sortInterfaceFuncs[resolveTypeName(callExpr.Fun, false)] {
+		return false
+	}
+	classType := valueClassOf(callExpr.Args[idx], out)
+	if classType == nil {
+		return false
+	}
+	convertNewValue(classType, callExpr.Args[idx], out)
+	return true
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"go/ast"
+	"strings"
+)
+
+// go assigns and passes structs and arrays by value, java copies their references. the struct classes
+// implement GoObjects.Value, their copy$ method copies the fields of struct and array types, and the
+// values read from variables are copied where go copies them. a struct and a pointer to it are the
+// same java object, the classes of structs converted to interfaces as pointers implement
+// GoObjects.Pointer and interfaces compare them by identity
+
+const valueInterfaceName = "GoObjects.Value"
+const pointerInterfaceName = "GoObjects.Pointer"
+
+// oInterfacePointers holds the keys of the structs converted to interfaces as pointers
+var oInterfacePointers = map[string]bool{}
+
+// oInterfaceValues warns about a conversion of a struct value to an interface by the key of the struct
+var oInterfaceValues = map[string]func(){}
+
+// structKeyOf returns the key of a struct type of the project
+func structKeyOf(typeExpr ast.Expr) string {
+	key := ""
+	switch tp := typeExpr.(type) {
+	case *ast.Ident:
+		key = resolveKey(strings.Title(tp.Name))
+	case *ast.SelectorExpr:
+		if ident, ok := tp.X.(*ast.Ident); !ok || ownPackagePath(ident.Name, "") == "" {
+			return ""
+		}
+		key = resolveKey(typeKeyIn(tp, ""))
+	default:
+		return ""
+	}
+	if outType := oTypes.get(key); outType == nil || outType.structType == nil || outType.system {
+		return ""
+	}
+	return key
+}
+
+// recordInterfaceValue notes whether a struct is converted to an interface as a value or a pointer
+func recordInterfaceValue(expr ast.Expr, out *Output) {
+	valueType := typeOf(expr, out)
+	if starExpr, isPointer := valueType.(*ast.StarExpr); isPointer {
+		if key := structKeyOf(starExpr.X); key != "" {
+			oInterfacePointers[key] = true
+		}
+		return
+	}
+	if key := structKeyOf(valueType); key != "" && oInterfaceValues[key] == nil {
+		oInterfaceValues[key] = func() {
+			out.Warn(expr, "the values of "+typeKeyName(key)+" in interfaces are compared by identity like its pointers")
+		}
+	}
+}
+
+// declareValueInterface declares GoObjects.Value for the classes of structs, and GoObjects.Pointer
+// for the structs converted to interfaces as pointers
+func declareValueInterface(key string) {
+	if outType := oTypes.get(key); outType == nil || outType.structType == nil {
+		return
+	}
+	oTypes.declareImplements(key, valueInterfaceName)
+	useGoObjects(oTypes.getImplementsPos(key).origOut.outSource)
+	if !oInterfacePointers[key] {
+		return
+	}
+	oTypes.declareImplements(key, pointerInterfaceName)
+	if warn := oInterfaceValues[key]; warn != nil {
+		warn()
+	}
+}
+
+func useGoObjects(outSource *OutSource) {
+	outSource.addSysImportName(JI_GO_OBJECTS.typeName, JI_GO_OBJECTS.qualifiedName)
+}
+
+// isValueType tells whether the values of a type are structs or arrays with a java class
+func isValueType(typeExpr ast.Expr) bool {
+	if _, isLiteral := typeExpr.(*ast.StructType); isLiteral {
+		// anonymous structs have no class
+		return false
+	}
+	switch tp := underlyingType(typeExpr).(type) {
+	case *ast.StructType:
+		return true
+	case *ast.ArrayType:
+		return tp.Len != nil
+	}
+	return false
+}
+
+// isVariableRead tells whether an expression reads a variable, composite literals and the results
+// of calls are new values
+func isVariableRead(expr ast.Expr) bool {
+	switch tp := expr.(type) {
+	case *ast.ParenExpr:
+		return isVariableRead(tp.X)
+	case *ast.Ident:
+		return tp.Name != "nil" && (tp.Obj == nil || tp.Obj.Kind == ast.Var)
+	case *ast.SelectorExpr, *ast.IndexExpr, *ast.StarExpr:
+		return true
+	}
+	return false
+}
+
+// valueCopy returns the java code around a struct or an array value copying it
+func valueCopy(typeExpr ast.Expr, outSource *OutSource) (string, string) {
+	if arrayType, isArray := underlyingType(typeExpr).(*ast.ArrayType); isArray && !isValueType(arrayType.Elt) {
+		return "", ".clone()"
+	}
+	if _, isStruct := underlyingType(typeExpr).(*ast.StructType); isStruct {
+		return "", ".copy$()"
+	}
+	useGoObjects(outSource)
+	return "GoObjects.copy(", ")"
+}
+
+// convertValueCopy prints a copy of a struct or an array read from a variable, typeExpr is the type
+// of the variable, parameter or element it is assigned to. it returns false if the value is not copied
+func convertValueCopy(typeExpr ast.Expr, expr ast.Expr, out *Output) bool {
+	if typeExpr == nil {
+		typeExpr = typeOf(expr, out)
+	}
+	if typeExpr == nil || !isValueType(typeExpr) || !isVariableRead(expr) {
+		return false
+	}
+	prefix, suffix := valueCopy(typeExpr, out.outSource)
+	out.Print(prefix)
+	convertExpr(expr, out)
+	out.Print(suffix)
+	return true
+}
+
+// convertStructCopy generates the copy$ method of GoObjects.Value
+func convertStructCopy(structType *ast.StructType, className string, out *Output) {
+	values := []string{}
+	for _, field := range equalityFields(structType) {
+		value := "this." + field.name
+		if isValueType(field.typeExpr) {
+			prefix, suffix := valueCopy(field.typeExpr, out.outSource)
+			value = prefix + value + suffix
+		}
+		values = append(values, value)
+	}
+	out.Println("@Override")
+	out.Println("public " + className + " copy$() {")
+	out.AddTab().Println("return new " + className + "(" + strings.Join(values, ", ") + ");")
+	out.Println("}")
+	out.Println("")
+}
@@ -0,0 +1,72 @@
+package main
+
+import "testing"
+
+// structs and arrays are copied when go copies them: assignments, arguments, returns, map keys and elements
+func TestValueCopies(t *testing.T) {
+	tr := translate(t, map[string]string{"app/main.go": `package main
+
+import "fmt"
+
+type Point struct {
+	X, Y int
+}
+
+type Line struct {
+	From, To Point
+	Tags     [2]string
+	Path     [2]Point
+	Next     *Line
+}
+
+func move(p Point, grid [3]int) Point {
+	p.X++
+	grid[0] = p.X
+	return p
+}
+
+func first(l *Line) Point {
+	return l.From
+}
+
+func main() {
+	a := Point{1, 2}
+	b := a
+	var c Point = a
+	var d = a
+	b = c
+	arr := [3]int{1, 2, 3}
+	arr2 := arr
+	move(a, arr)
+	l := Line{From: a, To: b}
+	seen := map[Point]int{a: 1}
+	seen[b] = 2
+	points := []Point{a, d}
+	points[1] = b
+	fmt.Println(b, c, d, arr2, first(&l), seen, points, Point{3, 4})
+}
+`})
+	expectContains(t, tr.java(t, "app/Main.java"),
+		"Point b = a.copy$();",
+		"Point c = a.copy$();",
+		"Point d = a.copy$();",
+		"b = c.copy$();",
+		"int[] arr2 = arr.clone();",
+		"move(a.copy$(), arr.clone());",
+		"new Line(a.copy$(), b.copy$(),",
+		".put(a.copy$(), 1)",
+		"GoMap.put(seen, b.copy$(), 2);",
+		"Slice.<Point>of(a.copy$(), d.copy$())",
+		"points.set(1, b.copy$());",
+		"return l.From.copy$();",
+		"Fmt.Println(b, c, d, (Object) arr2, first(l), seen, points, new Point(3, 4));",
+	)
+	expectContains(t, tr.java(t, "app/Point.java"),
+		"public class Point implements GoObjects.Value {",
+		"return new Point(this.X, this.Y);",
+	)
+	expectContains(t, tr.java(t, "app/Line.java"),
+		"public Line copy$() {",
+		"return new Line(this.From.copy$(), this.To.copy$(), this.Tags.clone(), GoObjects.copy(this.Path), this.Next);",
+	)
+}
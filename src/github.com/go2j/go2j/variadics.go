@@ -0,0 +1,161 @@
+package main
+
+import (
+	"go/ast"
+	"strings"
+)
+
+// variadic parameters stay java varargs, so java code calls them naturally.
+// the body wraps the array into the go slice, f(s...) passes the array of the slice
+
+// functions of the packages by package key and name, calls convert the variadic arguments to the element type
+var oPackageFuncs = map[string]*ast.FuncType{}
+
+func packageFuncKey(pkgName, name string) string {
+	return pkgName + "." + name
+}
+
+// variadicParam returns the ellipsis of the last parameter of a variadic function type
+func variadicParam(funcType *ast.FuncType) *ast.Ellipsis {
+	if funcType == nil || funcType.Params == nil || len(funcType.Params.List) == 0 {
+		return nil
+	}
+	ellipsis, _ := funcType.Params.List[len(funcType.Params.List)-1].Type.(*ast.Ellipsis)
+	return ellipsis
+}
+
+// varargsName names the java varargs array of a variadic parameter
+func varargsName(name string) string {
+	return name + "Args"
+}
+
+// convertVariadicParams declares the go slice of the variadic parameter at the start of the body
+func convertVariadicParams(funcType *ast.FuncType) func(out *Output) {
+	ellipsis := variadicParam(funcType)
+	if ellipsis == nil {
+		return nil
+	}
+	field := funcType.Params.List[len(funcType.Params.List)-1]
+	return func(out *Output) {
+		sliceType := &ast.ArrayType{Elt: ellipsis.Elt}
+		className := sliceClass(ellipsis.Elt)
+		useSliceClass(className, out)
+		for _, name := range field.Names {
+			if name.Name == "_" {
+				continue
+			}
+			nrto := newResolveTypeOpts()
+			nrto.FunctionAsReference = true
+			out.Print("")
+			convertType(sliceType, out, nrto)
+			out.Println("", name.Name, "=", className+".wrap("+varargsName(name.Name)+");")
+			out.AddVar(name.Name, sliceType)
+		}
+	}
+}
+
+// calleeFuncType returns the declaration of a called function or method when it is known
+func calleeFuncType(callExpr *ast.CallExpr, out *Output) *ast.FuncType {
+	switch fun := callExpr.Fun.(type) {
+	case *ast.Ident:
+		if out.GetVarType(fun.Name) == nil {
+			return oPackageFuncs[packageFuncKey(currentPackageKey(), fun.Name)]
+		}
+	case *ast.SelectorExpr:
+		if ident, ok := fun.X.(*ast.Ident); ok && isImportName(ident.Name, out) {
+			return oPackageFuncs[packageFuncKey(out.outSource.importedPackages[strings.Title(ident.Name)], fun.Sel.Name)]
+		}
+		if xType := typeOf(fun.X, out); xType != nil {
+			if funcType := methodFuncType(xType, fun.Sel.Name); funcType != nil {
+				return funcType
+			}
+		}
+	}
+	if funcType, ok := underlyingType(typeOf(callExpr.Fun, out)).(*ast.FuncType); ok {
+		return funcType
+	}
+	return nil
+}
+
+// convertCallArg converts an argument of a call, spread slices pass their array, variadic arguments are converted to the element type
+func convertCallArg(callExpr *ast.CallExpr, idx int, funcType *ast.FuncType, out *Output) {
+	arg := callExpr.Args[idx]
+	if callExpr.Ellipsis.IsValid() && idx == len(callExpr.Args)-1 {
+		convertSpreadArg(arg, out)
+		return
+	}
+	ellipsis := variadicParam(funcType)
+	if ellipsis == nil || idx < paramCount(funcType)-1 {
+		if funcType != nil {
+			recordConversion(arg, fieldTypeAt(funcType.Params, idx), out)
+		}
+		if funcType == nil || !convertAssignedValue(fieldTypeAt(funcType.Params, idx), arg, true, out) {
+			convertExpr(arg, out)
+		}
+		return
+	}
+	if isNilIdent(arg) && len(callExpr.Args) == paramCount(funcType) {
+		// a single nil is an element, not the array
+		out.Print("(")
+		convertType(ellipsis.Elt, out, newResolveTypeOpts())
+		out.Print(") null")
+		return
+	}
+	convertLitValue(ellipsis.Elt, arg, out)
+}
+
+// fieldTypeAt returns the type of the idx-th parameter or result of a field list
+func fieldTypeAt(fieldList *ast.FieldList, idx int) ast.Expr {
+	if fieldList == nil {
+		return nil
+	}
+	for _, field := range fieldList.List {
+		count := len(field.Names)
+		if count == 0 {
+			count = 1
+		}
+		if idx < count {
+			return field.Type
+		}
+		idx -= count
+	}
+	return nil
+}
+
+func resultTypeAt(funcType *ast.FuncType, idx int) ast.Expr {
+	if funcType == nil {
+		return nil
+	}
+	return fieldTypeAt(funcType.Results, idx)
+}
+
+func paramCount(funcType *ast.FuncType) int {
+	count := 0
+	for _, field := range funcType.Params.List {
+		if len(field.Names) == 0 {
+			count++
+		}
+		count += len(field.Names)
+	}
+	return count
+}
+
+// convertSpreadArg passes the array of a slice to java varargs
+func convertSpreadArg(arg ast.Expr, out *Output) {
+	argType := typeOf(arg, out)
+	if isNilIdent(arg) || !isSliceType(argType) {
+		convertExpr(arg, out)
+		return
+	}
+	elemType := sliceElemType(argType)
+	className := sliceClass(elemType)
+	useSliceClass(className, out)
+	out.Print(className, ".spread(")
+	convertExpr(arg, out)
+	if className == "Slice" {
+		out.Print(", new ")
+		convertErasedType(elemType, out)
+		out.Print("[0]")
+	}
+	out.Print(")")
+}